@@ -3,8 +3,8 @@ package api
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 	"time"
 
@@ -13,10 +13,16 @@ import (
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 	_ "github.com/lib/pq"
 )
 
+const dateLayout = "2006-01-02"
+
+var (
+	errWrongPassword     = errors.New("Old password is incorrect")
+	errFutureDateOfBirth = errors.New("Date of birth must be in the past")
+)
+
 type createAccountReq struct {
 	Name     string  `json:"name" binding:"required,min=3"`
 	Email    string  `json:"email" binding:"required,email"`
@@ -26,24 +32,48 @@ type createAccountReq struct {
 }
 
 type accountResp struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Weight    float32   `json:"weight"`
-	BodyFat   float32   `json:"body_fat"`
-	StartDate time.Time `json:"start_date"`
+	ID            uuid.UUID       `json:"id"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	Weight        float32         `json:"weight"`
+	BodyFat       float32         `json:"body_fat"`
+	StartDate     time.Time       `json:"start_date"`
+	EmailVerified bool            `json:"email_verified"`
+	DateOfBirth   *time.Time      `json:"date_of_birth"`
+	Height        *float64        `json:"height"`
+	Preferences   json.RawMessage `json:"preferences,omitempty"`
+}
+
+func newAccountResp(account db.Account) accountResp {
+	res := accountResp{
+		ID:            account.ID,
+		Name:          account.Name,
+		Email:         account.Email,
+		Weight:        account.Weight,
+		BodyFat:       account.BodyFat,
+		StartDate:     account.StartDate,
+		EmailVerified: account.EmailVerifiedAt.Valid,
+		Preferences:   account.Preferences,
+	}
+	if account.DateOfBirth.Valid {
+		res.DateOfBirth = &account.DateOfBirth.Time
+	}
+	if account.Height.Valid {
+		res.Height = &account.Height.Float64
+	}
+	return res
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
 	var req createAccountReq
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	hashedPassword, err := util.HashPassword(req.Password)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -57,28 +87,16 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	account, err := server.store.CreateAccount(ctx, args)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-			log.Println(pqErr.Code.Name())
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	res := accountResp{
-		ID:        account.ID,
-		Name:      account.Name,
-		StartDate: account.StartDate,
-		BodyFat:   account.BodyFat,
-		Weight:    account.Weight,
-		Email:     account.Email,
+	// the account is usable without the email, it can be requested again
+	if err := server.sendVerification(ctx, account.ID, account.Email); err != nil {
+		ctx.Error(err)
 	}
 
-	ctx.JSON(http.StatusOK, res)
+	ctx.JSON(http.StatusOK, newAccountResp(account))
 }
 
 type getAccountReq struct {
@@ -88,55 +106,54 @@ type getAccountReq struct {
 func (server *Server) getAccount(ctx *gin.Context) {
 	var req getAccountReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := uuid.Parse(req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusNotAcceptable, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	account, err := server.store.GetAccount(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.ID != authPayload.UserID {
-		err := errors.New("This account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if !authorizeUser(ctx, account.ID) {
 		return
 	}
 
-	res := accountResp{
-		ID:        account.ID,
-		Name:      account.Name,
-		StartDate: account.StartDate,
-		BodyFat:   account.BodyFat,
-		Weight:    account.Weight,
-		Email:     account.Email,
-	}
-
-	ctx.JSON(http.StatusOK, res)
+	ctx.JSON(http.StatusOK, newAccountResp(account))
 }
 
 type listAccountsReq struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+	Cursor   string `form:"cursor"`
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type accountCursor struct {
+	ID uuid.UUID `json:"id"`
 }
 
 func (server *Server) listAccounts(ctx *gin.Context) {
 	var req listAccountsReq
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pageArgs(req.PageID, req.PageSize, req.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -144,51 +161,262 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 
 	args := db.ListAccountsParams{
 		ID:     authPayload.UserID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if req.Cursor != "" {
+		var after accountCursor
+		if err := decodeCursor(req.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		args.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
 	}
 
 	accounts, err := server.store.ListAccounts(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	res := make([]accountResp, len(accounts))
 	for i, v := range accounts {
-		res[i] = accountResp{
-			Name:      v.Name,
-			ID:        v.ID,
-			Email:     v.Email,
-			Weight:    v.Weight,
-			BodyFat:   v.BodyFat,
-			StartDate: v.StartDate,
+		res[i] = newAccountResp(v)
+	}
+
+	ctx.JSON(http.StatusOK, newListResp(res, req.PageSize, func(account accountResp) interface{} {
+		return accountCursor{ID: account.ID}
+	}))
+}
+
+// updateAccountReq only changes the fields that are sent. Preferences are
+// merged into the stored ones and a key set to null is removed.
+type updateAccountReq struct {
+	Name        *string                `json:"name" binding:"omitempty,min=3"`
+	Email       *string                `json:"email" binding:"omitempty,email"`
+	Weight      *float32               `json:"weight" binding:"omitempty,gt=0"`
+	BodyFat     *float32               `json:"body_fat" binding:"omitempty,min=0,max=100"`
+	StartDate   *string                `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	DateOfBirth *string                `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	Height      *float32               `json:"height" binding:"omitempty,gt=0,max=300"`
+	Preferences map[string]interface{} `json:"preferences"`
+}
+
+func (req updateAccountReq) params(id uuid.UUID) (db.UpdateAccountParams, error) {
+	args := db.UpdateAccountParams{ID: id, Preferences: json.RawMessage("{}")}
+
+	if req.Name != nil {
+		args.Name = sql.NullString{String: *req.Name, Valid: true}
+	}
+
+	if req.Email != nil {
+		args.Email = sql.NullString{String: *req.Email, Valid: true}
+	}
+
+	if req.Weight != nil {
+		args.Weight = sql.NullFloat64{Float64: float64(*req.Weight), Valid: true}
+	}
+
+	if req.BodyFat != nil {
+		args.BodyFat = sql.NullFloat64{Float64: float64(*req.BodyFat), Valid: true}
+	}
+
+	if req.StartDate != nil {
+		startDate, err := time.Parse(dateLayout, *req.StartDate)
+		if err != nil {
+			return args, err
+		}
+		args.StartDate = sql.NullTime{Time: startDate, Valid: true}
+	}
+
+	if req.DateOfBirth != nil {
+		dateOfBirth, err := time.Parse(dateLayout, *req.DateOfBirth)
+		if err != nil {
+			return args, err
+		}
+		if !dateOfBirth.Before(time.Now()) {
+			return args, errFutureDateOfBirth
+		}
+		args.DateOfBirth = sql.NullTime{Time: dateOfBirth, Valid: true}
+	}
+
+	if req.Height != nil {
+		args.Height = sql.NullFloat64{Float64: float64(*req.Height), Valid: true}
+	}
+
+	if req.Preferences != nil {
+		preferences, err := json.Marshal(req.Preferences)
+		if err != nil {
+			return args, err
+		}
+		args.Preferences = preferences
+	}
+
+	return args, nil
+}
+
+// updateAccount changes the profile of the authenticated account. A new
+// email has to be verified again, so only a logged in session may change it.
+func (server *Server) updateAccount(ctx *gin.Context) {
+	var uri getAccountReq
+	var req updateAccountReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, id) {
+		return
+	}
+
+	if _, ok := ctx.Get(apiKeyScopesKey); ok && req.Email != nil {
+		respondError(ctx, http.StatusForbidden, errSessionRequired)
+		return
+	}
+
+	args, err := req.params(id)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := server.store.UpdateAccountTx(ctx, args)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, err)
+			return
 		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// like at sign up the change is kept if the email fails to send, it can
+	// be requested again
+	if res.EmailChanged {
+		if err := server.sendVerification(ctx, res.Account.ID, res.Account.Email); err != nil {
+			ctx.Error(err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, newAccountResp(res.Account))
+}
+
+type changePasswordReq struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6,nefield=OldPassword"`
+}
+
+// changePassword returns a fresh token since the one used for the request is
+// rejected by authenticationMiddleware once password_changed_at moves past it.
+func (server *Server) changePassword(ctx *gin.Context) {
+	var uri getAccountReq
+	var req changePasswordReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, id) {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := util.ValidatePassword(req.OldPassword, account.Password); err != nil {
+		respondError(ctx, http.StatusForbidden, errWrongPassword)
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	changedAt := time.Now().UTC()
+	err = server.store.UpdatePassword(ctx, db.UpdatePasswordParams{
+		Password:          hashedPassword,
+		PasswordChangedAt: changedAt,
+		ID:                account.ID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = server.store.RevokeAPIKeys(ctx, db.RevokeAPIKeysParams{
+		RevokedAt: sql.NullTime{Time: changedAt, Valid: true},
+		UserID:    account.ID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jwt, err := server.tokenCreator.CreateToken(account.ID, server.config.AccessDuration)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
 	}
 
-	ctx.JSON(http.StatusOK, res)
+	ctx.JSON(http.StatusOK, LoginRes{
+		JWT: jwt,
+		User: UserRes{
+			ID:                account.ID,
+			Email:             account.Email,
+			PasswordChangedAt: changedAt,
+			StartDate:         account.StartDate,
+		},
+	})
 }
 
 func (server *Server) deleteAccount(ctx *gin.Context) {
 	var req getAccountReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := uuid.Parse(req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusNotAcceptable, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	acc, err := server.store.DeleteAccount(context.Background(), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
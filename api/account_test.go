@@ -11,15 +11,113 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
 	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/mail"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
+func TestCreateAccount(t *testing.T) {
+	account := generateRandAccount(uuid.New())
+	password := util.RandomString(10)
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": account.Name, "email": account.Email, "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAccountParams) (db.Account, error) {
+						require.Equal(t, account.Email, arg.Email)
+						require.NoError(t, util.ValidatePassword(password, arg.Password))
+						return account, nil
+					})
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateEmailVerificationParams) (db.EmailVerification, error) {
+						require.Equal(t, account.ID, arg.UserID)
+						require.WithinDuration(t, time.Now().Add(defaultVerifyDuration), arg.ExpiresAt, time.Second)
+						return db.EmailVerification{TokenHash: arg.TokenHash, UserID: arg.UserID, ExpiresAt: arg.ExpiresAt}, nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateAccountResponse(t, recorder.Body, account)
+				require.Len(t, mailer.Sent(), 1)
+				require.Equal(t, account.Email, mailer.Sent()[0].To)
+			},
+		},
+		{
+			name: "VerificationNotStored",
+			body: gin.H{"name": account.Name, "email": account.Email, "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(1).Return(db.EmailVerification{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+		{
+			name: "DuplicateEmail",
+			body: gin.H{"name": account.Name, "email": account.Email, "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, &pq.Error{Code: "23505"})
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"name": account.Name, "email": "not-an-email", "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder, server.mailer.(*mail.MemoryMailer))
+		})
+	}
+}
+
 func TestGetAccount(t *testing.T) {
 	userID := uuid.New()
 	account := generateRandAccount(userID)
@@ -109,17 +207,14 @@ func TestGetAccount(t *testing.T) {
 }
 
 func TestListAccounts(t *testing.T) {
-	var lastAccount db.Account
-	userID := uuid.New()
+	account := generateRandAccount(uuid.New())
+	accounts := []db.Account{account}
 	n := 5
-	accounts := make([]db.Account, n)
-	for i := 0; i < n; i++ {
-		lastAccount = generateRandAccount(userID)
-	}
 
 	type Query struct {
 		pageID   int
 		pageSize int
+		cursor   string
 	}
 
 	testCases := []struct {
@@ -136,12 +231,12 @@ func TestListAccounts(t *testing.T) {
 				pageSize: n,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
-				addAuthHeader(t, request, tokenCreator, bearerType, lastAccount.ID, time.Minute)
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListAccountsParams{
-					ID:     lastAccount.ID,
-					Limit:  int32(n),
+					ID:     account.ID,
+					Limit:  int32(n) + 1,
 					Offset: 0,
 				}
 
@@ -149,7 +244,70 @@ func TestListAccounts(t *testing.T) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				validateAccountsResponse(t, recorder.Body, accounts)
+
+				res := decodeListResp[accountResp](t, recorder.Body)
+				require.Equal(t, []accountResp{newAccountResp(account)}, res.Data)
+				require.False(t, res.HasMore)
+				require.Empty(t, res.NextCursor)
+			},
+		},
+		{
+			name: "Cursor",
+			query: Query{
+				pageSize: n,
+				cursor:   encodeCursor(accountCursor{ID: account.ID}),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListAccountsParams{
+					ID:      account.ID,
+					AfterID: uuid.NullUUID{UUID: account.ID, Valid: true},
+					Limit:   int32(n) + 1,
+				}
+
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Account{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[accountResp](t, recorder.Body)
+				require.Empty(t, res.Data)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name: "InvalidCursor",
+			query: Query{
+				pageSize: n,
+				cursor:   "not a cursor",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "CursorWithPageID",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				cursor:   encodeCursor(accountCursor{ID: account.ID}),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
@@ -159,7 +317,7 @@ func TestListAccounts(t *testing.T) {
 				pageSize: n,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
-				addAuthHeader(t, request, tokenCreator, bearerType, lastAccount.ID, time.Minute)
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{}, sql.ErrConnDone)
@@ -175,7 +333,7 @@ func TestListAccounts(t *testing.T) {
 				pageSize: n,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
-				addAuthHeader(t, request, tokenCreator, bearerType, lastAccount.ID, time.Minute)
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
@@ -187,11 +345,11 @@ func TestListAccounts(t *testing.T) {
 		{
 			name: "InvalidPageSize",
 			query: Query{
-				pageID:   -1,
+				pageID:   1,
 				pageSize: 10000,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
-				addAuthHeader(t, request, tokenCreator, bearerType, lastAccount.ID, time.Minute)
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
@@ -203,8 +361,8 @@ func TestListAccounts(t *testing.T) {
 		{
 			name: "Unauthorized",
 			query: Query{
-				pageID:   -1,
-				pageSize: 10000,
+				pageID:   1,
+				pageSize: n,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
@@ -235,7 +393,12 @@ func TestListAccounts(t *testing.T) {
 			require.NoError(t, err)
 
 			qParams := req.URL.Query()
-			qParams.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			if tc.query.pageID != 0 {
+				qParams.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			}
+			if tc.query.cursor != "" {
+				qParams.Add("cursor", tc.query.cursor)
+			}
 			qParams.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
 			req.URL.RawQuery = qParams.Encode()
 
@@ -246,6 +409,423 @@ func TestListAccounts(t *testing.T) {
 	}
 }
 
+func TestUpdateAccount(t *testing.T) {
+	userID := uuid.New()
+	account := generateRandAccount(userID)
+	account.Preferences = json.RawMessage(`{"units":"kg"}`)
+
+	updated := account
+	updated.Name = util.RandomString(10)
+	updated.DateOfBirth = sql.NullTime{Time: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), Valid: true}
+	updated.Height = sql.NullFloat64{Float64: 180, Valid: true}
+
+	newEmail := util.RandomEmail()
+	emailChanged := account
+	emailChanged.Email = newEmail
+
+	withSession := func(t *testing.T, request *http.Request, server *Server, key string) {
+		addAuthHeader(t, request, server.tokenCreator, bearerType, userID, time.Minute)
+	}
+	withAPIKey := func(t *testing.T, request *http.Request, server *Server, key string) {
+		request.Header.Set(authorizationHeaderKey, bearerType+" "+key)
+	}
+
+	testCases := []struct {
+		name          string
+		accountID     uuid.UUID
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, server *Server, key string)
+		buildStubs    func(store *mockdb.MockStore, apiKey db.ApiKey)
+		checkRes      func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer)
+	}{
+		{
+			name:      "OK",
+			accountID: userID,
+			body: gin.H{
+				"name":          updated.Name,
+				"date_of_birth": "1990-05-17",
+				"height":        180,
+				"preferences":   gin.H{"units": "kg", "theme": nil},
+			},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				args := db.UpdateAccountParams{
+					Name:        sql.NullString{String: updated.Name, Valid: true},
+					DateOfBirth: updated.DateOfBirth,
+					Height:      updated.Height,
+					Preferences: json.RawMessage(`{"theme":null,"units":"kg"}`),
+					ID:          userID,
+				}
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Eq(args)).Times(1).
+					Return(db.UpdateAccountTxResult{Account: updated}, nil)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, mailer.Sent())
+
+				var res accountResp
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, updated.Name, res.Name)
+				require.Equal(t, account.Email, res.Email)
+				require.NotNil(t, res.DateOfBirth)
+				require.True(t, updated.DateOfBirth.Time.Equal(*res.DateOfBirth))
+				require.NotNil(t, res.Height)
+				require.Equal(t, 180.0, *res.Height)
+				require.JSONEq(t, `{"units":"kg"}`, string(res.Preferences))
+			},
+		},
+		{
+			name:          "NothingSent",
+			accountID:     userID,
+			body:          gin.H{},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				args := db.UpdateAccountParams{Preferences: json.RawMessage("{}"), ID: userID}
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Eq(args)).Times(1).
+					Return(db.UpdateAccountTxResult{Account: account}, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateAccountResponse(t, recorder.Body, account)
+			},
+		},
+		{
+			name:          "EmailChanged",
+			accountID:     userID,
+			body:          gin.H{"email": newEmail},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				args := db.UpdateAccountParams{
+					Email:       sql.NullString{String: newEmail, Valid: true},
+					Preferences: json.RawMessage("{}"),
+					ID:          userID,
+				}
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Eq(args)).Times(1).
+					Return(db.UpdateAccountTxResult{Account: emailChanged, EmailChanged: true}, nil)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateEmailVerificationParams) (db.EmailVerification, error) {
+						require.Equal(t, userID, arg.UserID)
+						return db.EmailVerification{TokenHash: arg.TokenHash, UserID: arg.UserID, ExpiresAt: arg.ExpiresAt}, nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateAccountResponse(t, recorder.Body, emailChanged)
+				require.Len(t, mailer.Sent(), 1)
+				require.Equal(t, newEmail, mailer.Sent()[0].To)
+			},
+		},
+		{
+			name:          "EmailTaken",
+			accountID:     userID,
+			body:          gin.H{"email": newEmail},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.UpdateAccountTxResult{}, &pq.Error{Code: "23505"})
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.AlreadyExists)
+			},
+		},
+		{
+			name:          "InvalidFields",
+			accountID:     userID,
+			body:          gin.H{"email": "not-an-email", "body_fat": 150, "start_date": "17/05/2020"},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				apiErr := requireErrorCode(t, recorder.Body, apierr.InvalidArgument)
+				require.Len(t, apiErr.Fields, 3)
+			},
+		},
+		{
+			name:          "FutureDateOfBirth",
+			accountID:     userID,
+			body:          gin.H{"date_of_birth": time.Now().AddDate(1, 0, 0).Format(dateLayout)},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:          "PreferencesNotObject",
+			accountID:     userID,
+			body:          gin.H{"preferences": []string{"kg"}},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:          "NotOwner",
+			accountID:     uuid.New(),
+			body:          gin.H{"name": updated.Name},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.PermissionDenied)
+			},
+		},
+		{
+			name:          "APIKey",
+			accountID:     userID,
+			body:          gin.H{"weight": 185.5},
+			configureAuth: withAPIKey,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				args := db.UpdateAccountParams{
+					Weight:      sql.NullFloat64{Float64: 185.5, Valid: true},
+					Preferences: json.RawMessage("{}"),
+					ID:          userID,
+				}
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Eq(args)).Times(1).
+					Return(db.UpdateAccountTxResult{Account: account}, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:          "APIKeyChangingEmail",
+			accountID:     userID,
+			body:          gin.H{"email": newEmail},
+			configureAuth: withAPIKey,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.PermissionDenied)
+			},
+		},
+		{
+			name:          "NotFound",
+			accountID:     userID,
+			body:          gin.H{"name": updated.Name},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateAccountTxResult{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:          "InternalError",
+			accountID:     userID,
+			body:          gin.H{"name": updated.Name},
+			configureAuth: withSession,
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateAccountTxResult{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:          "Unauthorized",
+			accountID:     userID,
+			body:          gin.H{"name": updated.Name},
+			configureAuth: func(t *testing.T, request *http.Request, server *Server, key string) {},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().UpdateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKey, key := generateRandAPIKey(userID, scopeAdmin)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, apiKey)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%s", tc.accountID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server, key)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder, server.mailer.(*mail.MemoryMailer))
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	oldPassword := util.RandomString(10)
+	newPassword := util.RandomString(10)
+	account := generateRandAccount(uuid.New())
+	account.Password, _ = util.HashPassword(oldPassword)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"old_password": oldPassword, "new_password": newPassword},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdatePasswordParams) error {
+						require.Equal(t, account.ID, arg.ID)
+						require.NoError(t, util.ValidatePassword(newPassword, arg.Password))
+						require.WithinDuration(t, time.Now(), arg.PasswordChangedAt, time.Second)
+						return nil
+					})
+				store.EXPECT().RevokeAPIKeys(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RevokeAPIKeysParams) (int64, error) {
+						require.Equal(t, account.ID, arg.UserID)
+						require.True(t, arg.RevokedAt.Valid)
+						return 1, nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res LoginRes
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.NotEmpty(t, res.JWT)
+				require.Equal(t, account.ID, res.User.ID)
+			},
+		},
+		{
+			name: "WrongOldPassword",
+			body: gin.H{"old_password": util.RandomString(10), "new_password": newPassword},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RevokeAPIKeys(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "SamePassword",
+			body: gin.H{"old_password": oldPassword, "new_password": oldPassword},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OtherAccount",
+			body: gin.H{"old_password": oldPassword, "new_password": newPassword},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"old_password": oldPassword, "new_password": newPassword},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+				store.EXPECT().RevokeAPIKeys(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "RevokeKeysError",
+			body: gin.H{"old_password": oldPassword, "new_password": newPassword},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().RevokeAPIKeys(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%s/password", account.ID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
+
 func generateRandAccount(userID uuid.UUID) db.Account {
 	password := util.RandomString(10)
 	hashedPassword, _ := util.HashPassword(password)
@@ -274,13 +854,3 @@ func validateAccountResponse(t *testing.T, body *bytes.Buffer, account db.Accoun
 	require.Equal(t, account.BodyFat, resAccount.BodyFat)
 	require.WithinDuration(t, account.StartDate, resAccount.StartDate, time.Second)
 }
-
-func validateAccountsResponse(t *testing.T, body *bytes.Buffer, account []db.Account) {
-	data, err := ioutil.ReadAll(body)
-	require.NoError(t, err)
-
-	var resAccounts []db.Account
-	err = json.Unmarshal(data, &resAccounts)
-	require.NoError(t, err)
-	require.Equal(t, account, resAccounts)
-}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyPrefix        = "mog_"
+	apiKeyBytes         = 32
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+	apiKeyScopesKey     = "api_key_scopes"
+	// last_used_at is only written this often so scripts polling the api
+	// don't turn every read into a write
+	apiKeyTouchInterval = time.Minute
+
+	scopeRead       = "read"
+	scopeWriteLifts = "lifts:write"
+	scopeAdmin      = "admin"
+)
+
+var (
+	errAPIKeyNotFound  = errors.New("API key not found")
+	errInvalidAPIKey   = errors.New("API key is invalid or has been revoked")
+	errScopeDenied     = errors.New("API key scopes do not allow this request")
+	errSessionRequired = errors.New("This request requires logging in, API keys are not accepted")
+)
+
+type apiKeyResp struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func newAPIKeyResp(key db.ApiKey) apiKeyResp {
+	res := apiKeyResp{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	}
+	if key.LastUsedAt.Valid {
+		res.LastUsedAt = &key.LastUsedAt.Time
+	}
+	if key.RevokedAt.Valid {
+		res.RevokedAt = &key.RevokedAt.Time
+	}
+	return res
+}
+
+type apiKeysUriReq struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type createAPIKeyReq struct {
+	Name   string   `json:"name" binding:"required,max=64"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=read lifts:write admin"`
+}
+
+type createAPIKeyResp struct {
+	APIKey apiKeyResp `json:"api_key"`
+	Key    string     `json:"key"`
+}
+
+// createAPIKey issues a personal api key. The key itself is only returned
+// here, just its hash is stored.
+func (server *Server) createAPIKey(ctx *gin.Context) {
+	var uri apiKeysUriReq
+	var req createAPIKeyReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	secret, err := util.NewSecret(apiKeyBytes)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	key := apiKeyPrefix + secret
+
+	scopes := []string{}
+	seen := map[string]bool{}
+	for _, scope := range req.Scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	apiKey, err := server.store.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		UserID:  userID,
+		Name:    req.Name,
+		Prefix:  key[:apiKeyDisplayLength],
+		KeyHash: util.HashSecret(key),
+		Scopes:  scopes,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, createAPIKeyResp{APIKey: newAPIKeyResp(apiKey), Key: key})
+}
+
+func (server *Server) listAPIKeys(ctx *gin.Context) {
+	var uri apiKeysUriReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	keys, err := server.store.ListAPIKeys(ctx, userID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := make([]apiKeyResp, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, newAPIKeyResp(key))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type revokeAPIKeyReq struct {
+	ID    string `uri:"id" binding:"required,uuid"`
+	KeyID string `uri:"key_id" binding:"required,uuid"`
+}
+
+func (server *Server) revokeAPIKey(ctx *gin.Context) {
+	var uri revokeAPIKeyReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	keyID, err := uuid.Parse(uri.KeyID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	n, err := server.store.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{
+		RevokedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:        keyID,
+		UserID:    userID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if n == 0 {
+		respondError(ctx, http.StatusNotFound, errAPIKeyNotFound)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// apiKeyAllows reports whether any of the scopes cover the request. Writing
+// lifts includes the workouts they are recorded under.
+func apiKeyAllows(scopes []string, method, path string) bool {
+	for _, scope := range scopes {
+		switch scope {
+		case scopeAdmin:
+			return true
+		case scopeRead:
+			if method == http.MethodGet || method == http.MethodHead {
+				return true
+			}
+		case scopeWriteLifts:
+			if strings.HasPrefix(path, "/lift") || strings.HasPrefix(path, "/workout") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// authenticateAPIKey resolves a personal api key to a payload for its owner.
+// The payload carries the key id in place of a token id.
+func authenticateAPIKey(ctx *gin.Context, store db.Store, key string) (*token.Payload, bool) {
+	apiKey, err := store.GetAPIKeyByHash(ctx, util.HashSecret(key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusUnauthorized, errInvalidAPIKey)
+			return nil, false
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if !apiKeyAllows(apiKey.Scopes, ctx.Request.Method, ctx.FullPath()) {
+		respondError(ctx, http.StatusForbidden, errScopeDenied)
+		return nil, false
+	}
+
+	now := time.Now().UTC()
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= apiKeyTouchInterval {
+		err := store.UpdateAPIKeyLastUsed(ctx, db.UpdateAPIKeyLastUsedParams{
+			LastUsedAt: sql.NullTime{Time: now, Valid: true},
+			ID:         apiKey.ID,
+		})
+		if err != nil {
+			respondError(ctx, http.StatusInternalServerError, err)
+			return nil, false
+		}
+	}
+
+	ctx.Set(apiKeyScopesKey, apiKey.Scopes)
+	return &token.Payload{ID: apiKey.ID, UserID: apiKey.UserID, IssuedAt: apiKey.CreatedAt}, true
+}
+
+// requireSession keeps api keys away from routes that manage credentials,
+// those need a token from logging in.
+func requireSession(ctx *gin.Context) {
+	if _, ok := ctx.Get(apiKeyScopesKey); ok {
+		respondError(ctx, http.StatusForbidden, errSessionRequired)
+		return
+	}
+	ctx.Next()
+}
@@ -0,0 +1,446 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func generateRandAPIKey(userID uuid.UUID, scopes ...string) (db.ApiKey, string) {
+	key := apiKeyPrefix + util.RandomString(43)
+	return db.ApiKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      util.RandomString(8),
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   util.HashSecret(key),
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+	}, key
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		userID     uuid.UUID
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: userID,
+			body:   gin.H{"name": "spreadsheet", "scopes": []string{"read", "lifts:write", "read"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAPIKeyParams) (db.ApiKey, error) {
+						require.Equal(t, userID, arg.UserID)
+						require.Equal(t, "spreadsheet", arg.Name)
+						require.Equal(t, []string{"read", "lifts:write"}, arg.Scopes)
+						require.True(t, strings.HasPrefix(arg.Prefix, apiKeyPrefix))
+						return db.ApiKey{ID: uuid.New(), UserID: arg.UserID, Name: arg.Name, Prefix: arg.Prefix, KeyHash: arg.KeyHash, Scopes: arg.Scopes}, nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var res createAPIKeyResp
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, strings.HasPrefix(res.Key, res.APIKey.Prefix))
+				require.Equal(t, []string{"read", "lifts:write"}, res.APIKey.Scopes)
+				require.Nil(t, res.APIKey.LastUsedAt)
+				require.NotContains(t, recorder.Body.String(), util.HashSecret(res.Key))
+			},
+		},
+		{
+			name:   "UnknownScope",
+			userID: userID,
+			body:   gin.H{"name": "spreadsheet", "scopes": []string{"root"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "NoScopes",
+			userID: userID,
+			body:   gin.H{"name": "spreadsheet", "scopes": []string{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "NotOwner",
+			userID: uuid.New(),
+			body:   gin.H{"name": "spreadsheet", "scopes": []string{"read"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			userID: userID,
+			body:   gin.H{"name": "spreadsheet", "scopes": []string{"admin"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%s/api_keys", tc.userID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	userID := uuid.New()
+	active, _ := generateRandAPIKey(userID, scopeRead)
+	revoked, _ := generateRandAPIKey(userID, scopeAdmin)
+	revoked.RevokedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAPIKeys(gomock.Any(), gomock.Eq(userID)).Times(1).Return([]db.ApiKey{active, revoked}, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []apiKeyResp
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res, 2)
+				require.Equal(t, active.ID, res[0].ID)
+				require.Nil(t, res[0].RevokedAt)
+				require.NotNil(t, res[1].RevokedAt)
+				require.NotContains(t, recorder.Body.String(), active.KeyHash)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAPIKeys(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%s/api_keys", userID), nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	userID := uuid.New()
+	keyID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		keyID      string
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name:  "OK",
+			keyID: keyID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RevokeAPIKeyParams) (int64, error) {
+						require.Equal(t, keyID, arg.ID)
+						require.Equal(t, userID, arg.UserID)
+						require.True(t, arg.RevokedAt.Valid)
+						return 1, nil
+					})
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name:  "NotFound",
+			keyID: keyID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name:  "InvalidID",
+			keyID: "not-a-uuid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name:  "InternalError",
+			keyID: keyID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%s/api_keys/%s", userID, tc.keyID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		method     string
+		route      string
+		scopes     []string
+		buildStubs func(store *mockdb.MockStore, apiKey db.ApiKey)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ReadOK",
+			method: http.MethodGet,
+			route:  "/category/test",
+			scopes: []string{scopeRead},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Eq(apiKey.KeyHash)).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateAPIKeyLastUsedParams) error {
+						require.Equal(t, apiKey.ID, arg.ID)
+						require.WithinDuration(t, time.Now(), arg.LastUsedAt.Time, time.Second)
+						return nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, userID.String(), recorder.Body.String())
+			},
+		},
+		{
+			name:   "RecentlyUsed",
+			method: http.MethodGet,
+			route:  "/category/test",
+			scopes: []string{scopeRead},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				apiKey.LastUsedAt = sql.NullTime{Time: time.Now().UTC().Add(-time.Second), Valid: true}
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ReadOnlyCannotWrite",
+			method: http.MethodPost,
+			route:  "/lift/test",
+			scopes: []string{scopeRead},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.PermissionDenied)
+			},
+		},
+		{
+			name:   "WriteLifts",
+			method: http.MethodPost,
+			route:  "/lift/test",
+			scopes: []string{scopeWriteLifts},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "WriteLiftsOnly",
+			method: http.MethodPost,
+			route:  "/category/test",
+			scopes: []string{scopeWriteLifts},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "Admin",
+			method: http.MethodPost,
+			route:  "/category/test",
+			scopes: []string{scopeAdmin},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SessionOnly",
+			method: http.MethodGet,
+			route:  "/session/test",
+			scopes: []string{scopeAdmin},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(apiKey, nil)
+				store.EXPECT().UpdateAPIKeyLastUsed(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), errSessionRequired.Error())
+			},
+		},
+		{
+			name:   "RevokedOrUnknown",
+			method: http.MethodGet,
+			route:  "/category/test",
+			scopes: []string{scopeRead},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(db.ApiKey{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+			},
+		},
+		{
+			name:   "InternalError",
+			method: http.MethodGet,
+			route:  "/category/test",
+			scopes: []string{scopeRead},
+			buildStubs: func(store *mockdb.MockStore, apiKey db.ApiKey) {
+				store.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Times(1).Return(db.ApiKey{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			apiKey, key := generateRandAPIKey(userID, tc.scopes...)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, apiKey)
+
+			server := newTestServer(t, store)
+			auth := authenticationMiddleware(server.tokenCreator, server.store, server.revocations)
+			handler := func(ctx *gin.Context) {
+				payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+				ctx.String(http.StatusOK, payload.UserID.String())
+			}
+			server.router.GET("/category/test", auth, handler)
+			server.router.POST("/category/test", auth, handler)
+			server.router.POST("/lift/test", auth, handler)
+			server.router.GET("/session/test", auth, requireSession, handler)
+
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(tc.method, tc.route, nil)
+			require.NoError(t, err)
+			req.Header.Set(authorizationHeaderKey, bearerType+" "+key)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package apierr
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/lib/pq"
+)
+
+type Code string
+
+const (
+	InvalidArgument   Code = "invalid_argument"
+	Unauthenticated   Code = "unauthenticated"
+	PermissionDenied  Code = "permission_denied"
+	NotFound          Code = "not_found"
+	AlreadyExists     Code = "already_exists"
+	Conflict          Code = "conflict"
+	Unprocessable     Code = "unprocessable"
+	ResourceExhausted Code = "resource_exhausted"
+	Internal          Code = "internal"
+)
+
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Error is the body of every failed API response. Status is only used to
+// pick the HTTP status and is never serialized. Details carries anything
+// the client needs to fix the request beyond field errors.
+type Error struct {
+	Status  int          `json:"-"`
+	Code    Code         `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+	Details interface{}  `json:"details,omitempty"`
+	err     error
+}
+
+type Response struct {
+	Error *Error `json:"error"`
+}
+
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func (e *Error) WithDetails(details interface{}) *Error {
+	e.Details = details
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// From maps err onto an API error. Missing rows, unique violations and
+// request validation failures always get their canonical status; anything
+// else is reported with the fallback status. Messages of 5xx errors and
+// database errors are replaced so driver details never reach the client.
+func From(fallback int, err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, len(validationErrs))
+		for i, fe := range validationErrs {
+			reason := fe.Tag()
+			if fe.Param() != "" {
+				reason = fmt.Sprintf("%s=%s", reason, fe.Param())
+			}
+			fields[i] = FieldError{Field: fe.Field(), Reason: reason}
+		}
+		return &Error{Status: http.StatusBadRequest, Code: InvalidArgument, Message: "Request failed validation", Fields: fields, err: err}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := FieldError{Field: typeErr.Field, Reason: "type=" + typeErr.Type.String()}
+		return &Error{Status: http.StatusBadRequest, Code: InvalidArgument, Message: "Request failed validation", Fields: []FieldError{field}, err: err}
+	}
+
+	var syntaxErr *json.SyntaxError
+	var numErr *strconv.NumError
+	if errors.As(err, &syntaxErr) || errors.As(err, &numErr) || errors.Is(err, io.EOF) {
+		return &Error{Status: http.StatusBadRequest, Code: InvalidArgument, Message: "Request could not be parsed", err: err}
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{Status: http.StatusNotFound, Code: NotFound, Message: "Resource not found", err: err}
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return &Error{Status: http.StatusConflict, Code: AlreadyExists, Message: "Resource already exists", err: err}
+		case "foreign_key_violation":
+			if fallback == http.StatusNotFound {
+				return &Error{Status: fallback, Code: NotFound, Message: "Referenced resource does not exist", err: err}
+			}
+			return &Error{Status: http.StatusConflict, Code: Conflict, Message: "Resource is still referenced", err: err}
+		case "check_violation", "not_null_violation":
+			return &Error{Status: http.StatusBadRequest, Code: InvalidArgument, Message: "Request violates a data constraint", err: err}
+		}
+		return &Error{Status: http.StatusInternalServerError, Code: Internal, Message: "Internal server error", err: err}
+	}
+
+	if fallback >= http.StatusInternalServerError {
+		return &Error{Status: fallback, Code: Internal, Message: "Internal server error", err: err}
+	}
+
+	return &Error{Status: fallback, Code: codeFor(fallback), Message: err.Error(), err: err}
+}
+
+func codeFor(status int) Code {
+	switch status {
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusUnprocessableEntity:
+		return Unprocessable
+	case http.StatusTooManyRequests:
+		return ResourceExhausted
+	}
+	return InvalidArgument
+}
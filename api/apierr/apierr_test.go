@@ -0,0 +1,58 @@
+package apierr
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	type req struct {
+		PageSize int `validate:"required,min=5"`
+	}
+	validationErr := validator.New().Struct(req{PageSize: 1})
+
+	testCases := []struct {
+		name     string
+		fallback int
+		err      error
+		status   int
+		code     Code
+		message  string
+	}{
+		{"NoRows", http.StatusInternalServerError, sql.ErrNoRows, http.StatusNotFound, NotFound, "Resource not found"},
+		{"WrappedNoRows", http.StatusInternalServerError, fmt.Errorf("load: %w", sql.ErrNoRows), http.StatusNotFound, NotFound, "Resource not found"},
+		{"Unique", http.StatusForbidden, &pq.Error{Code: "23505", Detail: "Key (email)=(a@b.c) already exists."}, http.StatusConflict, AlreadyExists, "Resource already exists"},
+		{"ForeignKeyOnCreate", http.StatusNotFound, &pq.Error{Code: "23503"}, http.StatusNotFound, NotFound, "Referenced resource does not exist"},
+		{"ForeignKeyOnDelete", http.StatusInternalServerError, &pq.Error{Code: "23503"}, http.StatusConflict, Conflict, "Resource is still referenced"},
+		{"OtherDriverError", http.StatusBadRequest, &pq.Error{Code: "42P01", Message: "relation does not exist"}, http.StatusInternalServerError, Internal, "Internal server error"},
+		{"Validation", http.StatusBadRequest, validationErr, http.StatusBadRequest, InvalidArgument, "Request failed validation"},
+		{"Internal", http.StatusInternalServerError, sql.ErrConnDone, http.StatusInternalServerError, Internal, "Internal server error"},
+		{"Unauthorized", http.StatusUnauthorized, errors.New("token has expired"), http.StatusUnauthorized, Unauthenticated, "token has expired"},
+		{"TooManyRequests", http.StatusTooManyRequests, errors.New("slow down"), http.StatusTooManyRequests, ResourceExhausted, "slow down"},
+		{"Passthrough", http.StatusBadRequest, New(http.StatusConflict, Conflict, "taken"), http.StatusConflict, Conflict, "taken"},
+		{"Unprocessable", http.StatusUnprocessableEntity, errors.New("cannot import"), http.StatusUnprocessableEntity, Unprocessable, "cannot import"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			apiErr := From(tc.fallback, tc.err)
+			require.Equal(t, tc.status, apiErr.Status)
+			require.Equal(t, tc.code, apiErr.Code)
+			require.Equal(t, tc.message, apiErr.Message)
+		})
+	}
+
+	apiErr := From(http.StatusBadRequest, validationErr)
+	require.Equal(t, []FieldError{{Field: "PageSize", Reason: "min=5"}}, apiErr.Fields)
+
+	var unwrapped validator.ValidationErrors
+	require.ErrorAs(t, apiErr, &unwrapped)
+}
@@ -2,15 +2,40 @@ package api
 
 import (
 	"database/sql"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+var (
+	errInvalidCredentials = errors.New("Invalid email or password")
+	errTooManyAttempts    = errors.New("Too many failed login attempts, try again later")
+)
+
+var (
+	unknownAccountOnce sync.Once
+	unknownAccountHash string
+)
+
+// compareUnknownAccount spends the same bcrypt work on an unknown email as a
+// wrong password would so response times don't reveal which emails exist.
+func compareUnknownAccount(password string) {
+	unknownAccountOnce.Do(func() {
+		unknownAccountHash, _ = util.HashPassword(util.RandomString(16))
+	})
+	util.ValidatePassword(password, unknownAccountHash)
+}
+
 type UserRes struct {
 	ID                uuid.UUID `json:"user_id"`
 	Email             string    `json:"email"`
@@ -28,8 +53,8 @@ func newUserResponse(account db.GetAccountByEmailRow) UserRes {
 }
 
 type LoginReq struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
 
 type LoginRes struct {
@@ -37,32 +62,82 @@ type LoginRes struct {
 	User UserRes `json:"user"`
 }
 
+// mfaChallengeRes is returned by login instead of LoginRes when the account
+// has two factor auth, the challenge token is exchanged at /user/login/mfa.
+type mfaChallengeRes struct {
+	MFARequired    bool   `json:"mfa_required"`
+	ChallengeToken string `json:"challenge_token"`
+}
+
 func (server *Server) login(ctx *gin.Context) {
 	var req LoginReq
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	// unknown emails are limited like real ones, a lockout that only real
+	// accounts hit would reveal them just the same
+	accountKey := "account:" + strings.ToLower(req.Email)
+	ipKey := "ip:" + ctx.ClientIP()
+
+	if !server.loginAllowed(ctx, accountKey, ipKey) {
 		return
 	}
 
 	account, err := server.store.GetAccountByEmail(ctx, req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			compareUnknownAccount(req.Password)
+			server.loginFailed(ctx, accountKey, ipKey, errInvalidCredentials)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	err = util.ValidatePassword(req.Password, account.Password)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		server.loginFailed(ctx, accountKey, ipKey, errInvalidCredentials)
+		return
+	}
+
+	if err := server.loginLimiter.Reset(ctx, accountKey); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.completeLogin(ctx, account)
+}
+
+// completeLogin answers a login that proved who the user is, either with an
+// access token or, when the account has two factor auth, a challenge token.
+func (server *Server) completeLogin(ctx *gin.Context, account db.GetAccountByEmailRow) {
+	mfa, err := server.store.GetAccountMFA(ctx, account.ID)
+	if err != nil && err != sql.ErrNoRows {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err == nil && mfa.ConfirmedAt.Valid {
+		duration := server.config.MFAChallengeDuration
+		if duration <= 0 {
+			duration = defaultChallengeExpiry
+		}
+
+		challenge, err := server.tokenCreator.CreateChallengeToken(account.ID, duration)
+		if err != nil {
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, mfaChallengeRes{MFARequired: true, ChallengeToken: challenge})
 		return
 	}
 
 	jwt, err := server.tokenCreator.CreateToken(account.ID, server.config.AccessDuration)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -73,3 +148,73 @@ func (server *Server) login(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (server *Server) loginAllowed(ctx *gin.Context, accountKey, ipKey string) bool {
+	accountWait, err := server.loginLimiter.Check(ctx, accountKey)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	ipWait, err := server.ipLimiter.Check(ctx, ipKey)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	wait := accountWait
+	if ipWait > wait {
+		wait = ipWait
+	}
+
+	if wait > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		respondError(ctx, http.StatusTooManyRequests, errTooManyAttempts)
+		return false
+	}
+	return true
+}
+
+// loginFailed records the failure against both keys. The response is the
+// same 401 even when this attempt caused a lockout.
+func (server *Server) loginFailed(ctx *gin.Context, accountKey, ipKey string, failure error) {
+	if _, err := server.loginLimiter.Fail(ctx, accountKey); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if _, err := server.ipLimiter.Fail(ctx, ipKey); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	respondError(ctx, http.StatusUnauthorized, failure)
+}
+
+// logout revokes the token the request was made with.
+func (server *Server) logout(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	err := server.revocations.Revoke(ctx, authPayload.ID, authPayload.UserID, authPayload.ExpiredAt)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// logoutAll revokes every token issued to the user so far, including the
+// one the request was made with. API keys are not tokens and keep working,
+// they are revoked one at a time or all at once by a password reset.
+func (server *Server) logoutAll(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	err := server.revocations.RevokeAll(ctx, authPayload.UserID, time.Now())
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
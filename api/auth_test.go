@@ -0,0 +1,330 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/limiter"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/revocation"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogin(t *testing.T) {
+	password := util.RandomString(10)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	account := generateRandAccount(uuid.New())
+	row := db.GetAccountByEmailRow{
+		ID:        account.ID,
+		Email:     account.Email,
+		Password:  hashedPassword,
+		StartDate: account.StartDate,
+	}
+	accountKey := "account:" + strings.ToLower(account.Email)
+	ipKey := "ip:192.0.2.1"
+
+	failTimes := func(t *testing.T, target limiter.Limiter, key string, n int) {
+		for i := 0; i < n; i++ {
+			_, err := target.Fail(context.Background(), key)
+			require.NoError(t, err)
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		prepare    func(t *testing.T, server *Server)
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"email": account.Email, "password": password},
+			prepare: func(t *testing.T, server *Server) {
+				failTimes(t, server.loginLimiter, accountKey, 2)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Eq(account.Email)).Times(1).Return(row, nil)
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.AccountMfa{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res LoginRes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotEmpty(t, res.JWT)
+				require.Equal(t, account.ID, res.User.ID)
+
+				// a successful login clears earlier failures for the account
+				failTimes(t, server.loginLimiter, accountKey, 4)
+				wait, err := server.loginLimiter.Check(context.Background(), accountKey)
+				require.NoError(t, err)
+				require.Zero(t, wait)
+			},
+		},
+		{
+			name: "MFARequired",
+			body: gin.H{"email": account.Email, "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Eq(account.Email)).Times(1).Return(row, nil)
+				mfa := db.AccountMfa{UserID: account.ID, ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(mfa, nil)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res mfaChallengeRes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, res.MFARequired)
+
+				payload, err := server.tokenCreator.VerifyToken(res.ChallengeToken)
+				require.NoError(t, err)
+				require.True(t, payload.Challenge)
+				require.Equal(t, account.ID, payload.UserID)
+				require.WithinDuration(t, time.Now().Add(defaultChallengeExpiry), payload.ExpiredAt, time.Second)
+			},
+		},
+		{
+			name: "MFANotConfirmed",
+			body: gin.H{"email": account.Email, "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Eq(account.Email)).Times(1).Return(row, nil)
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.AccountMfa{UserID: account.ID}, nil)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res LoginRes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotEmpty(t, res.JWT)
+			},
+		},
+		{
+			name: "WrongPassword",
+			body: gin.H{"email": account.Email, "password": "wrong-password"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Eq(account.Email)).Times(1).Return(row, nil)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				apiErr := requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+				require.Equal(t, errInvalidCredentials.Error(), apiErr.Message)
+			},
+		},
+		{
+			name: "UnknownEmail",
+			body: gin.H{"email": "nobody@example.com", "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.GetAccountByEmailRow{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				apiErr := requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+				require.Equal(t, errInvalidCredentials.Error(), apiErr.Message)
+			},
+		},
+		{
+			name: "AccountLocked",
+			body: gin.H{"email": account.Email, "password": password},
+			prepare: func(t *testing.T, server *Server) {
+				failTimes(t, server.loginLimiter, accountKey, 5)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				require.Equal(t, "60", recorder.Header().Get("Retry-After"))
+				requireErrorCode(t, recorder.Body, apierr.ResourceExhausted)
+			},
+		},
+		{
+			name: "AccountLockedByCase",
+			body: gin.H{"email": strings.ToUpper(account.Email), "password": password},
+			prepare: func(t *testing.T, server *Server) {
+				failTimes(t, server.loginLimiter, accountKey, 5)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+			},
+		},
+		{
+			name: "IPLocked",
+			body: gin.H{"email": account.Email, "password": password},
+			prepare: func(t *testing.T, server *Server) {
+				failTimes(t, server.ipLimiter, ipKey, 50)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+			},
+		},
+		{
+			name: "MissingPassword",
+			body: gin.H{"email": account.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"email": account.Email, "password": password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.GetAccountByEmailRow{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			if tc.prepare != nil {
+				tc.prepare(t, server)
+			}
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/login", bytes.NewReader(data))
+			require.NoError(t, err)
+			req.RemoteAddr = "192.0.2.1:54321"
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, server, recorder)
+		})
+	}
+}
+
+// failingRevocations stands in for a revocation store whose backend is down.
+type failingRevocations struct {
+	revocation.Store
+}
+
+func (failingRevocations) Revoke(context.Context, uuid.UUID, uuid.UUID, time.Time) error {
+	return sql.ErrConnDone
+}
+
+func (failingRevocations) RevokeAll(context.Context, uuid.UUID, time.Time) error {
+	return sql.ErrConnDone
+}
+
+func TestLogout(t *testing.T) {
+	userID := uuid.New()
+
+	logout := func(server *Server, url, accessToken string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, url, nil)
+		require.NoError(t, err)
+		if accessToken != "" {
+			req.Header.Set(authorizationHeaderKey, bearerType+" "+accessToken)
+		}
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	newToken := func(server *Server, userID uuid.UUID) string {
+		accessToken, err := server.tokenCreator.CreateToken(userID, time.Minute)
+		require.NoError(t, err)
+		return accessToken
+	}
+
+	testCases := []struct {
+		name  string
+		check func(t *testing.T, server *Server)
+	}{
+		{
+			name: "OK",
+			check: func(t *testing.T, server *Server) {
+				current, other := newToken(server, userID), newToken(server, userID)
+
+				require.Equal(t, http.StatusNoContent, logout(server, "/user/logout", current).Code)
+
+				recorder := logout(server, "/user/logout", current)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+
+				// other sessions of the same user are left alone
+				require.Equal(t, http.StatusNoContent, logout(server, "/user/logout", other).Code)
+			},
+		},
+		{
+			name: "Everywhere",
+			check: func(t *testing.T, server *Server) {
+				current, other := newToken(server, userID), newToken(server, userID)
+				otherUser := newToken(server, uuid.New())
+
+				require.Equal(t, http.StatusNoContent, logout(server, "/user/logout/all", current).Code)
+
+				for _, revoked := range []string{current, other} {
+					recorder := logout(server, "/user/logout", revoked)
+					require.Equal(t, http.StatusUnauthorized, recorder.Code)
+					requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+				}
+
+				require.Equal(t, http.StatusNoContent, logout(server, "/user/logout", otherUser).Code)
+				require.Equal(t, http.StatusNoContent, logout(server, "/user/logout", newToken(server, userID)).Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			check: func(t *testing.T, server *Server) {
+				require.Equal(t, http.StatusUnauthorized, logout(server, "/user/logout", "").Code)
+				require.Equal(t, http.StatusUnauthorized, logout(server, "/user/logout/all", "").Code)
+			},
+		},
+		{
+			name: "InternalError",
+			check: func(t *testing.T, server *Server) {
+				server.revocations = failingRevocations{server.revocations}
+
+				require.Equal(t, http.StatusInternalServerError, logout(server, "/user/logout", newToken(server, userID)).Code)
+				require.Equal(t, http.StatusInternalServerError, logout(server, "/user/logout/all", newToken(server, userID)).Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := newTestServer(t, mockdb.NewMockStore(ctrl))
+			tc.check(t, server)
+		})
+	}
+}
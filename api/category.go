@@ -2,27 +2,27 @@ package api
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
-	"strconv"
 
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	"github.com/gin-gonic/gin"
 )
 
 type createCategoryReq struct {
-	Name string `json:"name" binding:"required"`
+	Name string `json:"name" binding:"required,slugname"`
 }
 
 func (server *Server) createCategory(ctx *gin.Context) {
 	var req createCategoryReq
 	if err := ctx.BindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	category, err := server.store.CreateCategory(context.Background(), req.Name)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -30,35 +30,52 @@ func (server *Server) createCategory(ctx *gin.Context) {
 }
 
 type getCategoryReq struct {
-	ID string `uri:"id" binding:"required"`
+	Ref string `uri:"ref" binding:"required"`
 }
 
-func (server *Server) getCategory(ctx *gin.Context) {
-	var req getCategoryReq
-	if err := ctx.BindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
+// loadCategory resolves a category by id or slug, writing the error response
+// itself when the lookup fails.
+func (server *Server) loadCategory(ctx *gin.Context, ref string) (db.Category, bool) {
+	var category db.Category
+	var err error
+
+	if id, slug := parseRef(ref); slug == "" {
+		category, err = server.store.GetCategory(ctx, int16(id))
+	} else {
+		category, err = server.store.GetCategoryBySlug(ctx, slug)
 	}
 
-	id, err := strconv.Atoi(req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, err)
+			return category, false
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return category, false
+	}
+
+	return category, true
+}
+
+func (server *Server) getCategory(ctx *gin.Context) {
+	var req getCategoryReq
+	if err := ctx.BindUri(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	query, err := server.store.GetCategory(context.Background(), int16(id))
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	category, ok := server.loadCategory(ctx, req.Ref)
+	if !ok {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, query)
+	ctx.JSON(http.StatusOK, category)
 }
 
 func (server *Server) listCategories(ctx *gin.Context) {
 	categories, err := server.store.ListCategories(context.Background())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -69,29 +86,28 @@ func (server *Server) updateCategory(ctx *gin.Context) {
 	var uri getCategoryReq
 	var req createCategoryReq
 	if err := ctx.BindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.BindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	id, err := strconv.Atoi(uri.ID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	category, ok := server.loadCategory(ctx, uri.Ref)
+	if !ok {
 		return
 	}
 
 	args := db.UpdateCategoryParams{
 		Name: req.Name,
-		ID:   int16(id),
+		ID:   category.ID,
 	}
 
-	err = server.store.UpdateCategory(context.Background(), args)
+	err := server.store.UpdateCategory(context.Background(), args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -101,17 +117,24 @@ func (server *Server) updateCategory(ctx *gin.Context) {
 func (server *Server) deleteCategory(ctx *gin.Context) {
 	var req getCategoryReq
 	if err := ctx.BindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	id, err := strconv.Atoi(req.ID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	category, ok := server.loadCategory(ctx, req.Ref)
+	if !ok {
 		return
 	}
 
-	err = server.store.DeleteCategory(context.Background(), int16(id))
+	err := server.store.DeleteCategory(context.Background(), category.ID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusConflict, errStillReferenced)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
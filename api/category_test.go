@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
 	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
@@ -18,6 +19,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,6 +62,23 @@ func TestCreateCategory(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "NumericName",
+			body: gin.H{
+				"name": "100",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				apiErr := requireErrorCode(t, recorder.Body, apierr.InvalidArgument)
+				require.Equal(t, []apierr.FieldError{{Field: "name", Reason: "slugname"}}, apiErr.Fields)
+			},
+		},
 		{
 			name: "InternalError",
 			body: gin.H{
@@ -122,14 +141,14 @@ func TestGetCategory(t *testing.T) {
 
 	testCases := []struct {
 		name          string
-		categoryId    int16
+		categoryRef   string
 		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkRes      func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:       "OK",
-			categoryId: category.ID,
+			name:        "OK",
+			categoryRef: fmt.Sprint(category.ID),
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
@@ -142,8 +161,35 @@ func TestGetCategory(t *testing.T) {
 			},
 		},
 		{
-			name:       "InternalError",
-			categoryId: category.ID,
+			name:        "BySlug",
+			categoryRef: category.Slug,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategoryBySlug(gomock.Any(), gomock.Eq(category.Slug)).Times(1).Return(category, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateCategoryResponse(t, recorder.Body, category)
+			},
+		},
+		{
+			name:        "NotFound",
+			categoryRef: category.Slug,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategoryBySlug(gomock.Any(), gomock.Eq(category.Slug)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			categoryRef: fmt.Sprint(category.ID),
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
@@ -155,8 +201,8 @@ func TestGetCategory(t *testing.T) {
 			},
 		},
 		{
-			name:       "Unauthorized",
-			categoryId: category.ID,
+			name:        "Unauthorized",
+			categoryRef: fmt.Sprint(category.ID),
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
@@ -181,7 +227,7 @@ func TestGetCategory(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/category/%d", tc.categoryId)
+			url := fmt.Sprintf("/category/%s", tc.categoryRef)
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -285,6 +331,7 @@ func TestUpdateCategory(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
 				args := db.UpdateCategoryParams{
 					Name: category.Name,
 					ID:   category.ID,
@@ -317,6 +364,7 @@ func TestUpdateCategory(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
 				args := db.UpdateCategoryParams{
 					Name: category.Name,
 					ID:   category.ID,
@@ -388,12 +436,26 @@ func TestDeleteCategory(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
 				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNoContent, recorder.Code)
 			},
 		},
+		{
+			name: "Conflict",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(&pq.Error{Code: "23503"})
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 
 		{
 			name: "Unauthorized",
@@ -432,9 +494,11 @@ func TestDeleteCategory(t *testing.T) {
 }
 
 func generateRandCategory() db.Category {
+	name := util.RandomString(5)
 	return db.Category{
-		Name: util.RandomString(5),
+		Name: name,
 		ID:   int16(util.RandomInt(1, 100)),
+		Slug: util.Slugify(name),
 	}
 }
 
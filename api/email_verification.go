@@ -0,0 +1,141 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/mail"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	verifyTokenBytes      = 32
+	defaultVerifyDuration = 24 * time.Hour
+	defaultVerifyResend   = time.Minute
+)
+
+var (
+	errAlreadyVerified = errors.New("Email address is already verified")
+	errResendTooSoon   = errors.New("A verification email was sent recently, try again later")
+)
+
+// sendVerification issues a new verification token for the account and
+// emails it. Only failing to store the token is reported, a failed send is
+// logged and can be retried through the resend endpoint.
+func (server *Server) sendVerification(ctx *gin.Context, userID uuid.UUID, email string) error {
+	verifyToken, err := util.NewSecret(verifyTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	duration := server.config.EmailVerifyDuration
+	if duration <= 0 {
+		duration = defaultVerifyDuration
+	}
+
+	_, err = server.store.CreateEmailVerification(ctx, db.CreateEmailVerificationParams{
+		TokenHash: util.HashSecret(verifyToken),
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC().Add(duration),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = server.mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Welcome! Confirm this is your email address by opening the link below within %s:\n\n%s\n\n"+
+			"If you didn't create an account, you can ignore this email.\n", duration, tokenLink(server.config.EmailVerifyURL, verifyToken)),
+	})
+	if err != nil {
+		ctx.Error(err)
+	}
+
+	return nil
+}
+
+type verifyEmailReq struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var req verifyEmailReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	_, err := server.store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
+		TokenHash:  util.HashSecret(req.Token),
+		VerifiedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		if err == db.ErrVerifyTokenInvalid {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (server *Server) resendVerification(ctx *gin.Context) {
+	var req getAccountReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, id) {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if account.EmailVerifiedAt.Valid {
+		respondError(ctx, http.StatusConflict, errAlreadyVerified)
+		return
+	}
+
+	wait := server.config.EmailVerifyResendWait
+	if wait <= 0 {
+		wait = defaultVerifyResend
+	}
+
+	latest, err := server.store.GetLatestEmailVerification(ctx, account.ID)
+	if err != nil && err != sql.ErrNoRows {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err == nil && time.Now().UTC().Before(latest.CreatedAt.Add(wait)) {
+		respondError(ctx, http.StatusTooManyRequests, errResendTooSoon)
+		return
+	}
+
+	if err := server.sendVerification(ctx, account.ID, account.Email); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "Verification email sent"})
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/mail"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	verifyToken, err := util.NewSecret(verifyTokenBytes)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name: "OK",
+			body: gin.H{"token": verifyToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.VerifyEmailTxParams) (uuid.UUID, error) {
+						require.Equal(t, util.HashSecret(verifyToken), arg.TokenHash)
+						require.WithinDuration(t, time.Now(), arg.VerifiedAt, time.Second)
+						return uuid.New(), nil
+					})
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name: "InvalidToken",
+			body: gin.H{"token": verifyToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(1).Return(uuid.Nil, db.ErrVerifyTokenInvalid)
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "MissingToken",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"token": verifyToken},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(1).Return(uuid.Nil, sql.ErrConnDone)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/email/verify", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	account := generateRandAccount(uuid.New())
+	verified := account
+	verified.EmailVerifiedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	testCases := []struct {
+		name       string
+		accountID  uuid.UUID
+		setupAuth  func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer)
+	}{
+		{
+			name:      "OK",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				latest := db.EmailVerification{UserID: account.ID, CreatedAt: time.Now().UTC().Add(-time.Hour)}
+				store.EXPECT().GetLatestEmailVerification(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(latest, nil)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(1).Return(db.EmailVerification{}, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.Len(t, mailer.Sent(), 1)
+				require.Equal(t, account.Email, mailer.Sent()[0].To)
+			},
+		},
+		{
+			name:      "NeverSent",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetLatestEmailVerification(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.EmailVerification{}, sql.ErrNoRows)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(1).Return(db.EmailVerification{}, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.Len(t, mailer.Sent(), 1)
+			},
+		},
+		{
+			name:      "TooSoon",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				latest := db.EmailVerification{UserID: account.ID, CreatedAt: time.Now().UTC().Add(-time.Second)}
+				store.EXPECT().GetLatestEmailVerification(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(latest, nil)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.ResourceExhausted)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+		{
+			name:      "AlreadyVerified",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(verified, nil)
+				store.EXPECT().GetLatestEmailVerification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+		{
+			name:      "Forbidden",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			accountID: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetLatestEmailVerification(gomock.Any(), gomock.Any()).Times(1).Return(db.EmailVerification{}, sql.ErrNoRows)
+				store.EXPECT().CreateEmailVerification(gomock.Any(), gomock.Any()).Times(1).Return(db.EmailVerification{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%s/verification", tc.accountID)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder, server.mailer.(*mail.MemoryMailer))
+		})
+	}
+}
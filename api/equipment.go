@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sort"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type plateReq struct {
+	Weight float32 `json:"weight" binding:"required,gt=0"`
+	Pairs  int16   `json:"pairs" binding:"required,min=1"`
+}
+
+type equipmentResp struct {
+	BarWeight float32    `json:"bar_weight"`
+	Plates    []plateReq `json:"plates"`
+}
+
+func newEquipmentResponse(barWeight float32, inventory map[float32]int16) equipmentResp {
+	res := equipmentResp{
+		BarWeight: barWeight,
+		Plates:    []plateReq{},
+	}
+
+	for weight, pairs := range inventory {
+		res.Plates = append(res.Plates, plateReq{Weight: weight, Pairs: pairs})
+	}
+
+	sort.Slice(res.Plates, func(i, j int) bool { return res.Plates[i].Weight > res.Plates[j].Weight })
+	return res
+}
+
+// loadEquipment falls back to a standard barbell and plate set for accounts
+// that have not configured their equipment yet.
+func (server *Server) loadEquipment(ctx context.Context, userID uuid.UUID) (float32, map[float32]int16, error) {
+	barWeight := util.DefaultBarWeight
+	inventory := util.DefaultPlates
+
+	settings, err := server.store.GetEquipmentSettings(ctx, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, nil, err
+	}
+	if err == nil {
+		barWeight = settings.BarWeight
+	}
+
+	plates, err := server.store.ListPlates(ctx, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(plates) > 0 {
+		inventory = make(map[float32]int16, len(plates))
+		for _, plate := range plates {
+			inventory[plate.Weight] = plate.Pairs
+		}
+	}
+
+	return barWeight, inventory, nil
+}
+
+func (server *Server) getEquipment(ctx *gin.Context) {
+	var uri getUserIdReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	barWeight, inventory, err := server.loadEquipment(ctx, userID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newEquipmentResponse(barWeight, inventory))
+}
+
+type updateEquipmentReq struct {
+	BarWeight float32    `json:"bar_weight" binding:"required,gt=0"`
+	Plates    []plateReq `json:"plates" binding:"required,min=1,dive"`
+}
+
+func (server *Server) updateEquipment(ctx *gin.Context) {
+	var uri getUserIdReq
+	var req updateEquipmentReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	inventory := make(map[float32]int16, len(req.Plates))
+	for _, plate := range req.Plates {
+		inventory[plate.Weight] += plate.Pairs
+	}
+
+	args := db.UpdateEquipmentTxParams{
+		UserID:    userID,
+		BarWeight: req.BarWeight,
+	}
+
+	for _, plate := range newEquipmentResponse(req.BarWeight, inventory).Plates {
+		args.Weights = append(args.Weights, plate.Weight)
+		args.Pairs = append(args.Pairs, plate.Pairs)
+	}
+
+	res, err := server.store.UpdateEquipmentTx(ctx, args)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type targetWeightReq struct {
+	Weight float32 `form:"weight" binding:"required,gt=0"`
+}
+
+func (server *Server) calculatePlates(ctx *gin.Context) {
+	var uri getUserIdReq
+	var req targetWeightReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	barWeight, inventory, err := server.loadEquipment(ctx, userID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	load, err := util.CalculatePlates(req.Weight, barWeight, inventory)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, load)
+}
+
+func (server *Server) generateWarmup(ctx *gin.Context) {
+	var uri getUserIdReq
+	var req targetWeightReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	barWeight, inventory, err := server.loadEquipment(ctx, userID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, util.WarmupSets(req.Weight, barWeight, inventory))
+}
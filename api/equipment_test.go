@@ -0,0 +1,323 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEquipment(t *testing.T) {
+	userID := uuid.New()
+	plates := []db.PlateInventory{
+		{UserID: userID, Weight: 20, Pairs: 4},
+		{UserID: userID, Weight: 10, Pairs: 2},
+	}
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{UserID: userID, BarWeight: 20}, nil)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Eq(userID)).Times(1).Return(plates, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				res := decodeEquipmentResponse(t, recorder.Body)
+				require.Equal(t, float32(20), res.BarWeight)
+				require.Equal(t, []plateReq{{Weight: 20, Pairs: 4}, {Weight: 10, Pairs: 2}}, res.Plates)
+			},
+		},
+		{
+			name:   "Defaults",
+			userID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{}, sql.ErrNoRows)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Eq(userID)).Times(1).Return([]db.PlateInventory{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				res := decodeEquipmentResponse(t, recorder.Body)
+				require.Equal(t, util.DefaultBarWeight, res.BarWeight)
+				require.Len(t, res.Plates, len(util.DefaultPlates))
+			},
+		},
+		{
+			name:   "InternalError",
+			userID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{}, sql.ErrConnDone)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "WrongUser",
+			userID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/equipment/%s", tc.userID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestUpdateEquipment(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"bar_weight": 20,
+				"plates": []gin.H{
+					{"weight": 10, "pairs": 2},
+					{"weight": 20, "pairs": 4},
+				},
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.UpdateEquipmentTxParams{
+					UserID:    userID,
+					BarWeight: 20,
+					Weights:   []float32{20, 10},
+					Pairs:     []int16{4, 2},
+				}
+				store.EXPECT().UpdateEquipmentTx(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.UpdateEquipmentTxResult{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPlate",
+			body: gin.H{
+				"bar_weight": 20,
+				"plates": []gin.H{
+					{"weight": -10, "pairs": 2},
+				},
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateEquipmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"bar_weight": 20,
+				"plates": []gin.H{
+					{"weight": 10, "pairs": 2},
+				},
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateEquipmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/equipment/%s", userID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestCalculatePlates(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		path       string
+		weight     string
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Plates",
+			path:   "plates",
+			weight: "225",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{}, sql.ErrNoRows)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Eq(userID)).Times(1).Return([]db.PlateInventory{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var load util.PlateLoad
+				err := json.NewDecoder(recorder.Body).Decode(&load)
+				require.NoError(t, err)
+				require.Equal(t, float32(225), load.Achieved)
+				require.Equal(t, []util.Plate{{Weight: 45, Count: 2}}, load.PerSide)
+			},
+		},
+		{
+			name:   "BelowBar",
+			path:   "plates",
+			weight: "20",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{}, sql.ErrNoRows)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Eq(userID)).Times(1).Return([]db.PlateInventory{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "Warmup",
+			path:   "warmup",
+			weight: "225",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{}, sql.ErrNoRows)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Eq(userID)).Times(1).Return([]db.PlateInventory{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var sets []util.WarmupSet
+				err := json.NewDecoder(recorder.Body).Decode(&sets)
+				require.NoError(t, err)
+				require.Equal(t, util.WarmupSets(225, util.DefaultBarWeight, util.DefaultPlates), sets)
+			},
+		},
+		{
+			name:   "MissingWeight",
+			path:   "warmup",
+			weight: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/equipment/%s/%s?weight=%s", tc.path, userID, tc.weight)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func decodeEquipmentResponse(t *testing.T, body *bytes.Buffer) equipmentResp {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var res equipmentResp
+	err = json.Unmarshal(data, &res)
+	require.NoError(t, err)
+	return res
+}
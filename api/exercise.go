@@ -10,27 +10,35 @@ import (
 )
 
 type createExerciseReq struct {
-	Name        string `json:"name" binding:"required,min=3"`
-	MuscleGroup string `json:"muscle_group" binding:"required"`
-	Category    string `json:"category"`
+	Name            string `json:"name" binding:"required,min=3,slugname"`
+	MuscleGroup     string `json:"muscle_group" binding:"required"`
+	Category        string `json:"category"`
+	Equipment       string `json:"equipment" binding:"omitempty,oneof=barbell dumbbell cable machine bands bodyweight"`
+	MovementPattern string `json:"movement_pattern" binding:"omitempty,oneof=squat hinge lunge horizontal_push vertical_push horizontal_pull vertical_pull carry isolation"`
 }
 
 func (server *Server) createExercise(ctx *gin.Context) {
 	var req createExerciseReq
 	if err := ctx.BindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
+	if req.Equipment == "" {
+		req.Equipment = "barbell"
+	}
+
 	args := db.CreateExerciseParams{
-		Name:        req.Name,
-		MuscleGroup: req.MuscleGroup,
-		Category:    req.Category,
+		Name:            req.Name,
+		MuscleGroup:     req.MuscleGroup,
+		Category:        req.Category,
+		Equipment:       req.Equipment,
+		MovementPattern: req.MovementPattern,
 	}
 
 	ex, err := server.store.CreateExercise(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -38,24 +46,43 @@ func (server *Server) createExercise(ctx *gin.Context) {
 }
 
 type getExerciseReq struct {
-	Name string `uri:"name" binding:"required"`
+	Ref string `uri:"ref" binding:"required"`
 }
 
-func (server *Server) getExercise(ctx *gin.Context) {
-	var req getExerciseReq
-	if err := ctx.BindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
+// loadExercise resolves an exercise by id or slug, writing the error response
+// itself when the lookup fails. Exercise names resolve too since they slugify
+// to the stored slug.
+func (server *Server) loadExercise(ctx *gin.Context, ref string) (db.Exercise, bool) {
+	var exercise db.Exercise
+	var err error
+
+	if id, slug := parseRef(ref); slug == "" {
+		exercise, err = server.store.GetExerciseByID(ctx, int32(id))
+	} else {
+		exercise, err = server.store.GetExerciseBySlug(ctx, slug)
 	}
 
-	exercise, err := server.store.GetExercise(ctx, req.Name)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
+			respondError(ctx, http.StatusNotFound, err)
+			return exercise, false
 		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return exercise, false
+	}
+
+	return exercise, true
+}
+
+func (server *Server) getExercise(ctx *gin.Context) {
+	var req getExerciseReq
+	if err := ctx.BindUri(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	exercise, ok := server.loadExercise(ctx, req.Ref)
+	if !ok {
 		return
 	}
 
@@ -63,67 +90,123 @@ func (server *Server) getExercise(ctx *gin.Context) {
 }
 
 type listExercisesReq struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+	Cursor          string   `form:"cursor"`
+	PageID          int32    `form:"page_id" binding:"omitempty,min=1"`
+	PageSize        int32    `form:"page_size" binding:"required,min=5,max=50"`
+	IncludeArchived bool     `form:"include_archived"`
+	Equipment       []string `form:"equipment" binding:"dive,oneof=barbell dumbbell cable machine bands bodyweight"`
+	GymProfileID    string   `form:"gym_profile_id" binding:"omitempty,uuid"`
+}
+
+type exerciseCursor struct {
+	Name    string `json:"name"`
+	Primary bool   `json:"primary,omitempty"`
 }
 
 func (server *Server) listExercises(ctx *gin.Context) {
 	var req listExercisesReq
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	equipment, ok := server.availableEquipment(ctx, req.Equipment, req.GymProfileID)
+	if !ok {
+		return
+	}
+
+	limit, offset, err := pageArgs(req.PageID, req.PageSize, req.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	args := db.ListExercisesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		IncludeArchived: req.IncludeArchived,
+		Equipment:       equipment,
+		Limit:           limit,
+		Offset:          offset,
+	}
+
+	if req.Cursor != "" {
+		var after exerciseCursor
+		if err := decodeCursor(req.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		args.AfterName = sql.NullString{String: after.Name, Valid: true}
 	}
 
 	exs, err := server.store.ListExercises(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, exs)
-}
-
-type listByMuscleGroupReq struct {
-	MuscleGroup string `uri:"muscle_group" binding:"required"`
+	ctx.JSON(http.StatusOK, newListResp(exs, req.PageSize, func(ex db.Exercise) interface{} {
+		return exerciseCursor{Name: ex.Name}
+	}))
 }
 
 func (server *Server) getMuscleGroupExercises(ctx *gin.Context) {
-	var req listByMuscleGroupReq
+	var req getMuscleGroupReq
 	var query listExercisesReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pageArgs(query.PageID, query.PageSize, query.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var after exerciseCursor
+	if query.Cursor != "" {
+		if err := decodeCursor(query.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	muscleGroup, ok := server.loadMuscleGroup(ctx, req.Ref)
+	if !ok {
 		return
 	}
 
 	args := db.ListByMuscleGroupParams{
-		MuscleGroup: req.MuscleGroup,
-		Limit:       query.PageSize,
-		Offset:      (query.PageID - 1) * query.PageSize,
+		MuscleGroup:  muscleGroup.Name,
+		AfterName:    sql.NullString{String: after.Name, Valid: query.Cursor != ""},
+		AfterPrimary: after.Primary,
+		Limit:        limit,
+		Offset:       offset,
 	}
 
 	exercises, err := server.store.ListByMuscleGroup(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, exercises)
+	// primary movers sort ahead of exercises that only work the group
+	// secondarily, so the cursor has to remember which half it stopped in.
+	ctx.JSON(http.StatusOK, newListResp(exercises, query.PageSize, func(ex db.Exercise) interface{} {
+		return exerciseCursor{Name: ex.Name, Primary: ex.MuscleGroup == muscleGroup.Name}
+	}))
 }
 
 type updateExerciseReq struct {
-	Name        string `json:"name"`
-	MuscleGroup string `json:"muscle_group"`
-	Category    string `json:"category"`
+	Name            string `json:"name" binding:"omitempty,slugname"`
+	MuscleGroup     string `json:"muscle_group"`
+	Category        string `json:"category"`
+	Equipment       string `json:"equipment" binding:"omitempty,oneof=barbell dumbbell cable machine bands bodyweight"`
+	MovementPattern string `json:"movement_pattern" binding:"omitempty,oneof=squat hinge lunge horizontal_push vertical_push horizontal_pull vertical_pull carry isolation"`
 }
 
 func (server *Server) updateExercise(ctx *gin.Context) {
@@ -131,30 +214,37 @@ func (server *Server) updateExercise(ctx *gin.Context) {
 	var uri getExerciseReq
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
 		return
 	}
 
 	args := db.UpdateExerciseParams{
-		Name:    uri.Name,
+		Name:    exercise.Name,
 		Column1: req.Name,
 		Column2: req.MuscleGroup,
 		Column3: req.Category,
+		Column4: req.Equipment,
+		Column5: req.MovementPattern,
 	}
 
 	patch, err := server.store.UpdateExercise(context.Background(), args)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -164,15 +254,103 @@ func (server *Server) updateExercise(ctx *gin.Context) {
 func (server *Server) deleteExercise(ctx *gin.Context) {
 	var req getExerciseReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	err := server.store.DeleteExercise(ctx, req.Name)
+	exercise, ok := server.loadExercise(ctx, req.Ref)
+	if !ok {
+		return
+	}
+
+	err := server.store.DeleteExercise(ctx, exercise.Name)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusConflict, errStillReferenced)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+type archiveExerciseReq struct {
+	Archived *bool `json:"archived" binding:"required"`
+}
+
+func (server *Server) archiveExercise(ctx *gin.Context) {
+	var uri getExerciseReq
+	var req archiveExerciseReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	exercise, err := server.store.ArchiveExercise(ctx, db.ArchiveExerciseParams{
+		Archived: *req.Archived,
+		Name:     exercise.Name,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exercise)
+}
+
+type mergeExercisesReq struct {
+	Source string `json:"source" binding:"required"`
+	Target string `json:"target" binding:"required"`
+}
+
+func (server *Server) mergeExercises(ctx *gin.Context) {
+	var req mergeExercisesReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	source, ok := server.loadExercise(ctx, req.Source)
+	if !ok {
+		return
+	}
+
+	target, ok := server.loadExercise(ctx, req.Target)
+	if !ok {
+		return
+	}
+
+	res, err := server.store.MergeExercisesTx(ctx, db.MergeExercisesTxParams{
+		Source: source.Name,
+		Target: target.Name,
+	})
+	if err != nil {
+		switch err {
+		case db.ErrMergeSameExercise:
+			respondError(ctx, http.StatusBadRequest, err)
+		case sql.ErrNoRows:
+			respondError(ctx, http.StatusNotFound, err)
+		default:
+			respondError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/gin-gonic/gin"
+)
+
+var errAliasNotFound = errors.New("Alias not found for this exercise")
+
+type searchExercisesReq struct {
+	Query string `form:"q" binding:"required,min=2"`
+	Limit int32  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+func (server *Server) searchExercises(ctx *gin.Context) {
+	var req searchExercisesReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	exercises, err := server.store.SearchExercises(ctx, db.SearchExercisesParams{
+		Query:  req.Query,
+		UserID: authPayload.UserID,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exercises)
+}
+
+type createExerciseAliasReq struct {
+	Alias string `json:"alias" binding:"required,min=2"`
+}
+
+func (server *Server) createExerciseAlias(ctx *gin.Context) {
+	var uri getExerciseReq
+	var req createExerciseAliasReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	alias, err := server.store.CreateExerciseAlias(ctx, db.CreateExerciseAliasParams{
+		ExerciseName: exercise.Name,
+		Alias:        req.Alias,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alias)
+}
+
+func (server *Server) listExerciseAliases(ctx *gin.Context) {
+	var uri getExerciseReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	aliases, err := server.store.ListExerciseAliases(ctx, exercise.Name)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, aliases)
+}
+
+type deleteExerciseAliasReq struct {
+	Ref   string `uri:"ref" binding:"required"`
+	Alias string `uri:"alias" binding:"required"`
+}
+
+func (server *Server) deleteExerciseAlias(ctx *gin.Context) {
+	var uri deleteExerciseAliasReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	n, err := server.store.DeleteExerciseAlias(ctx, db.DeleteExerciseAliasParams{
+		ExerciseName: exercise.Name,
+		Alias:        uri.Alias,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if n == 0 {
+		respondError(ctx, http.StatusNotFound, errAliasNotFound)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchExercises(t *testing.T) {
+	userID := uuid.New()
+	results := []db.SearchExercisesRow{
+		{ID: 1, Name: "Bench Press", MuscleGroup: "Chest", Score: 1, TimesPerformed: 40},
+		{ID: 2, Name: "Incline Bench Press", MuscleGroup: "Chest", Score: 0.8},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"q": {"bench"}},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.SearchExercisesParams{Query: "bench", UserID: userID, Limit: 10}
+				store.EXPECT().SearchExercises(gomock.Any(), gomock.Eq(args)).Times(1).Return(results, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.SearchExercisesRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, results, res)
+			},
+		},
+		{
+			name:  "CustomLimit",
+			query: url.Values{"q": {"bb bench"}, "limit": {"3"}},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.SearchExercisesParams{Query: "bb bench", UserID: userID, Limit: 3}
+				store.EXPECT().SearchExercises(gomock.Any(), gomock.Eq(args)).Times(1).Return(results, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingQuery",
+			query: url.Values{},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchExercises(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"q": {"bench"}},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchExercises(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Unauthorized",
+			query: url.Values{"q": {"bench"}},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchExercises(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/exercise/search?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestCreateExerciseAlias(t *testing.T) {
+	exercise := generateRandExercise()
+	alias := db.ExerciseAlias{ID: 1, ExerciseName: exercise.Name, Alias: "flat bench"}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"alias": alias.Alias},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.CreateExerciseAliasParams{ExerciseName: exercise.Name, Alias: alias.Alias}
+				store.EXPECT().CreateExerciseAlias(gomock.Any(), gomock.Eq(args)).Times(1).Return(alias, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.ExerciseAlias
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, alias, res)
+			},
+		},
+		{
+			name: "DuplicateAlias",
+			body: gin.H{"alias": alias.Alias},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().CreateExerciseAlias(gomock.Any(), gomock.Any()).Times(1).Return(db.ExerciseAlias{}, &pq.Error{Code: "23505"})
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.AlreadyExists)
+			},
+		},
+		{
+			name: "ExerciseNotFound",
+			body: gin.H{"alias": alias.Alias},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().CreateExerciseAlias(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidBody",
+			body: gin.H{},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExerciseAlias(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{"alias": alias.Alias},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExerciseAlias(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/exercise/%s/alias", exercise.Slug)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestListExerciseAliases(t *testing.T) {
+	exercise := generateRandExercise()
+	aliases := []db.ExerciseAlias{
+		{ID: 1, ExerciseName: exercise.Name, Alias: "bb bench"},
+		{ID: 2, ExerciseName: exercise.Name, Alias: "flat bench"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+	store.EXPECT().ListExerciseAliases(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(aliases, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/exercise/%s/alias", exercise.Slug), nil)
+	require.NoError(t, err)
+
+	addAuthHeader(t, req, server.tokenCreator, bearerType, uuid.New(), time.Minute)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []db.ExerciseAlias
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Equal(t, aliases, res)
+}
+
+func TestDeleteExerciseAlias(t *testing.T) {
+	exercise := generateRandExercise()
+	args := db.DeleteExerciseAliasParams{ExerciseName: exercise.Name, Alias: "bb bench"}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().DeleteExerciseAlias(gomock.Any(), gomock.Eq(args)).Times(1).Return(int64(1), nil)
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().DeleteExerciseAlias(gomock.Any(), gomock.Eq(args)).Times(1).Return(int64(0), nil)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().DeleteExerciseAlias(gomock.Any(), gomock.Eq(args)).Times(1).Return(int64(0), sql.ErrConnDone)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/exercise/%s/alias/%s", exercise.Slug, url.PathEscape(args.Alias))
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, uuid.New(), time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+var errDuplicateMuscleGroup = errors.New("Each muscle group may only be listed once per exercise")
+
+func (server *Server) listExerciseMuscleGroups(ctx *gin.Context) {
+	var uri getExerciseReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	groups, err := server.store.ListExerciseMuscleGroups(ctx, exercise.Name)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// every exercise has at least its primary group, so nothing means no exercise
+	if len(groups) == 0 {
+		respondError(ctx, http.StatusNotFound, sql.ErrNoRows)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
+type secondaryMuscleGroupReq struct {
+	MuscleGroup  string  `json:"muscle_group" binding:"required"`
+	Contribution float32 `json:"contribution" binding:"required,gt=0,lte=1"`
+}
+
+type setExerciseMuscleGroupsReq struct {
+	Primary   string                    `json:"primary" binding:"required"`
+	Secondary []secondaryMuscleGroupReq `json:"secondary" binding:"dive"`
+}
+
+func (server *Server) setExerciseMuscleGroups(ctx *gin.Context) {
+	var uri getExerciseReq
+	var req setExerciseMuscleGroupsReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	args := db.SetExerciseMuscleGroupsTxParams{
+		ExerciseName:  exercise.Name,
+		Primary:       req.Primary,
+		MuscleGroups:  []string{},
+		Contributions: []float32{},
+	}
+
+	seen := map[string]bool{req.Primary: true}
+	for _, group := range req.Secondary {
+		if seen[group.MuscleGroup] {
+			respondError(ctx, http.StatusBadRequest, errDuplicateMuscleGroup)
+			return
+		}
+		seen[group.MuscleGroup] = true
+
+		args.MuscleGroups = append(args.MuscleGroups, group.MuscleGroup)
+		args.Contributions = append(args.Contributions, group.Contribution)
+	}
+
+	groups, err := server.store.SetExerciseMuscleGroupsTx(ctx, args)
+	if err != nil {
+		if err == sql.ErrNoRows || isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
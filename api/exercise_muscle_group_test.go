@@ -0,0 +1,256 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListExerciseMuscleGroups(t *testing.T) {
+	exercise := generateRandExercise()
+	groups := []db.ExerciseMuscleGroup{
+		{ExerciseName: exercise.Name, MuscleGroup: "Back", IsPrimary: true, Contribution: 1},
+		{ExerciseName: exercise.Name, MuscleGroup: "Biceps", Contribution: 0.5},
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().ListExerciseMuscleGroups(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(groups, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.ExerciseMuscleGroup
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, groups, res)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().ListExerciseMuscleGroups(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().ListExerciseMuscleGroups(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/exercise/%s/muscle_groups", exercise.Slug)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, uuid.New(), time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestSetExerciseMuscleGroups(t *testing.T) {
+	exercise := generateRandExercise()
+	groups := []db.ExerciseMuscleGroup{
+		{ExerciseName: exercise.Name, MuscleGroup: "Back", IsPrimary: true, Contribution: 1},
+		{ExerciseName: exercise.Name, MuscleGroup: "Biceps", Contribution: 0.5},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"primary":   "Back",
+				"secondary": []gin.H{{"muscle_group": "Biceps", "contribution": 0.5}},
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.SetExerciseMuscleGroupsTxParams{
+					ExerciseName:  exercise.Name,
+					Primary:       "Back",
+					MuscleGroups:  []string{"Biceps"},
+					Contributions: []float32{0.5},
+				}
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Eq(args)).Times(1).Return(groups, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.ExerciseMuscleGroup
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, groups, res)
+			},
+		},
+		{
+			name: "PrimaryOnly",
+			body: gin.H{"primary": "Back"},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.SetExerciseMuscleGroupsTxParams{
+					ExerciseName:  exercise.Name,
+					Primary:       "Back",
+					MuscleGroups:  []string{},
+					Contributions: []float32{},
+				}
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Eq(args)).Times(1).Return(groups[:1], nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateGroup",
+			body: gin.H{
+				"primary":   "Back",
+				"secondary": []gin.H{{"muscle_group": "Back", "contribution": 0.5}},
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidContribution",
+			body: gin.H{
+				"primary":   "Back",
+				"secondary": []gin.H{{"muscle_group": "Biceps", "contribution": 1.5}},
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownMuscleGroup",
+			body: gin.H{"primary": "Wings"},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Any()).Times(1).Return(nil, &pq.Error{Code: "23503"})
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"primary": "Back"},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{"primary": "Back"},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetExerciseMuscleGroupsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/exercise/%s/muscle_groups", exercise.Slug)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errNoLiftsToSwap = errors.New("Workout has no lifts for the exercise being swapped")
+
+type listExerciseSubstitutesReq struct {
+	Limit        int32    `form:"limit" binding:"omitempty,min=1,max=50"`
+	Equipment    []string `form:"equipment" binding:"dive,oneof=barbell dumbbell cable machine bands bodyweight"`
+	GymProfileID string   `form:"gym_profile_id" binding:"omitempty,uuid"`
+}
+
+func (server *Server) listExerciseSubstitutes(ctx *gin.Context) {
+	var uri getExerciseReq
+	var req listExerciseSubstitutesReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	exercise, ok := server.loadExercise(ctx, uri.Ref)
+	if !ok {
+		return
+	}
+
+	equipment, ok := server.availableEquipment(ctx, req.Equipment, req.GymProfileID)
+	if !ok {
+		return
+	}
+
+	substitutes, err := server.store.ListExerciseSubstitutes(ctx, db.ListExerciseSubstitutesParams{
+		Name:      exercise.Name,
+		Equipment: equipment,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, substitutes)
+}
+
+type swapWorkoutExerciseReq struct {
+	From int32 `json:"from" binding:"required,min=1"`
+	To   int32 `json:"to" binding:"required,min=1,nefield=From"`
+}
+
+// swapWorkoutExercise moves every set of one exercise in a workout onto
+// another, keeping the recorded weight and reps as the targets to hit.
+func (server *Server) swapWorkoutExercise(ctx *gin.Context) {
+	var uri getWorkoutReq
+	var req swapWorkoutExerciseReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	workoutID, err := uuid.Parse(uri.WorkoutId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	lifts, err := server.store.SwapWorkoutExercise(ctx, db.SwapWorkoutExerciseParams{
+		Target:    req.To,
+		WorkoutID: workoutID,
+		UserID:    authPayload.UserID,
+		Source:    req.From,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if len(lifts) == 0 {
+		respondError(ctx, http.StatusNotFound, errNoLiftsToSwap)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lifts)
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListExerciseSubstitutes(t *testing.T) {
+	exercise := generateRandExercise()
+	profile := generateRandGymProfile()
+	substitutes := []db.ListExerciseSubstitutesRow{
+		{ID: 2, Name: "Dumbbell Bench Press", MuscleGroup: "Chest", Equipment: "dumbbell", MovementPattern: "horizontal_push", Score: 1.6},
+		{ID: 3, Name: "Push Up", MuscleGroup: "Chest", Equipment: "bodyweight", MovementPattern: "horizontal_push", Score: 1.2},
+	}
+
+	testCases := []struct {
+		name       string
+		query      url.Values
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.ListExerciseSubstitutesParams{Name: exercise.Name, Equipment: []string{}, Limit: 10}
+				store.EXPECT().ListExerciseSubstitutes(gomock.Any(), gomock.Eq(args)).Times(1).Return(substitutes, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.ListExerciseSubstitutesRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, substitutes, res)
+			},
+		},
+		{
+			name:  "EquipmentFilter",
+			query: url.Values{"equipment": {"dumbbell", "cable"}, "limit": {"3"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.ListExerciseSubstitutesParams{Name: exercise.Name, Equipment: []string{"dumbbell", "cable"}, Limit: 3}
+				store.EXPECT().ListExerciseSubstitutes(gomock.Any(), gomock.Eq(args)).Times(1).Return(substitutes, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "GymProfile",
+			query: url.Values{"gym_profile_id": {profile.ID.String()}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().GetGymProfile(gomock.Any(), gomock.Eq(profile.ID)).Times(1).Return(profile, nil)
+				args := db.ListExerciseSubstitutesParams{Name: exercise.Name, Equipment: []string{"dumbbell", "bands", "bodyweight"}, Limit: 10}
+				store.EXPECT().ListExerciseSubstitutes(gomock.Any(), gomock.Eq(args)).Times(1).Return(substitutes, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "ExerciseNotFound",
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().ListExerciseSubstitutes(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidEquipment",
+			query: url.Values{"equipment": {"kettlebell"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListExerciseSubstitutes(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().ListExerciseSubstitutes(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/exercise/%s/substitutes?%s", exercise.Slug, tc.query.Encode())
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, profile.UserID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestSwapWorkoutExercise(t *testing.T) {
+	userID := uuid.New()
+	workoutID := uuid.New()
+	lift := db.Lift{
+		ID:           uuid.New(),
+		ExerciseID:   12,
+		WeightLifted: 80,
+		Reps:         8,
+		UserID:       userID,
+		WorkoutID:    workoutID,
+	}
+	args := db.SwapWorkoutExerciseParams{
+		Target:    12,
+		WorkoutID: workoutID,
+		UserID:    userID,
+		Source:    7,
+	}
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"from": args.Source, "to": args.Target},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SwapWorkoutExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Lift{lift}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.Lift
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, []db.Lift{lift}, res)
+			},
+		},
+		{
+			name: "NoLifts",
+			body: gin.H{"from": args.Source, "to": args.Target},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SwapWorkoutExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Lift{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownTarget",
+			body: gin.H{"from": args.Source, "to": args.Target},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SwapWorkoutExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(nil, &pq.Error{Code: "23503"})
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "SameExercise",
+			body: gin.H{"from": args.Source, "to": args.Source},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SwapWorkoutExercise(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"from": args.Source, "to": args.Target},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SwapWorkoutExercise(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workout/swap/%s", workoutID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +40,7 @@ func TestCreateExercise(t *testing.T) {
 				"name":         exercise.Name,
 				"muscle_group": exercise.MuscleGroup,
 				"category":     exercise.Category,
+				"equipment":    exercise.Equipment,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
@@ -46,6 +50,7 @@ func TestCreateExercise(t *testing.T) {
 					Name:        exercise.Name,
 					Category:    exercise.Category,
 					MuscleGroup: exercise.MuscleGroup,
+					Equipment:   exercise.Equipment,
 				}
 				store.EXPECT().CreateExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(exercise, nil)
 			},
@@ -65,6 +70,7 @@ func TestCreateExercise(t *testing.T) {
 					Name:        exercise.Name,
 					Category:    exercise.Category,
 					MuscleGroup: exercise.MuscleGroup,
+					Equipment:   exercise.Equipment,
 				}
 				store.EXPECT().CreateExercise(gomock.Any(), gomock.Eq(args)).Times(0).Return(db.Exercise{}, sql.ErrConnDone)
 			},
@@ -78,6 +84,7 @@ func TestCreateExercise(t *testing.T) {
 				"name":         exercise.Name,
 				"muscle_group": exercise.MuscleGroup,
 				"category":     exercise.Category,
+				"equipment":    exercise.Equipment,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
@@ -87,6 +94,7 @@ func TestCreateExercise(t *testing.T) {
 					Name:        exercise.Name,
 					Category:    exercise.Category,
 					MuscleGroup: exercise.MuscleGroup,
+					Equipment:   exercise.Equipment,
 				}
 				store.EXPECT().CreateExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.Exercise{}, sql.ErrConnDone)
 			},
@@ -100,6 +108,7 @@ func TestCreateExercise(t *testing.T) {
 				"name":         exercise.Name,
 				"muscle_group": exercise.MuscleGroup,
 				"category":     exercise.Category,
+				"equipment":    exercise.Equipment,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
@@ -108,6 +117,7 @@ func TestCreateExercise(t *testing.T) {
 					Name:        exercise.Name,
 					Category:    exercise.Category,
 					MuscleGroup: exercise.MuscleGroup,
+					Equipment:   exercise.Equipment,
 				}
 				store.EXPECT().CreateExercise(gomock.Any(), gomock.Eq(args)).Times(0)
 			},
@@ -150,7 +160,7 @@ func TestGetExercise(t *testing.T) {
 	testCases := []struct {
 		name          string
 		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
-		exerciseName  string
+		exerciseRef   string
 		buildStubs    func(store *mockdb.MockStore)
 		checkRes      func(recorder *httptest.ResponseRecorder)
 	}{
@@ -159,9 +169,9 @@ func TestGetExercise(t *testing.T) {
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
-			exerciseName: exercise.Name,
+			exerciseRef: exercise.Slug,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(exercise, nil)
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -169,38 +179,65 @@ func TestGetExercise(t *testing.T) {
 			},
 		},
 		{
-			name:         "NotFound",
-			exerciseName: exercise.Name,
+			name:        "ByID",
+			exerciseRef: fmt.Sprint(exercise.ID),
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(exercise.ID)).Times(1).Return(exercise, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateExerciseResponse(t, recorder.Body, exercise)
+			},
+		},
+		{
+			name:        "ByName",
+			exerciseRef: strings.ToUpper(exercise.Name),
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			exerciseRef: exercise.Slug,
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name:         "InternalError",
-			exerciseName: exercise.Name,
+			name:        "InternalError",
+			exerciseRef: exercise.Slug,
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(db.Exercise{}, sql.ErrConnDone)
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(db.Exercise{}, sql.ErrConnDone)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name:         "Unauthorized",
-			exerciseName: exercise.Name,
+			name:        "Unauthorized",
+			exerciseRef: exercise.Slug,
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(0)
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(0)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -221,7 +258,7 @@ func TestGetExercise(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/exercise/%s", tc.exerciseName)
+			url := fmt.Sprintf("/exercise/%s", tc.exerciseRef)
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -242,6 +279,7 @@ func TestListExercises(t *testing.T) {
 	type Query struct {
 		PageID   int
 		PageSize int
+		Cursor   string
 	}
 
 	testCases := []struct {
@@ -262,14 +300,42 @@ func TestListExercises(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListExercisesParams{
-					Offset: 0,
-					Limit:  int32(n),
+					Equipment: []string{},
+					Offset:    0,
+					Limit:     int32(n) + 1,
 				}
 				store.EXPECT().ListExercises(gomock.Any(), gomock.Eq(args)).Times(1).Return(exercises, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				validateExercisesResponse(t, recorder.Body, exercises)
+
+				res := decodeListResp[db.Exercise](t, recorder.Body)
+				require.Equal(t, exercises, res.Data)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name: "Cursor",
+			query: Query{
+				PageSize: n,
+				Cursor:   encodeCursor(exerciseCursor{Name: exercises[0].Name}),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListExercisesParams{
+					Equipment: []string{},
+					AfterName: sql.NullString{String: exercises[0].Name, Valid: true},
+					Limit:     int32(n) + 1,
+				}
+				store.EXPECT().ListExercises(gomock.Any(), gomock.Eq(args)).Times(1).Return(exercises[1:], nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.Exercise](t, recorder.Body)
+				require.Equal(t, exercises[1:], res.Data)
 			},
 		},
 		{
@@ -338,7 +404,12 @@ func TestListExercises(t *testing.T) {
 			require.NoError(t, err)
 
 			qParams := req.URL.Query()
-			qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			if tc.query.PageID != 0 {
+				qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			}
+			if tc.query.Cursor != "" {
+				qParams.Add("cursor", tc.query.Cursor)
+			}
 			qParams.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
 			req.URL.RawQuery = qParams.Encode()
 
@@ -351,13 +422,17 @@ func TestListExercises(t *testing.T) {
 
 func TestListByMuscleGroup(t *testing.T) {
 	exercises, muscleGroup := createMuscleGroupExercises()
+	group := db.MuscleGroup{ID: 1, Name: muscleGroup, Slug: muscleGroup}
 
 	type Query struct {
 		PageSize    int
 		PageID      int
 		MuscleGroup string
+		Cursor      string
 	}
 
+	secondary := db.Exercise{Name: util.RandomString(5), MuscleGroup: util.RandomString(5)}
+
 	testCases := []struct {
 		name          string
 		query         Query
@@ -376,16 +451,65 @@ func TestListByMuscleGroup(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(group.Slug)).Times(1).Return(group, nil)
 				args := db.ListByMuscleGroupParams{
 					MuscleGroup: muscleGroup,
-					Limit:       5,
+					Limit:       6,
 					Offset:      0,
 				}
 				store.EXPECT().ListByMuscleGroup(gomock.Any(), gomock.Eq(args)).Times(1).Return(exercises, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				validateExercisesResponse(t, recorder.Body, exercises)
+
+				res := decodeListResp[db.Exercise](t, recorder.Body)
+				require.Equal(t, exercises, res.Data)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name: "HasMore",
+			query: Query{
+				PageSize:    5,
+				MuscleGroup: muscleGroup,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(group.Slug)).Times(1).Return(group, nil)
+				store.EXPECT().ListByMuscleGroup(gomock.Any(), gomock.Any()).Times(1).Return(append(exercises, secondary), nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.Exercise](t, recorder.Body)
+				require.Equal(t, exercises, res.Data)
+				require.True(t, res.HasMore)
+				require.Equal(t, encodeCursor(exerciseCursor{Name: exercises[4].Name, Primary: true}), res.NextCursor)
+			},
+		},
+		{
+			name: "CursorPastPrimary",
+			query: Query{
+				PageSize:    5,
+				MuscleGroup: muscleGroup,
+				Cursor:      encodeCursor(exerciseCursor{Name: secondary.Name}),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(group.Slug)).Times(1).Return(group, nil)
+				args := db.ListByMuscleGroupParams{
+					MuscleGroup: muscleGroup,
+					AfterName:   sql.NullString{String: secondary.Name, Valid: true},
+					Limit:       6,
+				}
+				store.EXPECT().ListByMuscleGroup(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Exercise{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
@@ -399,6 +523,7 @@ func TestListByMuscleGroup(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(group.Slug)).Times(1).Return(group, nil)
 				store.EXPECT().ListByMuscleGroup(gomock.Any(), gomock.Any()).Times(1).Return([]db.Exercise{}, sql.ErrConnDone)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
@@ -458,7 +583,12 @@ func TestListByMuscleGroup(t *testing.T) {
 			require.NoError(t, err)
 
 			qParams := req.URL.Query()
-			qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			if tc.query.PageID != 0 {
+				qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			}
+			if tc.query.Cursor != "" {
+				qParams.Add("cursor", tc.query.Cursor)
+			}
 			qParams.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
 			req.URL.RawQuery = qParams.Encode()
 
@@ -472,10 +602,12 @@ func TestListByMuscleGroup(t *testing.T) {
 func TestUpdateExercise(t *testing.T) {
 	srcExercise := generateRandExercise()
 	shiftExercise := db.Exercise{
-		Name:        util.RandomString(5),
-		Category:    util.RandomString(5),
-		MuscleGroup: util.RandomString(5),
-		ID:          srcExercise.ID,
+		Name:            util.RandomString(5),
+		Category:        util.RandomString(5),
+		MuscleGroup:     util.RandomString(5),
+		Equipment:       "cable",
+		MovementPattern: "horizontal_push",
+		ID:              srcExercise.ID,
 	}
 
 	testCases := []struct {
@@ -488,19 +620,24 @@ func TestUpdateExercise(t *testing.T) {
 		{
 			name: "OK",
 			body: gin.H{
-				"name":         shiftExercise.Name,
-				"muscle_group": shiftExercise.MuscleGroup,
-				"category":     shiftExercise.Category,
+				"name":             shiftExercise.Name,
+				"muscle_group":     shiftExercise.MuscleGroup,
+				"category":         shiftExercise.Category,
+				"equipment":        shiftExercise.Equipment,
+				"movement_pattern": shiftExercise.MovementPattern,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(srcExercise.Slug)).Times(1).Return(srcExercise, nil)
 				args := db.UpdateExerciseParams{
 					Name:    srcExercise.Name,
 					Column1: shiftExercise.Name,
 					Column2: shiftExercise.MuscleGroup,
 					Column3: shiftExercise.Category,
+					Column4: shiftExercise.Equipment,
+					Column5: shiftExercise.MovementPattern,
 				}
 				store.EXPECT().UpdateExercise(gomock.Any(), gomock.Eq(args)).Return(shiftExercise, nil)
 			},
@@ -512,19 +649,24 @@ func TestUpdateExercise(t *testing.T) {
 		{
 			name: "InternalError",
 			body: gin.H{
-				"name":         shiftExercise.Name,
-				"muscle_group": shiftExercise.MuscleGroup,
-				"category":     shiftExercise.Category,
+				"name":             shiftExercise.Name,
+				"muscle_group":     shiftExercise.MuscleGroup,
+				"category":         shiftExercise.Category,
+				"equipment":        shiftExercise.Equipment,
+				"movement_pattern": shiftExercise.MovementPattern,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(srcExercise.Slug)).Times(1).Return(srcExercise, nil)
 				args := db.UpdateExerciseParams{
 					Name:    srcExercise.Name,
 					Column1: shiftExercise.Name,
 					Column2: shiftExercise.MuscleGroup,
 					Column3: shiftExercise.Category,
+					Column4: shiftExercise.Equipment,
+					Column5: shiftExercise.MovementPattern,
 				}
 				store.EXPECT().UpdateExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.Exercise{}, sql.ErrConnDone)
 			},
@@ -535,9 +677,11 @@ func TestUpdateExercise(t *testing.T) {
 		{
 			name: "Unauthorized",
 			body: gin.H{
-				"name":         shiftExercise.Name,
-				"muscle_group": shiftExercise.MuscleGroup,
-				"category":     shiftExercise.Category,
+				"name":             shiftExercise.Name,
+				"muscle_group":     shiftExercise.MuscleGroup,
+				"category":         shiftExercise.Category,
+				"equipment":        shiftExercise.Equipment,
+				"movement_pattern": shiftExercise.MovementPattern,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
@@ -547,6 +691,8 @@ func TestUpdateExercise(t *testing.T) {
 					Column1: shiftExercise.Name,
 					Column2: shiftExercise.MuscleGroup,
 					Column3: shiftExercise.Category,
+					Column4: shiftExercise.Equipment,
+					Column5: shiftExercise.MovementPattern,
 				}
 				store.EXPECT().UpdateExercise(gomock.Any(), gomock.Eq(args)).Times(0)
 			},
@@ -599,6 +745,7 @@ func TestDeleteExercise(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
 				store.EXPECT().DeleteExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
@@ -612,12 +759,27 @@ func TestDeleteExercise(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
 				store.EXPECT().DeleteExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(sql.ErrConnDone)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:         "Conflict",
+			exerciseName: exercise.Name,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				store.EXPECT().DeleteExercise(gomock.Any(), gomock.Eq(exercise.Name)).Times(1).Return(&pq.Error{Code: "23503"})
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 		{
 			name:         "Unauthorized",
 			exerciseName: exercise.Name,
@@ -655,12 +817,254 @@ func TestDeleteExercise(t *testing.T) {
 	}
 }
 
+func TestArchiveExercise(t *testing.T) {
+	exercise := generateRandExercise()
+	archived := exercise
+	archived.Archived = true
+
+	testCases := []struct {
+		name          string
+		exerciseName  string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "OK",
+			exerciseName: exercise.Name,
+			body:         gin.H{"archived": true},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.ArchiveExerciseParams{Archived: true, Name: exercise.Name}
+				store.EXPECT().ArchiveExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(archived, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateExerciseResponse(t, recorder.Body, archived)
+			},
+		},
+		{
+			name:         "Restore",
+			exerciseName: exercise.Name,
+			body:         gin.H{"archived": false},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(exercise, nil)
+				args := db.ArchiveExerciseParams{Archived: false, Name: exercise.Name}
+				store.EXPECT().ArchiveExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(exercise, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateExerciseResponse(t, recorder.Body, exercise)
+			},
+		},
+		{
+			name:         "MissingFlag",
+			exerciseName: exercise.Name,
+			body:         gin.H{},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ArchiveExercise(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:         "NotFound",
+			exerciseName: exercise.Name,
+			body:         gin.H{"archived": true},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(exercise.Slug)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().ArchiveExercise(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:         "Unauthorized",
+			exerciseName: exercise.Name,
+			body:         gin.H{"archived": true},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ArchiveExercise(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/exercise/%s/archive", tc.exerciseName)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestMergeExercises(t *testing.T) {
+	source := generateRandExercise()
+	target := generateRandExercise()
+	targetRef := strconv.Itoa(int(target.ID))
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"source": source.Slug, "target": targetRef},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(source.Slug)).Times(1).Return(source, nil)
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				args := db.MergeExercisesTxParams{Source: source.Name, Target: target.Name}
+				store.EXPECT().MergeExercisesTx(gomock.Any(), gomock.Eq(args)).Times(1).
+					Return(db.MergeExercisesTxResult{Exercise: target, LiftsMoved: 12}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.MergeExercisesTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, target, res.Exercise)
+				require.Equal(t, int64(12), res.LiftsMoved)
+			},
+		},
+		{
+			name: "SameExercise",
+			body: gin.H{"source": target.Slug, "target": targetRef},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(target.Slug)).Times(1).Return(target, nil)
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				store.EXPECT().MergeExercisesTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.MergeExercisesTxResult{}, db.ErrMergeSameExercise)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"source": source.Slug, "target": targetRef},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(source.Slug)).Times(1).Return(source, nil)
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().MergeExercisesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"source": source.Slug, "target": targetRef},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq(source.Slug)).Times(1).Return(source, nil)
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				store.EXPECT().MergeExercisesTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.MergeExercisesTxResult{}, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MissingTarget",
+			body: gin.H{"source": source.Name},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MergeExercisesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/exercise/merge", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
 func generateRandExercise() db.Exercise {
+	name := util.RandomString(5)
 	return db.Exercise{
-		Name:        util.RandomString(5),
+		Name:        name,
+		Slug:        util.Slugify(name),
 		Category:    util.RandomString(5),
 		ID:          int32(util.RandomInt(1, 200)),
 		MuscleGroup: util.RandomString(5),
+		Equipment:   "barbell",
 	}
 }
 
@@ -686,13 +1090,3 @@ func validateExerciseResponse(t *testing.T, body *bytes.Buffer, exercise db.Exer
 	require.NoError(t, err)
 	require.Equal(t, exercise, resExercise)
 }
-
-func validateExercisesResponse(t *testing.T, body *bytes.Buffer, exercise []db.Exercise) {
-	data, err := ioutil.ReadAll(body)
-	require.NoError(t, err)
-
-	var resExercises []db.Exercise
-	err = json.Unmarshal(data, &resExercises)
-	require.NoError(t, err)
-	require.Equal(t, exercise, resExercises)
-}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const exportPageSize = 100
+
+type exportReq struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+func (server *Server) exportAccount(ctx *gin.Context) {
+	var uri getAccountReq
+	var req exportReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, id) {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	profile := newAccountResp(account)
+
+	filename := fmt.Sprintf("export-%s-%s", account.ID, time.Now().UTC().Format("20060102"))
+
+	// The response is streamed page by page, so a failure part way through
+	// can only abort the connection and leave a truncated download behind.
+	if req.Format == "csv" {
+		ctx.Header("Content-Type", "application/zip")
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".zip"))
+		ctx.Status(http.StatusOK)
+		err = server.writeCSVExport(ctx, ctx.Writer, profile)
+	} else {
+		ctx.Header("Content-Type", "application/json")
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".json"))
+		ctx.Status(http.StatusOK)
+		err = server.writeJSONExport(ctx, ctx.Writer, profile)
+	}
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Abort()
+	}
+}
+
+func (server *Server) writeJSONExport(ctx context.Context, w io.Writer, profile accountResp) error {
+	encoder := json.NewEncoder(w)
+
+	if _, err := io.WriteString(w, `{"account":`); err != nil {
+		return err
+	}
+	if err := encoder.Encode(profile); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, `,"exercises":[`); err != nil {
+		return err
+	}
+	first := true
+	err := server.eachExercise(ctx, profile.ID, func(exercise db.Exercise) error {
+		return writeJSONElement(w, encoder, &first, exercise)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, `],"workouts":[`); err != nil {
+		return err
+	}
+	first = true
+	err = server.eachWorkout(ctx, profile.ID, func(workout db.Workout) error {
+		return writeJSONElement(w, encoder, &first, workout)
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, `],"lifts":[`); err != nil {
+		return err
+	}
+	first = true
+	err = server.eachLift(ctx, profile.ID, func(lift db.ListLiftsRow) error {
+		return writeJSONElement(w, encoder, &first, lift)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+func writeJSONElement(w io.Writer, encoder *json.Encoder, first *bool, v interface{}) error {
+	if !*first {
+		if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+	}
+	*first = false
+	return encoder.Encode(v)
+}
+
+func (server *Server) writeCSVExport(ctx context.Context, w io.Writer, profile accountResp) error {
+	archive := zip.NewWriter(w)
+
+	file, err := archive.Create("account.csv")
+	if err != nil {
+		return err
+	}
+	records := csv.NewWriter(file)
+	records.Write([]string{"id", "name", "email", "weight", "body_fat", "start_date", "email_verified", "date_of_birth", "height", "preferences"})
+	record := []string{
+		profile.ID.String(),
+		profile.Name,
+		profile.Email,
+		formatFloat(profile.Weight),
+		formatFloat(profile.BodyFat),
+		profile.StartDate.Format(time.RFC3339),
+		strconv.FormatBool(profile.EmailVerified),
+		"",
+		"",
+		string(profile.Preferences),
+	}
+	if profile.DateOfBirth != nil {
+		record[7] = profile.DateOfBirth.Format("2006-01-02")
+	}
+	if profile.Height != nil {
+		record[8] = strconv.FormatFloat(*profile.Height, 'f', -1, 64)
+	}
+	records.Write(record)
+	if err = flushCSV(records); err != nil {
+		return err
+	}
+
+	file, err = archive.Create("exercises.csv")
+	if err != nil {
+		return err
+	}
+	records = csv.NewWriter(file)
+	records.Write([]string{"id", "name", "slug", "muscle_group", "category", "equipment", "movement_pattern", "archived"})
+	err = server.eachExercise(ctx, profile.ID, func(exercise db.Exercise) error {
+		return records.Write([]string{
+			strconv.Itoa(int(exercise.ID)),
+			exercise.Name,
+			exercise.Slug,
+			exercise.MuscleGroup,
+			exercise.Category,
+			exercise.Equipment,
+			exercise.MovementPattern,
+			strconv.FormatBool(exercise.Archived),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err = flushCSV(records); err != nil {
+		return err
+	}
+
+	file, err = archive.Create("workouts.csv")
+	if err != nil {
+		return err
+	}
+	records = csv.NewWriter(file)
+	records.Write([]string{"id", "start_time", "finish_time", "notes", "rating", "energy"})
+	err = server.eachWorkout(ctx, profile.ID, func(workout db.Workout) error {
+		return records.Write([]string{
+			workout.ID.String(),
+			workout.StartTime.Format(time.RFC3339),
+			workout.FinishTime.Format(time.RFC3339),
+			workout.Notes,
+			strconv.Itoa(int(workout.Rating)),
+			strconv.Itoa(int(workout.Energy)),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err = flushCSV(records); err != nil {
+		return err
+	}
+
+	file, err = archive.Create("lifts.csv")
+	if err != nil {
+		return err
+	}
+	records = csv.NewWriter(file)
+	records.Write([]string{"id", "workout_id", "exercise_id", "exercise_name", "weight_lifted", "reps", "notes"})
+	err = server.eachLift(ctx, profile.ID, func(lift db.ListLiftsRow) error {
+		return records.Write([]string{
+			lift.ID.String(),
+			lift.WorkoutID.String(),
+			strconv.Itoa(int(lift.ExerciseID)),
+			lift.ExerciseName,
+			formatFloat(lift.WeightLifted),
+			strconv.Itoa(int(lift.Reps)),
+			lift.Notes,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if err = flushCSV(records); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func flushCSV(records *csv.Writer) error {
+	records.Flush()
+	return records.Error()
+}
+
+func formatFloat(v float32) string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 32)
+}
+
+// eachExercise visits the exercises the user's lifts reference, which is
+// everything an import needs to resolve the exported lifts.
+func (server *Server) eachExercise(ctx context.Context, userID uuid.UUID, fn func(db.Exercise) error) error {
+	var afterID int32
+	for {
+		exercises, err := server.store.ListUserExercises(ctx, db.ListUserExercisesParams{
+			UserID:  userID,
+			AfterID: afterID,
+			Limit:   exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, exercise := range exercises {
+			if err = fn(exercise); err != nil {
+				return err
+			}
+		}
+
+		if len(exercises) < exportPageSize {
+			return nil
+		}
+		afterID = exercises[len(exercises)-1].ID
+	}
+}
+
+func (server *Server) eachWorkout(ctx context.Context, userID uuid.UUID, fn func(db.Workout) error) error {
+	args := db.ListWorkoutsParams{
+		UserID: userID,
+		Limit:  exportPageSize,
+	}
+	for {
+		workouts, err := server.store.ListWorkouts(ctx, args)
+		if err != nil {
+			return err
+		}
+
+		for _, workout := range workouts {
+			if err = fn(workout); err != nil {
+				return err
+			}
+		}
+
+		if len(workouts) < exportPageSize {
+			return nil
+		}
+		last := workouts[len(workouts)-1]
+		args.AfterID = uuid.NullUUID{UUID: last.ID, Valid: true}
+		args.AfterStartTime = last.StartTime
+	}
+}
+
+func (server *Server) eachLift(ctx context.Context, userID uuid.UUID, fn func(db.ListLiftsRow) error) error {
+	args := db.ListLiftsParams{
+		UserID: userID,
+		Limit:  exportPageSize,
+	}
+	for {
+		lifts, err := server.store.ListLifts(ctx, args)
+		if err != nil {
+			return err
+		}
+
+		for _, lift := range lifts {
+			if err = fn(lift); err != nil {
+				return err
+			}
+		}
+
+		if len(lifts) < exportPageSize {
+			return nil
+		}
+		last := lifts[len(lifts)-1]
+		args.AfterID = uuid.NullUUID{UUID: last.ID, Valid: true}
+		args.AfterName = last.ExerciseName
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAccount(t *testing.T) {
+	userID := uuid.New()
+	account := generateRandAccount(userID)
+
+	workouts := make([]db.Workout, exportPageSize)
+	for i := range workouts {
+		workouts[i] = db.Workout{
+			ID:        uuid.New(),
+			UserID:    userID,
+			StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -i),
+			Notes:     fmt.Sprintf("workout %d", i),
+		}
+	}
+	lifts := []db.ListLiftsRow{
+		{ID: uuid.New(), ExerciseID: 4, ExerciseName: "Deadlift", WeightLifted: 315, Reps: 5, UserID: userID, WorkoutID: workouts[0].ID},
+		{ID: uuid.New(), ExerciseID: 9, ExerciseName: "Squat", WeightLifted: 225.5, Reps: 8, UserID: userID, WorkoutID: workouts[0].ID},
+	}
+
+	exercises := []db.Exercise{
+		{ID: 4, Name: "Deadlift", Slug: "deadlift", MuscleGroup: "Back", Category: "Barbell"},
+		{ID: 9, Name: "Squat", Slug: "squat", MuscleGroup: "Legs", Category: "Barbell"},
+	}
+
+	stubPages := func(store *mockdb.MockStore) {
+		store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(userID)).Times(1).Return(account, nil)
+		store.EXPECT().ListUserExercises(gomock.Any(), gomock.Eq(db.ListUserExercisesParams{UserID: userID, Limit: exportPageSize})).
+			Times(1).Return(exercises, nil)
+		last := workouts[len(workouts)-1]
+		store.EXPECT().ListWorkouts(gomock.Any(), gomock.Eq(db.ListWorkoutsParams{UserID: userID, Limit: exportPageSize})).
+			Times(1).Return(workouts, nil)
+		store.EXPECT().ListWorkouts(gomock.Any(), gomock.Eq(db.ListWorkoutsParams{
+			UserID:         userID,
+			AfterID:        uuid.NullUUID{UUID: last.ID, Valid: true},
+			AfterStartTime: last.StartTime,
+			Limit:          exportPageSize,
+		})).Times(1).Return([]db.Workout{}, nil)
+		store.EXPECT().ListLifts(gomock.Any(), gomock.Eq(db.ListLiftsParams{UserID: userID, Limit: exportPageSize})).
+			Times(1).Return(lifts, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		accountID     uuid.UUID
+		format        string
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "JSON",
+			accountID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: stubPages,
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+				var res struct {
+					Account   accountResp       `json:"account"`
+					Exercises []db.Exercise     `json:"exercises"`
+					Workouts  []db.Workout      `json:"workouts"`
+					Lifts     []db.ListLiftsRow `json:"lifts"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, account.Email, res.Account.Email)
+				require.Equal(t, account.EmailVerifiedAt.Valid, res.Account.EmailVerified)
+				require.Equal(t, exercises, res.Exercises)
+				require.Len(t, res.Workouts, len(workouts))
+				require.Equal(t, lifts, res.Lifts)
+			},
+		},
+		{
+			name:      "CSV",
+			accountID: userID,
+			format:    "csv",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: stubPages,
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/zip", recorder.Header().Get("Content-Type"))
+
+				body := recorder.Body.Bytes()
+				archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+				require.NoError(t, err)
+
+				rows := make(map[string][][]string)
+				for _, file := range archive.File {
+					f, err := file.Open()
+					require.NoError(t, err)
+					rows[file.Name], err = csv.NewReader(f).ReadAll()
+					require.NoError(t, err)
+					f.Close()
+				}
+
+				require.Len(t, rows["account.csv"], 2)
+				require.Equal(t, "email_verified", rows["account.csv"][0][6])
+				require.Equal(t, account.Email, rows["account.csv"][1][2])
+				require.Len(t, rows["exercises.csv"], len(exercises)+1)
+				require.Equal(t, []string{"9", "Squat", "squat"}, rows["exercises.csv"][2][:3])
+				require.Len(t, rows["workouts.csv"], len(workouts)+1)
+				require.Len(t, rows["lifts.csv"], len(lifts)+1)
+				require.Equal(t, []string{"9", "Squat", "225.5"}, rows["lifts.csv"][2][2:5])
+			},
+		},
+		{
+			name:      "InvalidFormat",
+			accountID: userID,
+			format:    "xml",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().ListWorkouts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "Forbidden",
+			accountID: userID,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%s/export", tc.accountID)
+			if tc.format != "" {
+				url += "?format=" + tc.format
+			}
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errGymProfileNotFound = errors.New("Gym profile not found")
+
+type gymProfileReq struct {
+	Name      string   `json:"name" binding:"required,min=1"`
+	Equipment []string `json:"equipment" binding:"required,dive,oneof=barbell dumbbell cable machine bands bodyweight"`
+}
+
+type gymProfileUriReq struct {
+	UserID string `uri:"user_id" binding:"required,uuid"`
+	ID     string `uri:"id" binding:"required,uuid"`
+}
+
+// loadGymProfile writes the error response itself and reports whether the
+// caller may continue.
+func (server *Server) loadGymProfile(ctx *gin.Context, id string) (db.GymProfile, bool) {
+	profileID, err := uuid.Parse(id)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return db.GymProfile{}, false
+	}
+
+	profile, err := server.store.GetGymProfile(ctx, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, errGymProfileNotFound)
+			return db.GymProfile{}, false
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return db.GymProfile{}, false
+	}
+
+	if !authorizeUser(ctx, profile.UserID) {
+		return db.GymProfile{}, false
+	}
+
+	return profile, true
+}
+
+// availableEquipment resolves the equipment filter for exercise listings. A
+// gym profile takes precedence over an explicit list, and bodyweight exercises
+// are doable anywhere. An empty result means no filter.
+func (server *Server) availableEquipment(ctx *gin.Context, equipment []string, gymProfileID string) ([]string, bool) {
+	if gymProfileID == "" {
+		if equipment == nil {
+			return []string{}, true
+		}
+		return equipment, true
+	}
+
+	profile, ok := server.loadGymProfile(ctx, gymProfileID)
+	if !ok {
+		return nil, false
+	}
+	return append(profile.Equipment, "bodyweight"), true
+}
+
+func (server *Server) createGymProfile(ctx *gin.Context) {
+	var uri getUserIdReq
+	var req gymProfileReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	profile, err := server.store.CreateGymProfile(ctx, db.CreateGymProfileParams{
+		UserID:    userID,
+		Name:      req.Name,
+		Equipment: req.Equipment,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+func (server *Server) listGymProfiles(ctx *gin.Context) {
+	var uri getUserIdReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	profiles, err := server.store.ListGymProfiles(ctx, userID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profiles)
+}
+
+func (server *Server) updateGymProfile(ctx *gin.Context) {
+	var uri gymProfileUriReq
+	var req gymProfileReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	profileID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	profile, err := server.store.UpdateGymProfile(ctx, db.UpdateGymProfileParams{
+		Name:      req.Name,
+		Equipment: req.Equipment,
+		ID:        profileID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, errGymProfileNotFound)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+func (server *Server) deleteGymProfile(ctx *gin.Context) {
+	var uri gymProfileUriReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	profileID, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	n, err := server.store.DeleteGymProfile(ctx, db.DeleteGymProfileParams{
+		ID:     profileID,
+		UserID: userID,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if n == 0 {
+		respondError(ctx, http.StatusNotFound, errGymProfileNotFound)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,355 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGymProfile(t *testing.T) {
+	profile := generateRandGymProfile()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": profile.Name, "equipment": profile.Equipment},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, profile.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.CreateGymProfileParams{
+					UserID:    profile.UserID,
+					Name:      profile.Name,
+					Equipment: profile.Equipment,
+				}
+				store.EXPECT().CreateGymProfile(gomock.Any(), gomock.Eq(args)).Times(1).Return(profile, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res db.GymProfile
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, profile, res)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: gin.H{"name": profile.Name, "equipment": profile.Equipment},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, profile.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGymProfile(gomock.Any(), gomock.Any()).Times(1).Return(db.GymProfile{}, &pq.Error{Code: "23505"})
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.AlreadyExists)
+			},
+		},
+		{
+			name: "InvalidEquipment",
+			body: gin.H{"name": profile.Name, "equipment": []string{"kettlebell"}},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, profile.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGymProfile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{"name": profile.Name, "equipment": profile.Equipment},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGymProfile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"name": profile.Name, "equipment": profile.Equipment},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, profile.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGymProfile(gomock.Any(), gomock.Any()).Times(1).Return(db.GymProfile{}, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/gym_profile/%s", profile.UserID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestListGymProfiles(t *testing.T) {
+	profile := generateRandGymProfile()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListGymProfiles(gomock.Any(), gomock.Eq(profile.UserID)).Times(1).Return([]db.GymProfile{profile}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/gym_profile/%s", profile.UserID), nil)
+	require.NoError(t, err)
+
+	addAuthHeader(t, req, server.tokenCreator, bearerType, profile.UserID, time.Minute)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res []db.GymProfile
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	require.Equal(t, []db.GymProfile{profile}, res)
+}
+
+func TestUpdateGymProfile(t *testing.T) {
+	profile := generateRandGymProfile()
+	args := db.UpdateGymProfileParams{
+		Name:      profile.Name,
+		Equipment: profile.Equipment,
+		ID:        profile.ID,
+		UserID:    profile.UserID,
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGymProfile(gomock.Any(), gomock.Eq(args)).Times(1).Return(profile, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGymProfile(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.GymProfile{}, sql.ErrNoRows)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGymProfile(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.GymProfile{}, sql.ErrConnDone)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"name": profile.Name, "equipment": profile.Equipment})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/gym_profile/%s/%s", profile.UserID, profile.ID)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, profile.UserID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestDeleteGymProfile(t *testing.T) {
+	profile := generateRandGymProfile()
+	args := db.DeleteGymProfileParams{ID: profile.ID, UserID: profile.UserID}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGymProfile(gomock.Any(), gomock.Eq(args)).Times(1).Return(int64(1), nil)
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGymProfile(gomock.Any(), gomock.Eq(args)).Times(1).Return(int64(0), nil)
+			},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/gym_profile/%s/%s", profile.UserID, profile.ID)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, profile.UserID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestListExercisesByGymProfile(t *testing.T) {
+	profile := generateRandGymProfile()
+	exercises := []db.Exercise{generateRandExercise()}
+
+	testCases := []struct {
+		name          string
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		code          int
+	}{
+		{
+			name: "OK",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, profile.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGymProfile(gomock.Any(), gomock.Eq(profile.ID)).Times(1).Return(profile, nil)
+				args := db.ListExercisesParams{
+					Equipment: []string{"dumbbell", "bands", "bodyweight"},
+					Limit:     6,
+					Offset:    0,
+				}
+				store.EXPECT().ListExercises(gomock.Any(), gomock.Eq(args)).Times(1).Return(exercises, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "ProfileNotFound",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, profile.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGymProfile(gomock.Any(), gomock.Eq(profile.ID)).Times(1).Return(db.GymProfile{}, sql.ErrNoRows)
+				store.EXPECT().ListExercises(gomock.Any(), gomock.Any()).Times(0)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name: "OtherUsersProfile",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGymProfile(gomock.Any(), gomock.Eq(profile.ID)).Times(1).Return(profile, nil)
+				store.EXPECT().ListExercises(gomock.Any(), gomock.Any()).Times(0)
+			},
+			code: http.StatusForbidden,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/exercise?page_id=1&page_size=5&gym_profile_id=%s", profile.ID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func generateRandGymProfile() db.GymProfile {
+	return db.GymProfile{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Name:      util.RandomString(8),
+		Equipment: []string{"dumbbell", "bands"},
+	}
+}
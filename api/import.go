@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/importer"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var errUnknownExercises = errors.New("Import contains exercises that are not in the catalog, supply a mapping or set skip_unknown")
+
+type importReq struct {
+	Source      string `form:"source" binding:"required,oneof=strong hevy fitnotes"`
+	DryRun      bool   `form:"dry_run"`
+	SkipUnknown bool   `form:"skip_unknown"`
+}
+
+type importResp struct {
+	Source   string                 `json:"source"`
+	DryRun   bool                   `json:"dry_run"`
+	Report   importer.MappingReport `json:"report"`
+	Workouts int                    `json:"workouts"`
+	Lifts    int                    `json:"lifts"`
+}
+
+func (server *Server) importWorkouts(ctx *gin.Context) {
+	var uri getUserIdReq
+	var req importReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userID) {
+		return
+	}
+
+	overrides := make(map[string]string)
+	if mapping := ctx.PostForm("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &overrides); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	workouts, err := importer.Parse(importer.Source(req.Source), file)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	catalog, err := server.store.ListExerciseNames(ctx)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	names := make([]string, len(catalog))
+	exerciseIDs := make(map[string]int32, len(catalog))
+	for i, exercise := range catalog {
+		names[i] = exercise.Name
+		exerciseIDs[exercise.Name] = exercise.ID
+	}
+
+	report := importer.MapExercises(workouts, names, overrides)
+	args := newImportWorkoutsParams(userID, workouts, report, exerciseIDs)
+	res := importResp{
+		Source:   req.Source,
+		DryRun:   req.DryRun,
+		Report:   report,
+		Workouts: len(args.Workouts),
+	}
+
+	for _, workout := range args.Workouts {
+		res.Lifts += len(workout.ExerciseIDs)
+	}
+
+	if len(report.Unknown) > 0 && !req.SkipUnknown && !req.DryRun {
+		apiErr := apierr.New(http.StatusUnprocessableEntity, apierr.Unprocessable, errUnknownExercises.Error())
+		respondError(ctx, http.StatusUnprocessableEntity, apiErr.WithDetails(report))
+		return
+	}
+
+	if req.DryRun || len(args.Workouts) == 0 {
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	created, err := server.store.ImportWorkoutsTx(ctx, args)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	res.Workouts = created.Workouts
+	res.Lifts = created.Lifts
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// newImportWorkoutsParams drops sets for exercises that could not be mapped
+// along with any workout left without sets.
+func newImportWorkoutsParams(userID uuid.UUID, workouts []importer.Workout, report importer.MappingReport, exerciseIDs map[string]int32) db.ImportWorkoutsTxParams {
+	args := db.ImportWorkoutsTxParams{UserID: userID}
+
+	for _, workout := range workouts {
+		imported := db.ImportedWorkout{
+			StartTime:  workout.StartTime,
+			FinishTime: workout.FinishTime,
+			Notes:      workout.Notes,
+		}
+
+		for _, set := range workout.Sets {
+			name, ok := report.Mapped[set.Exercise]
+			if !ok {
+				continue
+			}
+
+			imported.ExerciseIDs = append(imported.ExerciseIDs, exerciseIDs[name])
+			imported.Weights = append(imported.Weights, set.Weight)
+			imported.Reps = append(imported.Reps, set.Reps)
+			imported.SetNotes = append(imported.SetNotes, set.Notes)
+		}
+
+		if len(imported.ExerciseIDs) > 0 {
+			args.Workouts = append(args.Workouts, imported)
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const strongExport = `Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2022-11-01 18:30:00,"Push",1h,"Bench Press (Barbell)",1,185,8,0,0,,,
+2022-11-01 18:30:00,"Push",1h,"Cable Fly",1,30,12,0,0,,,
+`
+
+func TestImportWorkouts(t *testing.T) {
+	userID := uuid.New()
+	catalog := []db.ListExerciseNamesRow{
+		{ID: 1, Name: "Bench Press"},
+		{ID: 2, Name: "Deadlift"},
+		{ID: 3, Name: "Pec Deck"},
+	}
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		query         string
+		mapping       string
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			userID:  userID,
+			query:   "source=strong",
+			mapping: `{"Cable Fly": "Pec Deck"}`,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(1).Return(catalog, nil)
+				args := db.ImportWorkoutsTxParams{
+					UserID: userID,
+					Workouts: []db.ImportedWorkout{
+						{
+							StartTime:   time.Date(2022, 11, 1, 18, 30, 0, 0, time.UTC),
+							FinishTime:  time.Date(2022, 11, 1, 19, 30, 0, 0, time.UTC),
+							ExerciseIDs: []int32{1, 3},
+							Weights:     []float32{185, 30},
+							Reps:        []int16{8, 12},
+							SetNotes:    []string{"", ""},
+						},
+					},
+				}
+				store.EXPECT().ImportWorkoutsTx(gomock.Any(), gomock.Eq(args)).Times(1).
+					Return(db.ImportWorkoutsTxResult{Workouts: 1, Lifts: 2}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				res := decodeImportResponse(t, recorder.Body)
+				require.Equal(t, 1, res.Workouts)
+				require.Equal(t, 2, res.Lifts)
+				require.Empty(t, res.Report.Unknown)
+			},
+		},
+		{
+			name:   "DryRun",
+			userID: userID,
+			query:  "source=strong&dry_run=true",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(1).Return(catalog, nil)
+				store.EXPECT().ImportWorkoutsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				res := decodeImportResponse(t, recorder.Body)
+				require.True(t, res.DryRun)
+				require.Equal(t, 1, res.Lifts)
+				require.Equal(t, []string{"Cable Fly"}, res.Report.Unknown)
+			},
+		},
+		{
+			name:   "SkipUnknown",
+			userID: userID,
+			query:  "source=strong&skip_unknown=true",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(1).Return(catalog, nil)
+				store.EXPECT().ImportWorkoutsTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ImportWorkoutsTxResult{Workouts: 1, Lifts: 1}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:   "UnknownExercises",
+			userID: userID,
+			query:  "source=strong",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(1).Return(catalog, nil)
+				store.EXPECT().ImportWorkoutsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+				apiErr := requireErrorCode(t, recorder.Body, apierr.Unprocessable)
+				details, ok := apiErr.Details.(map[string]interface{})
+				require.True(t, ok)
+				require.NotEmpty(t, details["unknown"])
+			},
+		},
+		{
+			name:   "InvalidSource",
+			userID: userID,
+			query:  "source=myfitnesspal",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidFile",
+			userID: userID,
+			query:  "source=hevy",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			userID: userID,
+			query:  "source=strong&skip_unknown=true",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(1).Return(catalog, nil)
+				store.EXPECT().ImportWorkoutsTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ImportWorkoutsTxResult{}, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "Forbidden",
+			userID: userID,
+			query:  "source=strong",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExerciseNames(gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, err := writer.CreateFormFile("file", "export.csv")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(strongExport))
+			require.NoError(t, err)
+			if tc.mapping != "" {
+				require.NoError(t, writer.WriteField("mapping", tc.mapping))
+			}
+			require.NoError(t, writer.Close())
+
+			url := fmt.Sprintf("/import/%s?%s", tc.userID, tc.query)
+			req, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func decodeImportResponse(t *testing.T, body *bytes.Buffer) importResp {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var res importResp
+	err = json.Unmarshal(data, &res)
+	require.NoError(t, err)
+	return res
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/gin-gonic/gin"
+)
+
+const jwksMaxAge = "public, max-age=300"
+
+var errNoKeySet = errors.New("Access tokens are not signed with published keys")
+
+// jwks publishes the public signing keys so other services can verify
+// access tokens without sharing a secret. Keys scheduled for rotation show
+// up here before they start signing.
+func (server *Server) jwks(ctx *gin.Context) {
+	provider, ok := server.tokenCreator.(token.KeySetProvider)
+	if !ok {
+		respondError(ctx, http.StatusNotFound, errNoKeySet)
+		return
+	}
+
+	ctx.Header("Cache-Control", jwksMaxAge)
+	ctx.JSON(http.StatusOK, provider.JWKS())
+}
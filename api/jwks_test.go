@@ -0,0 +1,60 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	recorder := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, req)
+
+	if testTokenType != "jwt_asymmetric" {
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+		requireErrorCode(t, recorder.Body, apierr.NotFound)
+		return
+	}
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, jwksMaxAge, recorder.Header().Get("Cache-Control"))
+
+	var set token.JWKS
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &set))
+	require.Len(t, set.Keys, 1)
+	require.Equal(t, "test", set.Keys[0].KeyID)
+	require.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+	require.NotEmpty(t, set.Keys[0].X)
+
+	// the published key alone is enough to verify an access token
+	accessToken, err := server.tokenCreator.CreateToken(uuid.New(), server.config.AccessDuration)
+	require.NoError(t, err)
+
+	x, err := base64.RawURLEncoding.DecodeString(set.Keys[0].X)
+	require.NoError(t, err)
+
+	parsed, err := jwt.ParseWithClaims(accessToken, &token.Payload{}, func(*jwt.Token) (interface{}, error) {
+		return ed25519.PublicKey(x), nil
+	})
+	require.NoError(t, err)
+	require.True(t, parsed.Valid)
+}
@@ -2,57 +2,85 @@ package api
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
 	"strconv"
 
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type liftPaginationReq struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=1,max=50"`
+	Cursor   string `form:"cursor"`
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=1,max=50"`
+}
+
+type liftCursor struct {
+	ExerciseName string    `json:"exercise_name"`
+	ID           uuid.UUID `json:"id"`
+}
+
+// prCursor keys PR pages on the value being ranked, weight or reps, with the
+// lift id breaking ties.
+type prCursor struct {
+	Value float32   `json:"value"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func prValue(orderBy string, weight float32, reps int16) float32 {
+	if orderBy == "reps" {
+		return float32(reps)
+	}
+	return weight
 }
 
 type createLiftReq struct {
-	ExersiseName string  `json:"exercise_name" binding:"required"`
-	Weight       float32 `json:"weight" binding:"required"`
-	Reps         int16   `json:"reps" binding:"required"`
-	UserId       string  `json:"user_id" binding:"required"`
-	WorkoutID    string  `json:"workout_id" binding:"required"`
+	ExerciseID int32   `json:"exercise_id" binding:"required,min=1"`
+	Weight     float32 `json:"weight" binding:"required"`
+	Reps       int16   `json:"reps" binding:"required"`
+	UserId     string  `json:"user_id" binding:"required"`
+	WorkoutID  string  `json:"workout_id" binding:"required"`
+	Notes      string  `json:"notes"`
 }
 
 func (server *Server) createLift(ctx *gin.Context) {
 	var req createLiftReq
 	if err := ctx.BindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(req.UserId)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	workoutId, err := uuid.Parse(req.WorkoutID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	args := db.CreateLiftParams{
-		ExerciseName: req.ExersiseName,
+		ExerciseID:   req.ExerciseID,
 		WeightLifted: req.Weight,
 		Reps:         req.Reps,
 		UserID:       userId,
 		WorkoutID:    workoutId,
+		Notes:        req.Notes,
 	}
 
 	lift, err := server.store.CreateLift(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -60,9 +88,10 @@ func (server *Server) createLift(ctx *gin.Context) {
 }
 
 type createLiftsReq struct {
-	ExersiseName []string  `json:"exercise_name" binding:"required"`
-	Weight       []float32 `json:"weight" binding:"required"`
-	Reps         []int16   `json:"reps" binding:"required"`
+	ExerciseID []int32   `json:"exercise_id" binding:"required"`
+	Weight     []float32 `json:"weight" binding:"required"`
+	Reps       []int16   `json:"reps" binding:"required"`
+	Notes      []string  `json:"notes"`
 }
 
 type getWorkoutUser struct {
@@ -74,45 +103,54 @@ func (server *Server) createLifts(ctx *gin.Context) {
 	var req createLiftsReq
 	var uri getWorkoutUser
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userID, err := uuid.Parse(uri.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	workoutID, err := uuid.Parse(uri.WorkoutID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	tLen := len(req.Reps)
 	userIDS, workoutIDS := make([]uuid.UUID, tLen), make([]uuid.UUID, tLen)
+	notes := make([]string, tLen)
 
 	for i := 0; i < tLen; i++ {
 		userIDS[i] = userID
 		workoutIDS[i] = workoutID
+		if i < len(req.Notes) {
+			notes[i] = req.Notes[i]
+		}
 	}
 
 	lifts, err := server.store.CreateLifts(ctx, db.CreateLiftsParams{
-		Exercisenames: req.ExersiseName,
-		Reps:          req.Reps,
-		Weights:       req.Weight,
-		UserID:        userIDS,
-		WorkoutID:     workoutIDS,
+		ExerciseIds: req.ExerciseID,
+		Reps:        req.Reps,
+		Weights:     req.Weight,
+		UserID:      userIDS,
+		WorkoutID:   workoutIDS,
+		Notes:       notes,
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -127,19 +165,19 @@ type getLiftReq struct {
 func (server *Server) getLift(ctx *gin.Context) {
 	var req getLiftReq
 	if err := ctx.BindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(req.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	liftId, err := uuid.Parse(req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -148,7 +186,7 @@ func (server *Server) getLift(ctx *gin.Context) {
 		ID:     liftId,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -164,39 +202,57 @@ func (server *Server) listLifts(ctx *gin.Context) {
 	var req liftPaginationReq
 
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.BindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(uri.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pageArgs(req.PageID, req.PageSize, req.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	args := db.ListLiftsParams{
 		UserID: userId,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if req.Cursor != "" {
+		var after liftCursor
+		if err := decodeCursor(req.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		args.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
+		args.AfterName = after.ExerciseName
 	}
 
 	lifts, err := server.store.ListLifts(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, error(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, lifts)
+	ctx.JSON(http.StatusOK, newListResp(lifts, req.PageSize, func(lift db.ListLiftsRow) interface{} {
+		return liftCursor{ExerciseName: lift.ExerciseName, ID: lift.ID}
+	}))
 }
 
 type listPRsReq struct {
 	UserID  string `uri:"user_id" binding:"required"`
-	OrderBy string `uri:"order_by" binding:"required"`
+	OrderBy string `uri:"order_by" binding:"required,oneof=weight reps"`
 }
 
 func (server *Server) listPRs(ctx *gin.Context) {
@@ -204,82 +260,122 @@ func (server *Server) listPRs(ctx *gin.Context) {
 	var req liftPaginationReq
 
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.BindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := uuid.Parse(uri.UserID)
 	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pageArgs(req.PageID, req.PageSize, req.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	args := db.ListPRsParams{
 		UserID:  id,
-		Column2: uri.OrderBy,
-		Column3: uri.OrderBy,
-		Limit:   req.PageSize,
-		Offset:  (req.PageID - 1) * req.PageSize,
+		OrderBy: uri.OrderBy,
+		Limit:   limit,
+		Offset:  offset,
+	}
+
+	if req.Cursor != "" {
+		var after prCursor
+		if err := decodeCursor(req.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		args.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
+		args.AfterValue = after.Value
 	}
 
 	lifts, err := server.store.ListPRs(ctx, args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, error(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, lifts)
+	ctx.JSON(http.StatusOK, newListResp(lifts, req.PageSize, func(lift db.Lift) interface{} {
+		return prCursor{Value: prValue(uri.OrderBy, lift.WeightLifted, lift.Reps), ID: lift.ID}
+	}))
 }
 
 type listPRsByExerciseReq struct {
-	ExerciseName string `uri:"exercise_name" binding:"required"`
-	OrderBy      string `uri:"order_by" binding:"required"`
-	UserID       string `uri:"user_id" binding:"required"`
+	Exercise string `uri:"exercise" binding:"required"`
+	OrderBy  string `uri:"order_by" binding:"required,oneof=weight reps"`
+	UserID   string `uri:"user_id" binding:"required"`
 }
 
 func (server *Server) listPRsByExercise(ctx *gin.Context) {
 	var req listPRsByExerciseReq
 	var query liftPaginationReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(req.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pageArgs(query.PageID, query.PageSize, query.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var after prCursor
+	if query.Cursor != "" {
+		if err := decodeCursor(query.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	exercise, ok := server.loadExercise(ctx, req.Exercise)
+	if !ok {
 		return
 	}
 
 	lifts, err := server.store.ListPRsByExercise(context.Background(), db.ListPRsByExerciseParams{
-		UserID:       userId,
-		ExerciseName: req.ExerciseName,
-		Limit:        query.PageSize,
-		Offset:       (query.PageID - 1) * query.PageSize,
-		Column3:      req.OrderBy,
-		Column4:      req.OrderBy,
+		UserID:     userId,
+		ExerciseID: exercise.ID,
+		OrderBy:    req.OrderBy,
+		AfterID:    uuid.NullUUID{UUID: after.ID, Valid: query.Cursor != ""},
+		AfterValue: after.Value,
+		Limit:      limit,
+		Offset:     offset,
 	})
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, lifts)
+	ctx.JSON(http.StatusOK, newListResp(lifts, query.PageSize, func(lift db.Lift) interface{} {
+		return prCursor{Value: prValue(req.OrderBy, lift.WeightLifted, lift.Reps), ID: lift.ID}
+	}))
 }
 
 type listPRsByMuscleGroupReq struct {
 	UserID      string `uri:"user_id" binding:"required"`
 	MuscleGroup string `uri:"muscle_group" binding:"required"`
-	OrderBy     string `uri:"order_by" binding:"required"`
+	OrderBy     string `uri:"order_by" binding:"required,oneof=weight reps"`
 }
 
 func (server *Server) listPRsByMuscleGroup(ctx *gin.Context) {
@@ -287,40 +383,100 @@ func (server *Server) listPRsByMuscleGroup(ctx *gin.Context) {
 	var query liftPaginationReq
 
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(req.UserID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
+	limit, offset, err := pageArgs(query.PageID, query.PageSize, query.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var after prCursor
+	if query.Cursor != "" {
+		if err := decodeCursor(query.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+	}
+
 	lifts, err := server.store.ListPRsByMuscleGroup(context.Background(), db.ListPRsByMuscleGroupParams{
 		MuscleGroup: req.MuscleGroup,
 		UserID:      userId,
-		Column3:     req.OrderBy,
-		Column4:     req.OrderBy,
-		Limit:       query.PageSize,
-		Offset:      (query.PageID - 1) * query.PageSize,
+		OrderBy:     req.OrderBy,
+		AfterID:     uuid.NullUUID{UUID: after.ID, Valid: query.Cursor != ""},
+		AfterValue:  after.Value,
+		Limit:       limit,
+		Offset:      offset,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, lifts)
+	ctx.JSON(http.StatusOK, newListResp(lifts, query.PageSize, func(lift db.ListPRsByMuscleGroupRow) interface{} {
+		return prCursor{Value: prValue(req.OrderBy, lift.WeightLifted, lift.Reps), ID: lift.ID}
+	}))
+}
+
+type muscleGroupVolumeReq struct {
+	Start int64 `form:"start" binding:"required"`
+	End   int64 `form:"end" binding:"required,gtfield=Start"`
+}
+
+func (server *Server) listMuscleGroupVolume(ctx *gin.Context) {
+	var uri listLiftsReq
+	var req muscleGroupVolumeReq
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userId, err := uuid.Parse(uri.UserID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userId) {
+		return
+	}
+
+	volume, err := server.store.ListMuscleGroupVolume(ctx, db.ListMuscleGroupVolumeParams{
+		UserID:    userId,
+		StartTime: util.FormatMSEpoch(req.Start),
+		EndTime:   util.FormatMSEpoch(req.End),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, volume)
 }
 
 type updateLiftReq struct {
-	WeightLifted string `json:"weight_lifted"`
-	Reps         string `json:"reps"`
+	WeightLifted string  `json:"weight_lifted"`
+	Reps         string  `json:"reps"`
+	Notes        *string `json:"notes"`
 }
 
 type getLiftByIdReq struct {
@@ -334,35 +490,39 @@ func (server *Server) updateLift(ctx *gin.Context) {
 	weightPrecision := 32
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := uuid.Parse(uri.ID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	args.ID = id
 
 	patchedWeight, err := strconv.ParseFloat(req.WeightLifted, weightPrecision)
 	if err == nil {
-		args.Column1 = float32(patchedWeight)
+		args.WeightLifted = sql.NullFloat64{Float64: patchedWeight, Valid: true}
 	}
 
 	patchedReps, err := strconv.Atoi(req.Reps)
 	if err == nil {
-		args.Column2 = int16(patchedReps)
+		args.Reps = sql.NullInt16{Int16: int16(patchedReps), Valid: true}
+	}
+
+	if req.Notes != nil {
+		args.Notes = sql.NullString{String: *req.Notes, Valid: true}
 	}
 
 	patched, err := server.store.UpdateLift(context.Background(), args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -373,19 +533,19 @@ func (server *Server) deleteLift(ctx *gin.Context) {
 	var lift getLiftByIdReq
 
 	if err := ctx.BindUri(&lift); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := uuid.Parse(lift.ID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	err = server.store.DeleteLift(context.Background(), id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -8,9 +8,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
 	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
@@ -34,18 +36,18 @@ func TestCreateLift(t *testing.T) {
 		{
 			name: "OK",
 			body: gin.H{
-				"exercise_name": lift.ExerciseName,
-				"weight":        lift.WeightLifted,
-				"reps":          lift.Reps,
-				"user_id":       lift.UserID,
-				"workout_id":    lift.WorkoutID,
+				"exercise_id": lift.ExerciseID,
+				"weight":      lift.WeightLifted,
+				"reps":        lift.Reps,
+				"user_id":     lift.UserID,
+				"workout_id":  lift.WorkoutID,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.CreateLiftParams{
-					ExerciseName: lift.ExerciseName,
+					ExerciseID:   lift.ExerciseID,
 					WeightLifted: lift.WeightLifted,
 					Reps:         lift.Reps,
 					UserID:       lift.UserID,
@@ -66,7 +68,7 @@ func TestCreateLift(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.CreateLiftParams{
-					ExerciseName: lift.ExerciseName,
+					ExerciseID:   lift.ExerciseID,
 					WeightLifted: lift.WeightLifted,
 					Reps:         lift.Reps,
 					UserID:       lift.UserID,
@@ -81,18 +83,18 @@ func TestCreateLift(t *testing.T) {
 		{
 			name: "InternalError",
 			body: gin.H{
-				"exercise_name": lift.ExerciseName,
-				"weight":        lift.WeightLifted,
-				"reps":          lift.Reps,
-				"user_id":       lift.UserID,
-				"workout_id":    lift.WorkoutID,
+				"exercise_id": lift.ExerciseID,
+				"weight":      lift.WeightLifted,
+				"reps":        lift.Reps,
+				"user_id":     lift.UserID,
+				"workout_id":  lift.WorkoutID,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.CreateLiftParams{
-					ExerciseName: lift.ExerciseName,
+					ExerciseID:   lift.ExerciseID,
 					WeightLifted: lift.WeightLifted,
 					Reps:         lift.Reps,
 					UserID:       lift.UserID,
@@ -107,17 +109,17 @@ func TestCreateLift(t *testing.T) {
 		{
 			name: "Unauthorized",
 			body: gin.H{
-				"exercise_name": lift.ExerciseName,
-				"weight":        lift.WeightLifted,
-				"reps":          lift.Reps,
-				"user_id":       lift.UserID,
-				"workout_id":    lift.WorkoutID,
+				"exercise_id": lift.ExerciseID,
+				"weight":      lift.WeightLifted,
+				"reps":        lift.Reps,
+				"user_id":     lift.UserID,
+				"workout_id":  lift.WorkoutID,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.CreateLiftParams{
-					ExerciseName: lift.ExerciseName,
+					ExerciseID:   lift.ExerciseID,
 					WeightLifted: lift.WeightLifted,
 					Reps:         lift.Reps,
 					UserID:       lift.UserID,
@@ -170,9 +172,9 @@ func TestCreateLifts(t *testing.T) {
 		{
 			name: "OK",
 			body: gin.H{
-				"exercise_name": args.Exercisenames,
-				"weight":        args.Weights,
-				"reps":          args.Reps,
+				"exercise_id": args.ExerciseIds,
+				"weight":      args.Weights,
+				"reps":        args.Reps,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
@@ -201,9 +203,9 @@ func TestCreateLifts(t *testing.T) {
 		{
 			name: "InternalError",
 			body: gin.H{
-				"exercise_name": args.Exercisenames,
-				"weight":        args.Weights,
-				"reps":          args.Reps,
+				"exercise_id": args.ExerciseIds,
+				"weight":      args.Weights,
+				"reps":        args.Reps,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
@@ -218,9 +220,9 @@ func TestCreateLifts(t *testing.T) {
 		{
 			name: "Unauthorized",
 			body: gin.H{
-				"exercise_name": args.Exercisenames,
-				"weight":        args.Weights,
-				"reps":          args.Reps,
+				"exercise_id": args.ExerciseIds,
+				"weight":      args.Weights,
+				"reps":        args.Reps,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
@@ -342,13 +344,28 @@ func TestGetLift(t *testing.T) {
 }
 
 func TestListLifts(t *testing.T) {
-	lifts := generateRandLifts()
+	lifts := make([]db.ListLiftsRow, 5)
+	for i, lift := range generateRandLifts() {
+		lifts[i] = db.ListLiftsRow{
+			ID:           lift.ID,
+			WeightLifted: lift.WeightLifted,
+			Reps:         lift.Reps,
+			UserID:       lift.UserID,
+			WorkoutID:    lift.WorkoutID,
+			ExerciseID:   lift.ExerciseID,
+			ExerciseName: util.RandomString(5),
+		}
+	}
 
 	type Query struct {
 		PageID   int
 		PageSize int
+		Cursor   string
 	}
 
+	extra := append(lifts, db.ListLiftsRow{ID: uuid.New(), ExerciseName: util.RandomString(5)})
+	after := liftCursor{ExerciseName: lifts[4].ExerciseName, ID: lifts[4].ID}
+
 	testCases := []struct {
 		name          string
 		query         Query
@@ -367,7 +384,7 @@ func TestListLifts(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListLiftsParams{
-					Limit:  5,
+					Limit:  6,
 					Offset: 0,
 					UserID: lifts[0].UserID,
 				}
@@ -375,7 +392,91 @@ func TestListLifts(t *testing.T) {
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				validateLiftsResponse(t, recorder.Body, lifts)
+
+				res := decodeListResp[db.ListLiftsRow](t, recorder.Body)
+				require.Equal(t, lifts, res.Data)
+				require.False(t, res.HasMore)
+				require.Empty(t, res.NextCursor)
+			},
+		},
+		{
+			name: "HasMore",
+			query: Query{
+				PageSize: 5,
+				PageID:   1,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLifts(gomock.Any(), gomock.Any()).Times(1).Return(extra, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.ListLiftsRow](t, recorder.Body)
+				require.Equal(t, lifts, res.Data)
+				require.True(t, res.HasMore)
+				require.Equal(t, encodeCursor(after), res.NextCursor)
+			},
+		},
+		{
+			name: "Cursor",
+			query: Query{
+				PageSize: 5,
+				Cursor:   encodeCursor(after),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListLiftsParams{
+					UserID:    lifts[0].UserID,
+					AfterID:   uuid.NullUUID{UUID: after.ID, Valid: true},
+					AfterName: after.ExerciseName,
+					Limit:     6,
+				}
+				store.EXPECT().ListLifts(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.ListLiftsRow{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.ListLiftsRow](t, recorder.Body)
+				require.Empty(t, res.Data)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name: "InvalidCursor",
+			query: Query{
+				PageSize: 5,
+				Cursor:   "not-a-cursor",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLifts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "CursorWithPageID",
+			query: Query{
+				PageSize: 5,
+				PageID:   2,
+				Cursor:   encodeCursor(after),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLifts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
@@ -392,6 +493,12 @@ func TestListLifts(t *testing.T) {
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				apiErr := requireErrorCode(t, recorder.Body, apierr.InvalidArgument)
+				require.ElementsMatch(t, []apierr.FieldError{
+					{Field: "page_id", Reason: "min=1"},
+					{Field: "page_size", Reason: "max=50"},
+				}, apiErr.Fields)
 			},
 		},
 		{
@@ -405,11 +512,11 @@ func TestListLifts(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListLiftsParams{
-					Limit:  5,
+					Limit:  6,
 					Offset: 0,
 					UserID: lifts[0].UserID,
 				}
-				store.EXPECT().ListLifts(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Lift{}, sql.ErrConnDone)
+				store.EXPECT().ListLifts(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.ListLiftsRow{}, sql.ErrConnDone)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -425,7 +532,7 @@ func TestListLifts(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListLiftsParams{
-					Limit:  5,
+					Limit:  6,
 					Offset: 0,
 					UserID: lifts[0].UserID,
 				}
@@ -454,7 +561,12 @@ func TestListLifts(t *testing.T) {
 			require.NoError(t, err)
 
 			qParams := req.URL.Query()
-			qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			if tc.query.PageID != 0 {
+				qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			}
+			if tc.query.Cursor != "" {
+				qParams.Add("cursor", tc.query.Cursor)
+			}
 			qParams.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
 			req.URL.RawQuery = qParams.Encode()
 
@@ -473,8 +585,11 @@ func TestListPrs(t *testing.T) {
 		PageSize int
 		OrderBY  string
 		UserID   uuid.UUID
+		Cursor   string
 	}
 
+	after := prCursor{Value: float32(lifts[4].Reps), ID: lifts[4].ID}
+
 	testCases := []struct {
 		name          string
 		query         Query
@@ -498,7 +613,80 @@ func TestListPrs(t *testing.T) {
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				validateLiftsResponse(t, recorder.Body, lifts)
+				res := decodeListResp[db.Lift](t, recorder.Body)
+				require.Equal(t, lifts, res.Data)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name: "HasMoreByReps",
+			query: Query{
+				PageSize: 5,
+				PageID:   1,
+				OrderBY:  "reps",
+				UserID:   lifts[0].UserID,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListPRsParams{
+					UserID:  lifts[0].UserID,
+					OrderBy: "reps",
+					Limit:   6,
+				}
+				store.EXPECT().ListPRs(gomock.Any(), gomock.Eq(args)).Times(1).Return(append(lifts, generateRandLift()), nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.Lift](t, recorder.Body)
+				require.Equal(t, lifts, res.Data)
+				require.True(t, res.HasMore)
+				require.Equal(t, encodeCursor(after), res.NextCursor)
+			},
+		},
+		{
+			name: "Cursor",
+			query: Query{
+				PageSize: 5,
+				OrderBY:  "reps",
+				UserID:   lifts[0].UserID,
+				Cursor:   encodeCursor(after),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListPRsParams{
+					UserID:     lifts[0].UserID,
+					OrderBy:    "reps",
+					AfterID:    uuid.NullUUID{UUID: after.ID, Valid: true},
+					AfterValue: after.Value,
+					Limit:      6,
+				}
+				store.EXPECT().ListPRs(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Lift{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidOrderBy",
+			query: Query{
+				PageSize: 5,
+				PageID:   1,
+				OrderBY:  "volume",
+				UserID:   lifts[0].UserID,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPRs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
@@ -573,7 +761,12 @@ func TestListPrs(t *testing.T) {
 			require.NoError(t, err)
 
 			qParams := req.URL.Query()
-			qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			if tc.query.PageID != 0 {
+				qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			}
+			if tc.query.Cursor != "" {
+				qParams.Add("cursor", tc.query.Cursor)
+			}
 			qParams.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
 			req.URL.RawQuery = qParams.Encode()
 
@@ -586,13 +779,14 @@ func TestListPrs(t *testing.T) {
 
 func TestListPRsByExercise(t *testing.T) {
 	lifts := generateRandLifts()
+	exercise := db.Exercise{ID: lifts[0].ExerciseID, Name: util.RandomString(5)}
 
 	type Query struct {
-		PageID       int
-		PageSize     int
-		ExerciseName string
-		OrderBy      string
-		UserID       uuid.UUID
+		PageID   int
+		PageSize int
+		Exercise string
+		OrderBy  string
+		UserID   uuid.UUID
 	}
 
 	testCases := []struct {
@@ -605,50 +799,82 @@ func TestListPRsByExercise(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				PageSize:     5,
-				PageID:       1,
-				ExerciseName: lifts[0].ExerciseName,
-				UserID:       lifts[0].UserID,
-				OrderBy:      "weight",
+				PageSize: 5,
+				PageID:   1,
+				Exercise: fmt.Sprint(exercise.ID),
+				UserID:   lifts[0].UserID,
+				OrderBy:  "weight",
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListPRsByExercise(gomock.Any(), gomock.Any()).Times(1).Return(lifts, nil)
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(exercise.ID)).Times(1).Return(exercise, nil)
+				args := db.ListPRsByExerciseParams{
+					UserID:     lifts[0].UserID,
+					ExerciseID: exercise.ID,
+					OrderBy:    "weight",
+					Limit:      6,
+					Offset:     0,
+				}
+				store.EXPECT().ListPRsByExercise(gomock.Any(), gomock.Eq(args)).Times(1).Return(lifts, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				validateLiftsResponse(t, recorder.Body, lifts)
+
+				res := decodeListResp[db.Lift](t, recorder.Body)
+				require.Equal(t, lifts, res.Data)
+				require.False(t, res.HasMore)
 			},
 		},
 		{
 			name: "InternalError",
 			query: Query{
-				PageSize:     5,
-				PageID:       1,
-				ExerciseName: lifts[0].ExerciseName,
-				UserID:       lifts[0].UserID,
-				OrderBy:      "weight",
+				PageSize: 5,
+				PageID:   1,
+				Exercise: fmt.Sprint(exercise.ID),
+				UserID:   lifts[0].UserID,
+				OrderBy:  "weight",
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseByID(gomock.Any(), gomock.Eq(exercise.ID)).Times(1).Return(exercise, nil)
 				store.EXPECT().ListPRsByExercise(gomock.Any(), gomock.Any()).Times(1).Return([]db.Lift{}, sql.ErrConnDone)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "ExerciseNotFound",
+			query: Query{
+				PageSize: 5,
+				PageID:   1,
+				Exercise: "Unknown Lift",
+				UserID:   lifts[0].UserID,
+				OrderBy:  "weight",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetExerciseBySlug(gomock.Any(), gomock.Eq("unknown-lift")).Times(1).Return(db.Exercise{}, sql.ErrNoRows)
+				store.EXPECT().ListPRsByExercise(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name: "Unauthorized",
 			query: Query{
-				PageSize:     5,
-				PageID:       1,
-				ExerciseName: lifts[0].ExerciseName,
-				UserID:       lifts[0].UserID,
-				OrderBy:      "weight",
+				PageSize: 5,
+				PageID:   1,
+				Exercise: fmt.Sprint(exercise.ID),
+				UserID:   lifts[0].UserID,
+				OrderBy:  "weight",
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
@@ -673,7 +899,7 @@ func TestListPRsByExercise(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/lift/pr/%s/%s/%s", tc.query.ExerciseName, tc.query.OrderBy, tc.query.UserID)
+			url := fmt.Sprintf("/lift/pr/%s/%s/%s", url.PathEscape(tc.query.Exercise), tc.query.OrderBy, tc.query.UserID)
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -731,10 +957,20 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().ListPRsByMuscleGroup(gomock.Any(), gomock.Any()).Times(1).Return(lifts, nil)
+				args := db.ListPRsByMuscleGroupParams{
+					MuscleGroup: "Chest",
+					UserID:      userId,
+					OrderBy:     "weight",
+					Limit:       6,
+				}
+				store.EXPECT().ListPRsByMuscleGroup(gomock.Any(), gomock.Eq(args)).Times(1).Return(lifts, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.ListPRsByMuscleGroupRow](t, recorder.Body)
+				require.Equal(t, lifts, res.Data)
+				require.False(t, res.HasMore)
 			},
 		},
 		{
@@ -804,68 +1040,112 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 	}
 }
 
-// @todo Fix this failing test.
-// func TestUpdateLift(t *testing.T) {
-// 	lift := generateRandLift()
-
-// 	type Query struct {
-// 		PageSize int
-// 		PageID   int
-// 	}
-
-// 	testCases := []struct {
-// 		name          string
-// 		query         Query
-// 		body          gin.H
-// 		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
-// 		buildStubs    func(store *mockdb.MockStore)
-// 		checkRes      func(recorder *httptest.ResponseRecorder)
-// 	}{
-// 		{
-// 			name: "OK",
-// 			body: gin.H{
-// 				"weight_lifted": "20.5",
-// 				"reps":          "10",
-// 			},
-// 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
-// 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
-// 			},
-// 			buildStubs: func(store *mockdb.MockStore) {
-// 				args := db.UpdateLiftParams{
-// 					ID:      lift.ID,
-// 					Column1: "20.5",
-// 					Column2: "10",
-// 				}
-// 				store.EXPECT().UpdateLift(gomock.Any(), gomock.Eq(args)).Times(1)
-// 			},
-// 			checkRes: func(recorder *httptest.ResponseRecorder) {
-// 				require.Equal(t, http.StatusOK, recorder.Code)
-// 			},
-// 		},
-// 	}
-
-// 	for i := range testCases {
-// 		tc := testCases[i]
-// 		t.Run(tc.name, func(t *testing.T) {
-// 			ctrl := gomock.NewController(t)
-// 			defer ctrl.Finish()
-
-// 			store := mockdb.NewMockStore(ctrl)
-// 			tc.buildStubs(store)
-
-// 			server := newTestServer(t, store)
-// 			recorder := httptest.NewRecorder()
-
-// 			url := fmt.Sprintf("/lift/%s", lift.ID)
-// 			req, err := http.NewRequest(http.MethodPatch, url, nil)
-// 			require.NoError(t, err)
-
-// 			tc.configureAuth(t, req, server.tokenCreator)
-// 			server.router.ServeHTTP(recorder, req)
-// 			tc.checkRes(recorder)
-// 		})
-// 	}
-// }
+func TestUpdateLift(t *testing.T) {
+	lift := generateRandLift()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"weight_lifted": "20.5",
+				"reps":          "10",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, lift.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.UpdateLiftParams{
+					ID:           lift.ID,
+					WeightLifted: sql.NullFloat64{Float64: 20.5, Valid: true},
+					Reps:         sql.NullInt16{Int16: 10, Valid: true},
+				}
+				store.EXPECT().UpdateLift(gomock.Any(), gomock.Eq(args)).Times(1).Return(lift, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ClearNotes",
+			body: gin.H{
+				"notes": "",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, lift.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.UpdateLiftParams{
+					ID:    lift.ID,
+					Notes: sql.NullString{String: "", Valid: true},
+				}
+				store.EXPECT().UpdateLift(gomock.Any(), gomock.Eq(args)).Times(1).Return(lift, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"reps": "10",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, lift.UserID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateLift(gomock.Any(), gomock.Any()).Times(1).Return(db.Lift{}, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"reps": "10",
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateLift(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/lift/%s", lift.ID)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
 
 func TestDeleteLift(t *testing.T) {
 	lift := generateRandLift()
@@ -930,7 +1210,7 @@ func TestDeleteLift(t *testing.T) {
 func generateRandLift() db.Lift {
 	return db.Lift{
 		ID:           uuid.New(),
-		ExerciseName: util.RandomString(5),
+		ExerciseID:   int32(util.RandomInt(1, 200)),
 		WeightLifted: float32(util.RandomInt(100, 200)),
 		Reps:         int16(util.RandomInt(5, 12)),
 		UserID:       uuid.New(),
@@ -945,22 +1225,23 @@ func generateCompleteWorkoutLifts() (db.CreateLiftsParams, []db.Lift) {
 	workoutID := uuid.New()
 	lifts := make([]db.Lift, n)
 	createLiftsArgs := db.CreateLiftsParams{
-		Exercisenames: make([]string, n),
-		Weights:       make([]float32, n),
-		Reps:          make([]int16, n),
-		UserID:        make([]uuid.UUID, n),
-		WorkoutID:     make([]uuid.UUID, n),
+		ExerciseIds: make([]int32, n),
+		Weights:     make([]float32, n),
+		Reps:        make([]int16, n),
+		UserID:      make([]uuid.UUID, n),
+		WorkoutID:   make([]uuid.UUID, n),
+		Notes:       make([]string, n),
 	}
 
 	for i := 0; i < n; i++ {
-		createLiftsArgs.Exercisenames[i] = util.RandomString(5)
+		createLiftsArgs.ExerciseIds[i] = int32(util.RandomInt(1, 200))
 		createLiftsArgs.Weights[i] = float32(util.RandomInt(100, 220))
 		createLiftsArgs.Reps[i] = int16(util.RandomInt(6, 12))
 		createLiftsArgs.UserID[i] = userID
 		createLiftsArgs.WorkoutID[i] = workoutID
 
 		lifts[i] = db.Lift{
-			ExerciseName: createLiftsArgs.Exercisenames[i],
+			ExerciseID:   createLiftsArgs.ExerciseIds[i],
 			Reps:         createLiftsArgs.Reps[i],
 			WeightLifted: createLiftsArgs.Weights[i],
 			WorkoutID:    createLiftsArgs.WorkoutID[i],
@@ -980,7 +1261,7 @@ func generateRandLifts() []db.Lift {
 	for i := 0; i < n; i++ {
 		lifts[i] = db.Lift{
 			ID:           uuid.New(),
-			ExerciseName: util.RandomString(5),
+			ExerciseID:   int32(util.RandomInt(1, 200)),
 			WeightLifted: float32(util.RandomInt(100, 200)),
 			Reps:         int16(util.RandomInt(5, 12)),
 			UserID:       userID,
@@ -1009,3 +1290,111 @@ func validateLiftsResponse(t *testing.T, body *bytes.Buffer, lift []db.Lift) {
 	require.NoError(t, err)
 	require.Equal(t, lift, resLifts)
 }
+
+func TestListMuscleGroupVolume(t *testing.T) {
+	userID := uuid.New()
+	start := time.Now().Add(-7 * 24 * time.Hour).UnixMilli()
+	end := time.Now().UnixMilli()
+	volume := []db.ListMuscleGroupVolumeRow{
+		{MuscleGroup: "Back", Volume: 12000, Sets: 12},
+		{MuscleGroup: "Biceps", Volume: 6000, Sets: 6},
+	}
+
+	testCases := []struct {
+		name          string
+		start         int64
+		end           int64
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			start: start,
+			end:   end,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListMuscleGroupVolumeParams{
+					UserID:    userID,
+					StartTime: util.FormatMSEpoch(start),
+					EndTime:   util.FormatMSEpoch(end),
+				}
+				store.EXPECT().ListMuscleGroupVolume(gomock.Any(), gomock.Eq(args)).Times(1).Return(volume, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res []db.ListMuscleGroupVolumeRow
+				err := json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				require.Equal(t, volume, res)
+			},
+		},
+		{
+			name:  "InvalidRange",
+			start: end,
+			end:   start,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMuscleGroupVolume(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			start: start,
+			end:   end,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMuscleGroupVolume(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "Forbidden",
+			start: start,
+			end:   end,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListMuscleGroupVolume(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/lift/volume/%s?start=%d&end=%d", userID, tc.start, tc.end)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
@@ -1,21 +1,66 @@
 package api
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
-func newTestServer(t *testing.T, store db.Store) *Server {
-	config := util.Config{
+// testTokenType is the token.Maker the suite currently runs against,
+// TestMain runs every test once per type.
+var testTokenType string
+
+func newTestConfig(t *testing.T) util.Config {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	return util.Config{
 		SecretKey:      util.RandomString(32),
+		TokenType:      testTokenType,
+		PasetoKeys:     "test:" + hex.EncodeToString(key),
+		JWTSigningKeys: "test:" + writeTestSigningKey(t),
 		AccessDuration: time.Minute,
 	}
+}
+
+func writeTestSigningKey(t *testing.T) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "signing.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := newTestConfig(t)
+
+	// every authenticated request looks up when the password last changed
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().GetPasswordChangedAt(gomock.Any(), gomock.Any()).AnyTimes().Return(time.Time{}, nil)
+	}
 
 	server, err := NewServer(config, store)
 	require.NoError(t, err)
@@ -23,8 +68,24 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	return server
 }
 
+func requireErrorCode(t *testing.T, body *bytes.Buffer, code apierr.Code) *apierr.Error {
+	var res apierr.Response
+	err := json.Unmarshal(body.Bytes(), &res)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	require.Equal(t, code, res.Error.Code)
+	return res.Error
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 
-	os.Exit(m.Run())
+	for _, tokenType := range []string{"jwt", "jwt_asymmetric", "paseto_local", "paseto_public"} {
+		testTokenType = tokenType
+		if code := m.Run(); code != 0 {
+			fmt.Printf("tests failed with token type %s\n", tokenType)
+			os.Exit(code)
+		}
+	}
+	os.Exit(0)
 }
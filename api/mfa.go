@@ -0,0 +1,293 @@
+package api
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMFAIssuer       = "isMogged"
+	defaultChallengeExpiry = 5 * time.Minute
+	recoveryCodeCount      = 10
+)
+
+var (
+	errMFAEnabled     = errors.New("Two factor authentication is already enabled")
+	errInvalidMFACode = errors.New("Invalid authentication code")
+	errNotChallenge   = errors.New("Token is not a two factor challenge")
+)
+
+type enrollMFAResp struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// enrollMFA starts (or restarts) setting up an authenticator. Two factor
+// login is not required until the secret is confirmed with a code.
+func (server *Server) enrollMFA(ctx *gin.Context) {
+	var req getAccountReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, id) {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	mfa, err := server.store.GetAccountMFA(ctx, account.ID)
+	if err != nil && err != sql.ErrNoRows {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err == nil && mfa.ConfirmedAt.Valid {
+		respondError(ctx, http.StatusConflict, errMFAEnabled)
+		return
+	}
+
+	secret, err := util.NewTOTPSecret()
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = server.store.UpsertAccountMFA(ctx, db.UpsertAccountMFAParams{
+		UserID: account.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	issuer := server.config.MFAIssuer
+	if issuer == "" {
+		issuer = defaultMFAIssuer
+	}
+
+	ctx.JSON(http.StatusOK, enrollMFAResp{
+		Secret:          secret,
+		ProvisioningURI: util.TOTPURI(issuer, account.Email, secret),
+	})
+}
+
+type confirmMFAReq struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type confirmMFAResp struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// confirmMFA enables two factor login once the user shows their
+// authenticator produces valid codes. Recovery codes are only ever returned
+// here, just their hashes are stored.
+func (server *Server) confirmMFA(ctx *gin.Context) {
+	var uri getAccountReq
+	var req confirmMFAReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, id) {
+		return
+	}
+
+	mfa, err := server.store.GetAccountMFA(ctx, id)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if mfa.ConfirmedAt.Valid {
+		respondError(ctx, http.StatusConflict, errMFAEnabled)
+		return
+	}
+
+	now := time.Now().UTC()
+	step, ok := util.ValidateTOTP(mfa.Secret, req.Code, now, mfa.LastUsedStep)
+	if !ok {
+		respondError(ctx, http.StatusBadRequest, errInvalidMFACode)
+		return
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = newRecoveryCode()
+		if err != nil {
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		hashes[i] = util.HashSecret(normalizeRecoveryCode(codes[i]))
+	}
+
+	err = server.store.ConfirmMFATx(ctx, db.ConfirmMFATxParams{
+		UserID:      id,
+		ConfirmedAt: now,
+		Step:        step,
+		CodeHashes:  hashes,
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, confirmMFAResp{RecoveryCodes: codes})
+}
+
+type loginMFAReq struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// loginMFA is the second step of a two factor login. code is either the
+// current TOTP code or one of the unused recovery codes.
+func (server *Server) loginMFA(ctx *gin.Context) {
+	var req loginMFAReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	payload, err := server.tokenCreator.VerifyToken(req.ChallengeToken)
+	if err != nil {
+		respondError(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	if !payload.Challenge {
+		respondError(ctx, http.StatusUnauthorized, errNotChallenge)
+		return
+	}
+
+	mfaKey := "mfa:" + payload.UserID.String()
+	ipKey := "ip:" + ctx.ClientIP()
+
+	if !server.loginAllowed(ctx, mfaKey, ipKey) {
+		return
+	}
+
+	mfa, err := server.store.GetAccountMFA(ctx, payload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusUnauthorized, errUnknownOwner)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ok, err := server.useMFACode(ctx, mfa, req.Code)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !ok {
+		server.loginFailed(ctx, mfaKey, ipKey, errInvalidMFACode)
+		return
+	}
+
+	if err := server.loginLimiter.Reset(ctx, mfaKey); err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, payload.UserID)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	jwt, err := server.tokenCreator.CreateToken(account.ID, server.config.AccessDuration)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LoginRes{
+		JWT: jwt,
+		User: UserRes{
+			ID:                account.ID,
+			Email:             account.Email,
+			PasswordChangedAt: account.PasswordChangedAt,
+			StartDate:         account.StartDate,
+		},
+	})
+}
+
+// useMFACode consumes code if it is valid. Marking the TOTP step or recovery
+// code as used only succeeds once, so two requests racing with the same code
+// cannot both log in.
+func (server *Server) useMFACode(ctx *gin.Context, mfa db.AccountMfa, code string) (bool, error) {
+	now := time.Now().UTC()
+
+	if step, ok := util.ValidateTOTP(mfa.Secret, code, now, mfa.LastUsedStep); ok {
+		n, err := server.store.UpdateMFALastUsedStep(ctx, db.UpdateMFALastUsedStepParams{
+			Step:   step,
+			UserID: mfa.UserID,
+		})
+		return n == 1, err
+	}
+
+	n, err := server.store.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{
+		Now:      now,
+		CodeHash: util.HashSecret(normalizeRecoveryCode(code)),
+		UserID:   mfa.UserID,
+	})
+	return n == 1, err
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCode returns a code like "k3j9x-2mfq7" that is easy to copy
+// down by hand.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
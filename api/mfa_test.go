@@ -0,0 +1,421 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomMFA(t *testing.T, userID uuid.UUID, confirmed bool) db.AccountMfa {
+	secret, err := util.NewTOTPSecret()
+	require.NoError(t, err)
+
+	mfa := db.AccountMfa{UserID: userID, Secret: secret}
+	if confirmed {
+		mfa.ConfirmedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+	return mfa
+}
+
+func currentTOTP(t *testing.T, secret string) string {
+	code, err := util.TOTPCode(secret, util.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestEnrollMFA(t *testing.T) {
+	account := generateRandAccount(uuid.New())
+
+	testCases := []struct {
+		name       string
+		setupAuth  func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.AccountMfa{}, sql.ErrNoRows)
+				store.EXPECT().UpsertAccountMFA(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpsertAccountMFAParams) (db.AccountMfa, error) {
+						require.Equal(t, account.ID, arg.UserID)
+						require.NotEmpty(t, arg.Secret)
+						return db.AccountMfa{UserID: arg.UserID, Secret: arg.Secret}, nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res enrollMFAResp
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+
+				uri, err := url.Parse(res.ProvisioningURI)
+				require.NoError(t, err)
+				require.Equal(t, res.Secret, uri.Query().Get("secret"))
+				require.Equal(t, defaultMFAIssuer, uri.Query().Get("issuer"))
+				require.Contains(t, uri.Path, account.Email)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(randomMFA(t, account.ID, true), nil)
+				store.EXPECT().UpsertAccountMFA(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			setupAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, account.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMfa{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%s/mfa", account.ID)
+			req, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
+
+func TestConfirmMFA(t *testing.T) {
+	userID := uuid.New()
+	pending := randomMFA(t, userID, false)
+
+	testCases := []struct {
+		name       string
+		body       func(t *testing.T) gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": currentTOTP(t, pending.Secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(userID)).Times(1).Return(pending, nil)
+				store.EXPECT().ConfirmMFATx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ConfirmMFATxParams) error {
+						require.Equal(t, userID, arg.UserID)
+						require.Len(t, arg.CodeHashes, recoveryCodeCount)
+						require.InDelta(t, util.TOTPStep(time.Now()), arg.Step, 1)
+						return nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res confirmMFAResp
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.RecoveryCodes, recoveryCodeCount)
+				require.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, res.RecoveryCodes[0])
+			},
+		},
+		{
+			name: "WrongCode",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": "000000"}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(userID)).Times(1).Return(pending, nil)
+				store.EXPECT().ConfirmMFATx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": "123456"}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.AccountMfa{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyConfirmed",
+			body: func(t *testing.T) gin.H {
+				return gin.H{"code": "123456"}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(userID)).Times(1).Return(randomMFA(t, userID, true), nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "MissingCode",
+			body: func(t *testing.T) gin.H {
+				return gin.H{}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body(t))
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%s/mfa/confirm", userID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
+
+func TestLoginMFA(t *testing.T) {
+	account := generateRandAccount(uuid.New())
+	mfa := randomMFA(t, account.ID, true)
+
+	challenge := func(t *testing.T, tokenCreator token.Maker) string {
+		token, err := tokenCreator.CreateChallengeToken(account.ID, time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name       string
+		body       func(t *testing.T, tokenCreator token.Maker) gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "TOTP",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				return gin.H{"challenge_token": challenge(t, tokenCreator), "code": currentTOTP(t, mfa.Secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(mfa, nil)
+				store.EXPECT().UpdateMFALastUsedStep(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateMFALastUsedStepParams) (int64, error) {
+						require.InDelta(t, util.TOTPStep(time.Now()), arg.Step, 1)
+						return 1, nil
+					})
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res LoginRes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+
+				payload, err := server.tokenCreator.VerifyToken(res.JWT)
+				require.NoError(t, err)
+				require.False(t, payload.Challenge)
+				require.Equal(t, account.ID, payload.UserID)
+			},
+		},
+		{
+			name: "TOTPReplayed",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				return gin.H{"challenge_token": challenge(t, tokenCreator), "code": currentTOTP(t, mfa.Secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				used := mfa
+				used.LastUsedStep = util.TOTPStep(time.Now()) + 1
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(used, nil)
+				store.EXPECT().UpdateMFALastUsedStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RecoveryCode",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				return gin.H{"challenge_token": challenge(t, tokenCreator), "code": " ABCDE-FGHIJ "}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(mfa, nil)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UseRecoveryCodeParams) (int64, error) {
+						require.Equal(t, util.HashSecret("abcdefghij"), arg.CodeHash)
+						require.Equal(t, account.ID, arg.UserID)
+						return 1, nil
+					})
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "WrongCodeLocksOut",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				return gin.H{"challenge_token": challenge(t, tokenCreator), "code": "not-a-code"}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(mfa, nil)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				apiErr := requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+				require.Equal(t, errInvalidMFACode.Error(), apiErr.Message)
+
+				// the failure above counts towards the lockout
+				for i := 0; i < 4; i++ {
+					_, err := server.loginLimiter.Fail(context.Background(), "mfa:"+account.ID.String())
+					require.NoError(t, err)
+				}
+				wait, err := server.loginLimiter.Check(context.Background(), "mfa:"+account.ID.String())
+				require.NoError(t, err)
+				require.NotZero(t, wait)
+			},
+		},
+		{
+			name: "AccessTokenRejected",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				token, err := tokenCreator.CreateToken(account.ID, time.Minute)
+				require.NoError(t, err)
+				return gin.H{"challenge_token": token, "code": currentTOTP(t, mfa.Secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiredChallenge",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				token, err := tokenCreator.CreateChallengeToken(account.ID, -time.Minute)
+				require.NoError(t, err)
+				return gin.H{"challenge_token": token, "code": currentTOTP(t, mfa.Secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: func(t *testing.T, tokenCreator token.Maker) gin.H {
+				return gin.H{"challenge_token": challenge(t, tokenCreator), "code": currentTOTP(t, mfa.Secret)}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMfa{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body(t, server.tokenCreator))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/login/mfa", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(t, server, recorder)
+		})
+	}
+}
@@ -1,13 +1,26 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/revocation"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var (
+	errNotOwner     = errors.New("This account does not belong to the authenticated user")
+	errStaleToken   = errors.New("Token was issued before the last password change")
+	errRevokedToken = errors.New("Token has been revoked")
+	errUnknownOwner = errors.New("Token belongs to an account that no longer exists")
+	errUnverified   = errors.New("Email address must be verified before making changes")
+	errChallengeUse = errors.New("Two factor challenge tokens cannot be used to access the API")
 )
 
 const (
@@ -16,34 +29,78 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authenticationMiddleware(tokenCreator token.Maker) gin.HandlerFunc {
+func authenticationMiddleware(tokenCreator token.Maker, store db.Store, revocations revocation.Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 
 		if len(authorizationHeader) == 0 {
 			err := errors.New("Authorization header is not provided")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			respondError(ctx, http.StatusUnauthorized, err)
 			return
 		}
 
 		fields := strings.Fields(authorizationHeader)
 		if len(fields) < 2 {
 			err := errors.New("Invalid authorization header format")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			respondError(ctx, http.StatusUnauthorized, err)
 			return
 		}
 
 		authorizationType := strings.ToLower(fields[0])
 		if authorizationType != bearerType {
 			err := fmt.Errorf("%s authorization type is not currently supported", authorizationType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			respondError(ctx, http.StatusUnauthorized, err)
 			return
 		}
 
+		// api keys are checked against their own revoked_at rather than the
+		// password change time and token revocations, see ResetPasswordTx
 		accessToken := fields[1]
+		if strings.HasPrefix(accessToken, apiKeyPrefix) {
+			payload, ok := authenticateAPIKey(ctx, store, accessToken)
+			if !ok {
+				return
+			}
+
+			ctx.Set(authorizationPayloadKey, payload)
+			ctx.Next()
+			return
+		}
+
 		payload, err := tokenCreator.VerifyToken(accessToken)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			respondError(ctx, http.StatusUnauthorized, err)
+			return
+		}
+
+		if payload.Challenge {
+			respondError(ctx, http.StatusUnauthorized, errChallengeUse)
+			return
+		}
+
+		changedAt, err := store.GetPasswordChangedAt(ctx, payload.UserID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				respondError(ctx, http.StatusUnauthorized, errUnknownOwner)
+				return
+			}
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if payload.IssuedAt.Before(changedAt) {
+			respondError(ctx, http.StatusUnauthorized, errStaleToken)
+			return
+		}
+
+		revoked, err := revocations.IsRevoked(ctx, payload)
+		if err != nil {
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if revoked {
+			respondError(ctx, http.StatusUnauthorized, errRevokedToken)
 			return
 		}
 
@@ -51,3 +108,42 @@ func authenticationMiddleware(tokenCreator token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// verifiedEmailMiddleware rejects requests that change data from accounts
+// that have not verified their email. Reads are always allowed.
+func verifiedEmailMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		verifiedAt, err := store.GetEmailVerifiedAt(ctx, authPayload.UserID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				respondError(ctx, http.StatusUnauthorized, errUnknownOwner)
+				return
+			}
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !verifiedAt.Valid {
+			respondError(ctx, http.StatusForbidden, errUnverified)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func authorizeUser(ctx *gin.Context, userID uuid.UUID) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if userID != authPayload.UserID {
+		respondError(ctx, http.StatusForbidden, errNotOwner)
+		return false
+	}
+	return true
+}
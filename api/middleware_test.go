@@ -1,14 +1,18 @@
 package api
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -28,20 +32,63 @@ func addAuthHeader(
 }
 
 func TestAuthenticationMiddleware(t *testing.T) {
+	userID := uuid.New()
+
 	testCases := []struct {
 		name          string
 		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
 		checkRes      func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
-				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				changedAt := time.Now().Add(-time.Hour)
+				store.EXPECT().GetPasswordChangedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(changedAt, nil)
 			},
 			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "PasswordChangedAfterIssue",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				changedAt := time.Now().Add(time.Second)
+				store.EXPECT().GetPasswordChangedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(changedAt, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "AccountDeleted",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPasswordChangedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(time.Time{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ChallengeToken",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				challenge, err := tokenCreator.CreateChallengeToken(userID, time.Minute)
+				require.NoError(t, err)
+				request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", bearerType, challenge))
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 		{
 			name: "NoAuthorization",
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
@@ -82,10 +129,18 @@ func TestAuthenticationMiddleware(t *testing.T) {
 	for i := range testCases {
 		tc := testCases[i]
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			}
+
+			server := newTestServer(t, store)
 
 			route := "/auth"
-			server.router.GET(route, authenticationMiddleware(server.tokenCreator), func(ctx *gin.Context) {
+			server.router.GET(route, authenticationMiddleware(server.tokenCreator, server.store, server.revocations), func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{})
 			})
 
@@ -99,3 +154,110 @@ func TestAuthenticationMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifiedEmailMiddleware(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		method     string
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name:   "Verified",
+			method: http.MethodPost,
+			buildStubs: func(store *mockdb.MockStore) {
+				verifiedAt := sql.NullTime{Time: time.Now().UTC(), Valid: true}
+				store.EXPECT().GetEmailVerifiedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(verifiedAt, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:   "Unverified",
+			method: http.MethodPost,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmailVerifiedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(sql.NullTime{}, nil)
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name:   "UnverifiedRead",
+			method: http.MethodGet,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmailVerifiedAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:   "InternalError",
+			method: http.MethodDelete,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmailVerifiedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(sql.NullTime{}, sql.ErrConnDone)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			route := "/verified"
+			server.router.Handle(tc.method, route,
+				authenticationMiddleware(server.tokenCreator, server.store, server.revocations),
+				verifiedEmailMiddleware(server.store),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(tc.method, route, nil)
+			require.NoError(t, err)
+
+			addAuthHeader(t, request, server.tokenCreator, bearerType, userID, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestRequireVerifiedEmailRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetPasswordChangedAt(gomock.Any(), gomock.Any()).AnyTimes().Return(time.Time{}, nil)
+	store.EXPECT().GetEmailVerifiedAt(gomock.Any(), gomock.Eq(userID)).Times(1).Return(sql.NullTime{}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.Account{}, sql.ErrConnDone)
+
+	config := newTestConfig(t)
+	config.RequireVerifiedEmail = true
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	// resending the email must stay reachable for unverified accounts
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%s/verification", userID), nil)
+	require.NoError(t, err)
+	addAuthHeader(t, request, server.tokenCreator, bearerType, userID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodPost, "/category", nil)
+	require.NoError(t, err)
+	addAuthHeader(t, request, server.tokenCreator, bearerType, userID, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
@@ -10,19 +10,19 @@ import (
 )
 
 type createMuscleGroupReq struct {
-	Name string `json:"name" binding:"required,min=3"`
+	Name string `json:"name" binding:"required,min=3,slugname"`
 }
 
 func (server *Server) createMuscleGroup(ctx *gin.Context) {
 	var req createMuscleGroupReq
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	mg, err := server.store.CreateMuscleGroup(ctx, strings.ToLower(req.Name))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -30,24 +30,42 @@ func (server *Server) createMuscleGroup(ctx *gin.Context) {
 }
 
 type getMuscleGroupReq struct {
-	Name string `uri:"name" binding:"required"`
+	Ref string `uri:"ref" binding:"required"`
 }
 
-func (server *Server) getMuscleGroup(ctx *gin.Context) {
-	var req getMuscleGroupReq
-	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
+// loadMuscleGroup resolves a muscle group by id or slug, writing the error
+// response itself when the lookup fails.
+func (server *Server) loadMuscleGroup(ctx *gin.Context, ref string) (db.MuscleGroup, bool) {
+	var muscleGroup db.MuscleGroup
+	var err error
+
+	if id, slug := parseRef(ref); slug == "" {
+		muscleGroup, err = server.store.GetMuscleGroupByID(ctx, int16(id))
+	} else {
+		muscleGroup, err = server.store.GetMuscleGroupBySlug(ctx, slug)
 	}
 
-	muscleGroup, err := server.store.GetMuscleGroup(ctx, req.Name)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
+			respondError(ctx, http.StatusNotFound, err)
+			return muscleGroup, false
 		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return muscleGroup, false
+	}
+
+	return muscleGroup, true
+}
+
+func (server *Server) getMuscleGroup(ctx *gin.Context) {
+	var req getMuscleGroupReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	muscleGroup, ok := server.loadMuscleGroup(ctx, req.Ref)
+	if !ok {
 		return
 	}
 
@@ -57,38 +75,44 @@ func (server *Server) getMuscleGroup(ctx *gin.Context) {
 func (server *Server) listMuscleGroups(ctx *gin.Context) {
 	muscleGroups, err := server.store.GetMuscleGroups(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, muscleGroups)
 }
 
-type updateMuscleGroupReq struct {
-	GroupName   string `json:"name" binding:"required"`
-	UpdatedName string `json:"updated_name" binding:"required"`
-}
-
 func (server *Server) updateMuscleGroup(ctx *gin.Context) {
-	var req updateMuscleGroupReq
+	var uri getMuscleGroupReq
+	var req createMuscleGroupReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	muscleGroup, ok := server.loadMuscleGroup(ctx, uri.Ref)
+	if !ok {
 		return
 	}
 
 	arg := db.UpdateGroupParams{
-		Name:   strings.ToLower(req.UpdatedName),
-		Name_2: strings.ToLower(req.GroupName),
+		Name: strings.ToLower(req.Name),
+		ID:   muscleGroup.ID,
 	}
 
 	patch, err := server.store.UpdateGroup(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -98,18 +122,28 @@ func (server *Server) updateMuscleGroup(ctx *gin.Context) {
 func (server *Server) deleteMuscleGroup(ctx *gin.Context) {
 	var req getMuscleGroupReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	muscleGroup, ok := server.loadMuscleGroup(ctx, req.Ref)
+	if !ok {
 		return
 	}
 
-	d, err := server.store.DeleteGroup(ctx, req.Name)
+	d, err := server.store.DeleteGroup(ctx, muscleGroup.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+
+		if isForeignKeyViolation(err) {
+			respondError(ctx, http.StatusConflict, errStillReferenced)
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
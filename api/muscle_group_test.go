@@ -121,12 +121,39 @@ func TestGetMuscleGroup(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetMuscleGroup(gomock.Any(), gomock.Eq(muscleGroup.Name)).Times(1).Return(muscleGroup, nil)
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(1).Return(muscleGroup, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:        "ByID",
+			muscleGroup: fmt.Sprint(muscleGroup.ID),
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupByID(gomock.Any(), gomock.Eq(muscleGroup.ID)).Times(1).Return(muscleGroup, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				validateMuscleGroupResponse(t, recorder.Body, muscleGroup)
+			},
+		},
+		{
+			name:        "NotFound",
+			muscleGroup: muscleGroup.Name,
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(1).Return(db.MuscleGroup{}, sql.ErrNoRows)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name:        "InternalError",
 			muscleGroup: muscleGroup.Name,
@@ -134,7 +161,7 @@ func TestGetMuscleGroup(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetMuscleGroup(gomock.Any(), gomock.Eq(muscleGroup.Name)).Times(1).Return(db.MuscleGroup{}, sql.ErrConnDone)
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(1).Return(db.MuscleGroup{}, sql.ErrConnDone)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -146,7 +173,7 @@ func TestGetMuscleGroup(t *testing.T) {
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().GetMuscleGroup(gomock.Any(), gomock.Eq(muscleGroup.Name)).Times(0)
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(0)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -247,6 +274,80 @@ func TestListMuscleGroups(t *testing.T) {
 	}
 }
 
+func TestUpdateMuscleGroup(t *testing.T) {
+	muscleGroup := generateRandMuscleGroup()
+	patched := muscleGroup
+	patched.Name = util.RandomString(5)
+	patched.Slug = patched.Name
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": patched.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(1).Return(muscleGroup, nil)
+				args := db.UpdateGroupParams{Name: patched.Name, ID: muscleGroup.ID}
+				store.EXPECT().UpdateGroup(gomock.Any(), gomock.Eq(args)).Times(1).Return(patched, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				validateMuscleGroupResponse(t, recorder.Body, patched)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"name": patched.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(1).Return(db.MuscleGroup{}, sql.ErrNoRows)
+				store.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidBody",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/muscle_group/%s", muscleGroup.Slug)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthHeader(t, req, server.tokenCreator, bearerType, uuid.New(), time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
 func TestDeleteMuscleGroup(t *testing.T) {
 	muscleGroup := generateRandMuscleGroup()
 
@@ -264,7 +365,8 @@ func TestDeleteMuscleGroup(t *testing.T) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteGroup(gomock.Any(), gomock.Eq(muscleGroup.Name)).Times(1).Return(muscleGroup, nil)
+				store.EXPECT().GetMuscleGroupBySlug(gomock.Any(), gomock.Eq(muscleGroup.Slug)).Times(1).Return(muscleGroup, nil)
+				store.EXPECT().DeleteGroup(gomock.Any(), gomock.Eq(muscleGroup.ID)).Times(1).Return(muscleGroup, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -277,7 +379,7 @@ func TestDeleteMuscleGroup(t *testing.T) {
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteGroup(gomock.Any(), gomock.Eq(muscleGroup.Name)).Times(0)
+				store.EXPECT().DeleteGroup(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -309,9 +411,11 @@ func TestDeleteMuscleGroup(t *testing.T) {
 }
 
 func generateRandMuscleGroup() db.MuscleGroup {
+	name := util.RandomString(5)
 	return db.MuscleGroup{
-		Name: util.RandomString(5),
+		Name: name,
 		ID:   int16(util.RandomInt(1, 150)),
+		Slug: util.Slugify(name),
 	}
 }
 
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/oidc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultOIDCLoginExpiry = 10 * time.Minute
+	oidcCleanupInterval    = time.Hour
+	oidcStateBytes         = 32
+)
+
+var (
+	errUnknownProvider    = errors.New("Unknown identity provider")
+	errOIDCStateInvalid   = errors.New("Sign in attempt is invalid or has expired, start again")
+	errOIDCDenied         = errors.New("The identity provider did not sign you in")
+	errOIDCInvalidIDToken = errors.New("The identity provider returned an invalid id token")
+)
+
+// newOIDCProviders builds the identity providers users can sign in with,
+// keyed by the name used in their login urls.
+func newOIDCProviders(config util.Config) (map[string]*oidc.Provider, error) {
+	configs, err := util.ParseOIDCProviders(config.OIDCProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string]*oidc.Provider, len(configs))
+	for _, c := range configs {
+		providers[c.Name] = oidc.NewProvider(c, nil)
+	}
+	return providers, nil
+}
+
+type oidcProviderUri struct {
+	Provider string `uri:"provider" binding:"required"`
+}
+
+func (server *Server) oidcProvider(ctx *gin.Context) (*oidc.Provider, bool) {
+	var uri oidcProviderUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	provider, ok := server.oidcProviders[uri.Provider]
+	if !ok {
+		respondError(ctx, http.StatusNotFound, errUnknownProvider)
+		return nil, false
+	}
+	return provider, true
+}
+
+// oidcLogin starts signing in with a provider. The state, nonce and PKCE
+// verifier are kept until the callback, only a hash of the state is stored.
+func (server *Server) oidcLogin(ctx *gin.Context) {
+	provider, ok := server.oidcProvider(ctx)
+	if !ok {
+		return
+	}
+
+	state, err := util.NewSecret(oidcStateBytes)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	nonce, err := util.NewSecret(oidcStateBytes)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		respondError(ctx, http.StatusBadGateway, err)
+		return
+	}
+
+	duration := server.config.OIDCLoginDuration
+	if duration <= 0 {
+		duration = defaultOIDCLoginExpiry
+	}
+
+	err = server.store.CreateOIDCLogin(ctx, db.CreateOIDCLoginParams{
+		StateHash:    util.HashSecret(state),
+		Provider:     provider.Name(),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().UTC().Add(duration),
+	})
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, authURL)
+}
+
+type oidcCallbackReq struct {
+	Code  string `form:"code"`
+	State string `form:"state" binding:"required"`
+	Error string `form:"error"`
+}
+
+// oidcCallback is where the provider sends the user back to. The state is
+// consumed whatever the outcome so a callback can't be replayed.
+func (server *Server) oidcCallback(ctx *gin.Context) {
+	provider, ok := server.oidcProvider(ctx)
+	if !ok {
+		return
+	}
+
+	var req oidcCallbackReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	login, err := server.store.UseOIDCLogin(ctx, db.UseOIDCLoginParams{
+		StateHash: util.HashSecret(req.State),
+		Now:       now,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusUnauthorized, errOIDCStateInvalid)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if login.Provider != provider.Name() {
+		respondError(ctx, http.StatusUnauthorized, errOIDCStateInvalid)
+		return
+	}
+
+	if req.Error != "" || req.Code == "" {
+		respondError(ctx, http.StatusUnauthorized, errOIDCDenied)
+		return
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidIDToken) {
+			respondError(ctx, http.StatusUnauthorized, errOIDCInvalidIDToken)
+			return
+		}
+		respondError(ctx, http.StatusBadGateway, err)
+		return
+	}
+
+	// accounts created here have a password nobody knows, one can be set
+	// through the forgot password flow
+	password, err := util.HashPassword(util.RandomString(32))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	account, err := server.store.OIDCLoginTx(ctx, db.OIDCLoginTxParams{
+		Provider:      provider.Name(),
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Password:      password,
+		Now:           now,
+	})
+	if err != nil {
+		if err == db.ErrOIDCEmailUnverified {
+			respondError(ctx, http.StatusForbidden, err)
+			return
+		}
+		if err == db.ErrOIDCAccountUnverified {
+			respondError(ctx, http.StatusConflict, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.completeLogin(ctx, db.GetAccountByEmailRow{
+		ID:                account.ID,
+		Email:             account.Email,
+		Password:          account.Password,
+		PasswordChangedAt: account.PasswordChangedAt,
+		StartDate:         account.StartDate,
+	})
+}
+
+// runOIDCCleanup deletes sign in attempts that were never finished.
+func runOIDCCleanup(ctx context.Context, store db.Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := store.DeleteExpiredOIDCLogins(ctx, time.Now().UTC()); err != nil {
+				log.Printf("expired oidc login cleanup failed: %v", err)
+			}
+		}
+	}
+}
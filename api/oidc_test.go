@@ -0,0 +1,321 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/oidc/oidctest"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const oidcRedirectURL = "http://localhost:8080/user/oidc/test/callback"
+
+func newOIDCTestServer(t *testing.T, store *mockdb.MockStore, idp *oidctest.Server) *Server {
+	spec, err := json.Marshal([]util.OIDCProvider{idp.Provider("test", oidcRedirectURL)})
+	require.NoError(t, err)
+
+	config := newTestConfig(t)
+	config.OIDCProviders = string(spec)
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+	return server
+}
+
+// stubOIDCLogins keeps the sign in started at /user/oidc/:provider so the
+// callback can consume it, provider replaces the provider it was started
+// with when set.
+func stubOIDCLogins(store *mockdb.MockStore, provider string) {
+	var started db.CreateOIDCLoginParams
+	store.EXPECT().CreateOIDCLogin(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateOIDCLoginParams) error {
+			started = arg
+			return nil
+		})
+	store.EXPECT().UseOIDCLogin(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UseOIDCLoginParams) (db.OidcLogin, error) {
+			if arg.StateHash != started.StateHash || !arg.Now.Before(started.ExpiresAt) {
+				return db.OidcLogin{}, sql.ErrNoRows
+			}
+			login := db.OidcLogin{
+				StateHash:    started.StateHash,
+				Provider:     started.Provider,
+				CodeVerifier: started.CodeVerifier,
+				Nonce:        started.Nonce,
+				ExpiresAt:    started.ExpiresAt,
+			}
+			if provider != "" {
+				login.Provider = provider
+			}
+			return login, nil
+		})
+}
+
+func TestOIDCLogin(t *testing.T) {
+	account := generateRandAccount(uuid.New())
+	identity := oidctest.Identity{
+		Subject:       util.RandomString(16),
+		Email:         account.Email,
+		EmailVerified: true,
+		Name:          account.Name,
+	}
+
+	testCases := []struct {
+		name       string
+		identity   oidctest.Identity
+		claims     func(claims jwt.MapClaims)
+		callback   func(query url.Values)
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			identity: identity,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.OIDCLoginTxParams) (db.Account, error) {
+						require.Equal(t, "test", arg.Provider)
+						require.Equal(t, identity.Subject, arg.Subject)
+						require.Equal(t, identity.Email, arg.Email)
+						require.True(t, arg.EmailVerified)
+						require.Equal(t, identity.Name, arg.Name)
+						require.NotEmpty(t, arg.Password)
+						return account, nil
+					})
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.AccountMfa{}, sql.ErrNoRows)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res LoginRes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotEmpty(t, res.JWT)
+				require.Equal(t, account.ID, res.User.ID)
+				require.Equal(t, account.Email, res.User.Email)
+			},
+		},
+		{
+			name:     "MFARequired",
+			identity: identity,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				mfa := db.AccountMfa{UserID: account.ID, ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+				store.EXPECT().GetAccountMFA(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(mfa, nil)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res mfaChallengeRes
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.True(t, res.MFARequired)
+				require.NotEmpty(t, res.ChallengeToken)
+			},
+		},
+		{
+			name:     "EmailUnverified",
+			identity: oidctest.Identity{Subject: identity.Subject, Email: identity.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, db.ErrOIDCEmailUnverified)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.PermissionDenied)
+			},
+		},
+		{
+			name:     "AccountUnverified",
+			identity: identity,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, db.ErrOIDCAccountUnverified)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Conflict)
+			},
+		},
+		{
+			name:     "UnknownState",
+			identity: identity,
+			callback: func(query url.Values) {
+				query.Set("state", util.RandomString(32))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+			},
+		},
+		{
+			name:     "StartedWithOtherProvider",
+			identity: identity,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "other")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+			},
+		},
+		{
+			name:     "ProviderError",
+			identity: identity,
+			callback: func(query url.Values) {
+				query.Del("code")
+				query.Set("error", "access_denied")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+			},
+		},
+		{
+			name:     "InvalidIDToken",
+			identity: identity,
+			claims: func(claims jwt.MapClaims) {
+				claims["nonce"] = util.RandomString(32)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Unauthenticated)
+			},
+		},
+		{
+			name:     "InternalError",
+			identity: identity,
+			buildStubs: func(store *mockdb.MockStore) {
+				stubOIDCLogins(store, "")
+				store.EXPECT().OIDCLoginTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				requireErrorCode(t, recorder.Body, apierr.Internal)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			idp := oidctest.NewServer()
+			defer idp.Close()
+			idp.Claims = tc.claims
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newOIDCTestServer(t, store, idp)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/user/oidc/test", nil)
+			require.NoError(t, err)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusFound, recorder.Code)
+
+			callback, err := idp.Authorize(recorder.Header().Get("Location"), tc.identity)
+			require.NoError(t, err)
+
+			u, err := url.Parse(callback)
+			require.NoError(t, err)
+			require.Equal(t, "/user/oidc/test/callback", u.Path)
+			if tc.callback != nil {
+				query := u.Query()
+				tc.callback(query)
+				u.RawQuery = query.Encode()
+			}
+
+			recorder = httptest.NewRecorder()
+			request, err = http.NewRequest(http.MethodGet, u.RequestURI(), nil)
+			require.NoError(t, err)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkRes(t, recorder)
+		})
+	}
+}
+
+func TestOIDCLoginErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idp := oidctest.NewServer()
+	defer idp.Close()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreateOIDCLogin(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().UseOIDCLogin(gomock.Any(), gomock.Any()).Times(0)
+	server := newOIDCTestServer(t, store, idp)
+
+	for _, path := range []string{"/user/oidc/unknown", "/user/oidc/unknown/callback?state=abc&code=abc"} {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusNotFound, recorder.Code, path)
+		requireErrorCode(t, recorder.Body, apierr.NotFound)
+	}
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/user/oidc/test/callback?code=abc", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	requireErrorCode(t, recorder.Body, apierr.InvalidArgument)
+
+	// discovery fails while the provider is down
+	idp.Close()
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/user/oidc/test", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadGateway, recorder.Code)
+	requireErrorCode(t, recorder.Body, apierr.Internal)
+}
+
+func TestNewOIDCProviders(t *testing.T) {
+	providers, err := newOIDCProviders(newTestConfig(t))
+	require.NoError(t, err)
+	require.Empty(t, providers)
+
+	config := newTestConfig(t)
+	config.OIDCProviders = `[{"name":"corp","issuer":"https://id.example.com","client_id":"lift","redirect_url":"https://lift.example.com/user/oidc/corp/callback"}]`
+	providers, err = newOIDCProviders(config)
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	require.Equal(t, "corp", providers["corp"].Name())
+
+	config.OIDCProviders = "not json"
+	_, err = NewServer(config, mockdb.NewMockStore(gomock.NewController(t)))
+	require.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	errInvalidCursor  = errors.New("Invalid pagination cursor")
+	errCursorWithPage = errors.New("Cursor and page_id cannot be used together")
+)
+
+// listResp is the envelope returned by every paginated list endpoint.
+type listResp struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// encodeCursor turns the sort key of the last row on a page into an opaque
+// token the client passes back to fetch the next page.
+func encodeCursor(key interface{}) string {
+	data, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(token string, key interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return errInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(key); err != nil {
+		return errInvalidCursor
+	}
+
+	return nil
+}
+
+// pageArgs returns the limit and offset for a list query. Cursor requests
+// always start at offset zero and page_id remains as an offset fallback. One
+// row more than the page size is requested so has_more can be reported.
+func pageArgs(pageID, pageSize int32, cursor string) (int32, int32, error) {
+	if cursor != "" && pageID != 0 {
+		return 0, 0, errCursorWithPage
+	}
+
+	var offset int32
+	if pageID > 1 {
+		offset = (pageID - 1) * pageSize
+	}
+
+	return pageSize + 1, offset, nil
+}
+
+// newListResp drops the extra row requested by pageArgs and, when more rows
+// remain, builds the next cursor from the last row kept.
+func newListResp[T any](rows []T, pageSize int32, cursorKey func(T) interface{}) listResp {
+	if rows == nil {
+		rows = []T{}
+	}
+
+	resp := listResp{Data: rows}
+	if len(rows) > int(pageSize) {
+		rows = rows[:pageSize]
+		resp.Data = rows
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(cursorKey(rows[len(rows)-1]))
+	}
+
+	return resp
+}
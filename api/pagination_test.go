@@ -0,0 +1,75 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type testListResp[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+}
+
+func decodeListResp[T any](t *testing.T, body *bytes.Buffer) testListResp[T] {
+	var res testListResp[T]
+	err := json.Unmarshal(body.Bytes(), &res)
+	require.NoError(t, err)
+	return res
+}
+
+func TestPageArgs(t *testing.T) {
+	limit, offset, err := pageArgs(3, 10, "")
+	require.NoError(t, err)
+	require.Equal(t, int32(11), limit)
+	require.Equal(t, int32(20), offset)
+
+	limit, offset, err = pageArgs(0, 10, "")
+	require.NoError(t, err)
+	require.Equal(t, int32(11), limit)
+	require.Zero(t, offset)
+
+	limit, offset, err = pageArgs(0, 10, "abc")
+	require.NoError(t, err)
+	require.Equal(t, int32(11), limit)
+	require.Zero(t, offset)
+
+	_, _, err = pageArgs(1, 10, "abc")
+	require.ErrorIs(t, err, errCursorWithPage)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	key := liftCursor{ExerciseName: "Bench Press", ID: uuid.New()}
+
+	var decoded liftCursor
+	err := decodeCursor(encodeCursor(key), &decoded)
+	require.NoError(t, err)
+	require.Equal(t, key, decoded)
+
+	for _, token := range []string{"not a cursor", encodeCursor("bench"), encodeCursor(prCursor{Value: 100})} {
+		err = decodeCursor(token, &decoded)
+		require.ErrorIs(t, err, errInvalidCursor)
+	}
+}
+
+func TestNewListResp(t *testing.T) {
+	key := func(n int) interface{} { return n }
+
+	resp := newListResp([]int(nil), 2, key)
+	require.Equal(t, []int{}, resp.Data)
+	require.False(t, resp.HasMore)
+	require.Empty(t, resp.NextCursor)
+
+	resp = newListResp([]int{1, 2}, 2, key)
+	require.Equal(t, []int{1, 2}, resp.Data)
+	require.False(t, resp.HasMore)
+
+	resp = newListResp([]int{1, 2, 3}, 2, key)
+	require.Equal(t, []int{1, 2}, resp.Data)
+	require.True(t, resp.HasMore)
+	require.Equal(t, encodeCursor(2), resp.NextCursor)
+}
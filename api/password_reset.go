@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/mail"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	resetTokenBytes      = 32
+	defaultResetDuration = 30 * time.Minute
+)
+
+var errTooManyResets = errors.New("Too many password reset requests, try again later")
+
+type forgotPasswordReq struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// forgotPassword answers the same way, and just as quickly, whether or not
+// the email belongs to an account so it cannot be used to discover who is
+// registered. The reset is issued and mailed after the response is sent.
+func (server *Server) forgotPassword(ctx *gin.Context) {
+	var req forgotPasswordReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !server.resetAllowed(ctx, req.Email) {
+		return
+	}
+
+	accepted := gin.H{"message": "If an account exists for this email a reset link has been sent"}
+
+	account, err := server.store.GetAccountByEmail(ctx, req.Email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusAccepted, accepted)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.background.Add(1)
+	go func() {
+		defer server.background.Done()
+		if err := server.sendPasswordReset(context.Background(), account.ID, account.Email); err != nil {
+			log.Printf("password reset for %s failed: %v", account.ID, err)
+		}
+	}()
+
+	ctx.JSON(http.StatusAccepted, accepted)
+}
+
+// resetAllowed counts every reset request against the email and the client
+// ip, whether or not the email is registered, and refuses the ones that go
+// over the limit so the endpoint can't be used to flood an inbox.
+func (server *Server) resetAllowed(ctx *gin.Context, email string) bool {
+	emailWait, err := server.loginLimiter.Fail(ctx, "reset:"+strings.ToLower(email))
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	ipWait, err := server.ipLimiter.Fail(ctx, "reset-ip:"+ctx.ClientIP())
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return false
+	}
+
+	wait := emailWait
+	if ipWait > wait {
+		wait = ipWait
+	}
+
+	if wait > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		respondError(ctx, http.StatusTooManyRequests, errTooManyResets)
+		return false
+	}
+	return true
+}
+
+func (server *Server) sendPasswordReset(ctx context.Context, userID uuid.UUID, email string) error {
+	resetToken, err := util.NewSecret(resetTokenBytes)
+	if err != nil {
+		return err
+	}
+
+	duration := server.config.PasswordResetDuration
+	if duration <= 0 {
+		duration = defaultResetDuration
+	}
+
+	_, err = server.store.CreatePasswordReset(ctx, db.CreatePasswordResetParams{
+		TokenHash: util.HashSecret(resetToken),
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC().Add(duration),
+	})
+	if err != nil {
+		return err
+	}
+
+	return server.mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Reset your password",
+		Body:    resetEmailBody(server.config.PasswordResetURL, resetToken, duration),
+	})
+}
+
+func resetEmailBody(resetURL, resetToken string, duration time.Duration) string {
+	return fmt.Sprintf("Someone asked to reset the password for your account.\n\n"+
+		"Use the link below within %s to choose a new one:\n\n%s\n\n"+
+		"If this wasn't you, you can ignore this email.\n", duration, tokenLink(resetURL, resetToken))
+}
+
+// tokenLink points the emailed token at the client page that consumes it.
+// Without a configured page the raw token is sent instead.
+func tokenLink(pageURL, token string) string {
+	if pageURL == "" {
+		return token
+	}
+	return fmt.Sprintf("%s?token=%s", pageURL, token)
+}
+
+type resetPasswordReq struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+func (server *Server) resetPassword(ctx *gin.Context) {
+	var req resetPasswordReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash: util.HashSecret(req.Token),
+		Password:  hashedPassword,
+		ResetAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		if err == db.ErrResetTokenInvalid {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/mail"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var resetTokenPattern = regexp.MustCompile(`[A-Za-z0-9_-]{43}`)
+
+func TestForgotPassword(t *testing.T) {
+	account := generateRandAccount(uuid.New())
+	var tokenHash string
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		checkRes   func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"email": account.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Eq(account.Email)).Times(1).
+					Return(db.GetAccountByEmailRow{ID: account.ID, Email: account.Email}, nil)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+						require.Equal(t, account.ID, arg.UserID)
+						require.WithinDuration(t, time.Now().Add(defaultResetDuration), arg.ExpiresAt, time.Second)
+						tokenHash = arg.TokenHash
+						return db.PasswordReset{TokenHash: arg.TokenHash, UserID: arg.UserID, ExpiresAt: arg.ExpiresAt}, nil
+					})
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				sent := mailer.Sent()
+				require.Len(t, sent, 1)
+				require.Equal(t, account.Email, sent[0].To)
+
+				resetToken := resetTokenPattern.FindString(sent[0].Body)
+				require.NotEmpty(t, resetToken)
+				require.Equal(t, tokenHash, util.HashSecret(resetToken))
+			},
+		},
+		{
+			name: "UnknownEmail",
+			body: gin.H{"email": "nobody@example.com"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.GetAccountByEmailRow{}, sql.ErrNoRows)
+				store.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"email": "not-an-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"email": account.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.GetAccountByEmailRow{}, sql.ErrConnDone)
+			},
+			checkRes: func(t *testing.T, recorder *httptest.ResponseRecorder, mailer *mail.MemoryMailer) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Empty(t, mailer.Sent())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/password/forgot", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			server.background.Wait()
+			tc.checkRes(t, recorder, server.mailer.(*mail.MemoryMailer))
+		})
+	}
+}
+
+func TestForgotPasswordLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccountByEmail(gomock.Any(), gomock.Any()).AnyTimes().Return(db.GetAccountByEmailRow{}, sql.ErrNoRows)
+
+	server := newTestServer(t, store)
+	data, err := json.Marshal(gin.H{"email": "nobody@example.com"})
+	require.NoError(t, err)
+
+	forgot := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/user/password/forgot", bytes.NewReader(data))
+		require.NoError(t, err)
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// unknown emails are limited the same as registered ones
+	for i := 1; i < 5; i++ {
+		require.Equal(t, http.StatusAccepted, forgot().Code)
+	}
+
+	recorder := forgot()
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+	requireErrorCode(t, recorder.Body, apierr.ResourceExhausted)
+}
+
+func TestResetPassword(t *testing.T) {
+	resetToken, err := util.NewSecret(resetTokenBytes)
+	require.NoError(t, err)
+	newPassword := util.RandomString(10)
+
+	testCases := []struct {
+		name       string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name: "OK",
+			body: gin.H{"token": resetToken, "new_password": newPassword},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ResetPasswordTxParams) (uuid.UUID, error) {
+						require.Equal(t, util.HashSecret(resetToken), arg.TokenHash)
+						require.NoError(t, util.ValidatePassword(newPassword, arg.Password))
+						return uuid.New(), nil
+					})
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name: "InvalidToken",
+			body: gin.H{"token": resetToken, "new_password": newPassword},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).Return(uuid.Nil, db.ErrResetTokenInvalid)
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "ShortPassword",
+			body: gin.H{"token": resetToken, "new_password": "abc"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"token": resetToken, "new_password": newPassword},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResetPasswordTx(gomock.Any(), gomock.Any()).Times(1).Return(uuid.Nil, sql.ErrConnDone)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/password/reset", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
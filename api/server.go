@@ -1,31 +1,89 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api/apierr"
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/limiter"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/mail"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/oidc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/revocation"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultRevocationCacheTTL = 30 * time.Second
+	revocationCleanupInterval = time.Hour
 )
 
 type Server struct {
-	config       util.Config
-	store        db.Store
-	tokenCreator token.Maker
-	router       *gin.Engine
+	config        util.Config
+	store         db.Store
+	tokenCreator  token.Maker
+	mailer        mail.Mailer
+	loginLimiter  limiter.Limiter
+	ipLimiter     limiter.Limiter
+	revocations   revocation.Store
+	oidcProviders map[string]*oidc.Provider
+	router        *gin.Engine
+	// background tracks work that outlives its request, such as mail
+	background sync.WaitGroup
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenCreator, err := token.NewJWTCreator(config.SecretKey)
+	tokenCreator, err := newTokenMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create token generator: %w", err)
 	}
 
+	mailer, err := newMailer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	loginLimiter, ipLimiter, err := newLoginLimiters(config, store)
+	if err != nil {
+		return nil, err
+	}
+
+	revocations, err := newRevocationStore(config, store)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcProviders, err := newOIDCProviders(config)
+	if err != nil {
+		return nil, err
+	}
+
 	server := &Server{
-		config:       config,
-		store:        store,
-		tokenCreator: tokenCreator,
+		config:        config,
+		store:         store,
+		tokenCreator:  tokenCreator,
+		mailer:        mailer,
+		loginLimiter:  loginLimiter,
+		ipLimiter:     ipLimiter,
+		revocations:   revocations,
+		oidcProviders: oidcProviders,
+	}
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+		v.RegisterValidation("slugname", validSlugName)
 	}
 
 	server.buildRoutes()
@@ -37,44 +95,95 @@ func (server *Server) buildRoutes() {
 
 	router.POST("/accounts", server.createAccount)
 	router.POST("/user/login", server.login)
+	router.POST("/user/login/mfa", server.loginMFA)
+	router.POST("/user/password/forgot", server.forgotPassword)
+	router.POST("/user/password/reset", server.resetPassword)
+	router.POST("/user/email/verify", server.verifyEmail)
+	router.GET("/user/oidc/:provider", server.oidcLogin)
+	router.GET("/user/oidc/:provider/callback", server.oidcCallback)
+	router.GET("/.well-known/jwks.json", server.jwks)
 
-	authRouter := router.Group("/").Use(authenticationMiddleware(server.tokenCreator))
+	authRouter := router.Group("/").Use(authenticationMiddleware(server.tokenCreator, server.store, server.revocations))
+
+	authRouter.POST("/user/logout", requireSession, server.logout)
+	authRouter.POST("/user/logout/all", requireSession, server.logoutAll)
 
 	authRouter.GET("/accounts/:id", server.getAccount)
+	authRouter.PATCH("/accounts/:id", server.updateAccount)
 	authRouter.GET("/accounts", server.listAccounts)
-	authRouter.DELETE("/accounts/:id", server.deleteAccount)
+	authRouter.PATCH("/accounts/:id/password", requireSession, server.changePassword)
+	authRouter.DELETE("/accounts/:id", requireSession, server.deleteAccount)
+	authRouter.GET("/accounts/:id/export", server.exportAccount)
+	authRouter.POST("/accounts/:id/verification", server.resendVerification)
+
+	// routes above stay open to unverified accounts so they can request a new
+	// email, or correct or delete an account created with the wrong address
+	if server.config.RequireVerifiedEmail {
+		authRouter.Use(verifiedEmailMiddleware(server.store))
+	}
+
+	authRouter.POST("/accounts/:id/mfa", requireSession, server.enrollMFA)
+	authRouter.POST("/accounts/:id/mfa/confirm", requireSession, server.confirmMFA)
+	authRouter.POST("/accounts/:id/api_keys", requireSession, server.createAPIKey)
+	authRouter.GET("/accounts/:id/api_keys", requireSession, server.listAPIKeys)
+	authRouter.DELETE("/accounts/:id/api_keys/:key_id", requireSession, server.revokeAPIKey)
 
 	authRouter.POST("/category", server.createCategory)
-	authRouter.GET("/category/:id", server.getCategory)
+	authRouter.GET("/category/:ref", server.getCategory)
 	authRouter.GET("/category", server.listCategories)
-	authRouter.PATCH("/category/:id", server.updateCategory)
-	authRouter.DELETE("/category/:id", server.deleteCategory)
+	authRouter.PATCH("/category/:ref", server.updateCategory)
+	authRouter.DELETE("/category/:ref", server.deleteCategory)
 
 	authRouter.POST("/muscle_group", server.createMuscleGroup)
-	authRouter.GET("/muscle_group/:name", server.getMuscleGroup)
+	authRouter.GET("/muscle_group/:ref", server.getMuscleGroup)
 	authRouter.GET("muscle_group", server.listMuscleGroups)
-	authRouter.PATCH("muscle_group", server.updateMuscleGroup)
-	authRouter.DELETE("/muscle_group/:name", server.deleteMuscleGroup)
+	authRouter.PATCH("/muscle_group/:ref", server.updateMuscleGroup)
+	authRouter.DELETE("/muscle_group/:ref", server.deleteMuscleGroup)
 
 	authRouter.POST("/exercise", server.createExercise)
-	authRouter.GET("/exercise/:name", server.getExercise)
+	authRouter.POST("/exercise/merge", server.mergeExercises)
+	authRouter.GET("/exercise/:ref", server.getExercise)
+	authRouter.GET("/exercise/search", server.searchExercises)
 	authRouter.GET("/exercise", server.listExercises)
-	authRouter.GET("/exercise/group/:muscle_group", server.getMuscleGroupExercises)
-	authRouter.PATCH("/exercise/:name", server.updateExercise)
-	authRouter.DELETE("/exercise/:name", server.deleteExercise)
+	authRouter.GET("/exercise/group/:ref", server.getMuscleGroupExercises)
+	authRouter.PATCH("/exercise/:ref", server.updateExercise)
+	authRouter.PATCH("/exercise/:ref/archive", server.archiveExercise)
+	authRouter.DELETE("/exercise/:ref", server.deleteExercise)
+	authRouter.POST("/exercise/:ref/alias", server.createExerciseAlias)
+	authRouter.GET("/exercise/:ref/alias", server.listExerciseAliases)
+	authRouter.DELETE("/exercise/:ref/alias/:alias", server.deleteExerciseAlias)
+	authRouter.GET("/exercise/:ref/muscle_groups", server.listExerciseMuscleGroups)
+	authRouter.PUT("/exercise/:ref/muscle_groups", server.setExerciseMuscleGroups)
+	authRouter.GET("/exercise/:ref/substitutes", server.listExerciseSubstitutes)
 
 	authRouter.POST("/workout/:user_id", server.createWorkout)
+	authRouter.POST("/workout/:user_id/repeat", server.repeatWorkout)
+	authRouter.POST("/workout/swap/:workout_id", server.swapWorkoutExercise)
 	authRouter.GET("/workout/:workout_id", server.getWorkout)
 	authRouter.GET("/workout/history/:user_id", server.listWorkouts)
-	authRouter.PATCH("/workout/:workout_id", server.updateFinishTime)
+	authRouter.GET("/workout/search/:user_id", server.searchWorkouts)
+	authRouter.PATCH("/workout/:workout_id", server.updateWorkout)
 	authRouter.DELETE("/workout/:workout_id", server.deleteWorkout)
 
+	authRouter.GET("/equipment/:user_id", server.getEquipment)
+	authRouter.PUT("/equipment/:user_id", server.updateEquipment)
+	authRouter.GET("/equipment/plates/:user_id", server.calculatePlates)
+	authRouter.GET("/equipment/warmup/:user_id", server.generateWarmup)
+
+	authRouter.POST("/gym_profile/:user_id", server.createGymProfile)
+	authRouter.GET("/gym_profile/:user_id", server.listGymProfiles)
+	authRouter.PUT("/gym_profile/:user_id/:id", server.updateGymProfile)
+	authRouter.DELETE("/gym_profile/:user_id/:id", server.deleteGymProfile)
+
+	authRouter.POST("/import/:user_id", server.importWorkouts)
+
 	authRouter.POST("/lift", server.createLift)
 	authRouter.POST("/lift/:workout_id/:user_id", server.createLifts)
 	authRouter.GET("/lift/:id/:user_id", server.getLift)
 	authRouter.GET("/lift/history/:user_id", server.listLifts)
 	authRouter.GET("/lift/history/pr/:order_by/:user_id", server.listPRs)
-	authRouter.GET("/lift/pr/:exercise_name/:order_by/:user_id", server.listPRsByExercise)
+	authRouter.GET("/lift/volume/:user_id", server.listMuscleGroupVolume)
+	authRouter.GET("/lift/pr/:exercise/:order_by/:user_id", server.listPRsByExercise)
 	authRouter.GET("/lift/pr/group/:muscle_group/:order_by/:user_id", server.listPRsByMuscleGroup)
 	authRouter.PATCH("/lift/:id", server.updateLift)
 	authRouter.DELETE("/lift/:id", server.deleteLift)
@@ -82,10 +191,145 @@ func (server *Server) buildRoutes() {
 	server.router = router
 }
 
+// newTokenMaker picks the access token format. PASETO makers take their
+// keys from PasetoKeys, the first key mints new tokens. jwt_asymmetric
+// signs with the PEM keys listed in JWTSigningKeys and publishes them at
+// /.well-known/jwks.json.
+func newTokenMaker(config util.Config) (token.Maker, error) {
+	switch config.TokenType {
+	case "", "jwt":
+		return token.NewJWTCreator(config.SecretKey)
+	case "paseto_local", "paseto_public":
+		keys, err := token.ParseKeys(config.PasetoKeys)
+		if err != nil {
+			return nil, err
+		}
+		if config.TokenType == "paseto_local" {
+			return token.NewPasetoLocalMaker(keys)
+		}
+		return token.NewPasetoPublicMaker(keys)
+	case "jwt_asymmetric":
+		keys, err := token.ParseSigningKeys(config.JWTSigningKeys)
+		if err != nil {
+			return nil, err
+		}
+		return token.NewAsymmetricJWTMaker(keys)
+	}
+	return nil, fmt.Errorf("Unknown token type: %s", config.TokenType)
+}
+
+// newMailer picks the mail transport from config. Without a driver mail is
+// only kept in memory, which is what the tests rely on.
+func newMailer(config util.Config) (mail.Mailer, error) {
+	switch config.MailDriver {
+	case "smtp":
+		return mail.NewSMTPMailer(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword, config.MailFrom), nil
+	case "file":
+		return mail.NewFileMailer(config.MailDir, config.MailFrom)
+	case "", "memory":
+		return mail.NewMemoryMailer(), nil
+	}
+	return nil, fmt.Errorf("Unknown mail driver: %s", config.MailDriver)
+}
+
+// newLoginLimiters returns the limiter for failed logins against one account
+// and a looser one for failures coming from one client ip.
+func newLoginLimiters(config util.Config, store db.Store) (limiter.Limiter, limiter.Limiter, error) {
+	account := limiter.Policy{
+		MaxAttempts: config.LoginMaxAttempts,
+		BaseDelay:   config.LoginLockout,
+		MaxDelay:    config.LoginMaxLockout,
+	}
+	if account.MaxAttempts <= 0 {
+		account.MaxAttempts = 5
+	}
+	if account.BaseDelay <= 0 {
+		account.BaseDelay = time.Minute
+	}
+	if account.MaxDelay <= 0 {
+		account.MaxDelay = time.Hour
+	}
+
+	ip := account
+	ip.MaxAttempts = config.LoginIPMaxAttempts
+	if ip.MaxAttempts <= 0 {
+		ip.MaxAttempts = 10 * account.MaxAttempts
+	}
+
+	switch config.LoginLimiter {
+	case "postgres":
+		return limiter.NewPostgresLimiter(store, account), limiter.NewPostgresLimiter(store, ip), nil
+	case "", "memory":
+		return limiter.NewMemoryLimiter(account), limiter.NewMemoryLimiter(ip), nil
+	}
+	return nil, nil, fmt.Errorf("Unknown login limiter: %s", config.LoginLimiter)
+}
+
+// newRevocationStore returns where logged out tokens are kept. The postgres
+// store is shared by every instance, so lookups go through a cache.
+func newRevocationStore(config util.Config, store db.Store) (revocation.Store, error) {
+	switch config.RevocationStore {
+	case "postgres":
+		ttl := config.RevocationCacheTTL
+		if ttl <= 0 {
+			ttl = defaultRevocationCacheTTL
+		}
+		return revocation.NewCache(revocation.NewPostgresStore(store), ttl), nil
+	case "", "memory":
+		return revocation.NewMemoryStore(), nil
+	}
+	return nil, fmt.Errorf("Unknown revocation store: %s", config.RevocationStore)
+}
+
 func (server *Server) Start(address string) error {
+	go revocation.RunCleanup(context.Background(), server.revocations, revocationCleanupInterval)
+	if len(server.oidcProviders) > 0 {
+		go runOIDCCleanup(context.Background(), server.store, oidcCleanupInterval)
+	}
 	return server.router.Run(address)
 }
 
-func errorResponse(err error) gin.H {
-	return gin.H{"error": err.Error()}
+// respondError aborts the request with the uniform error envelope. status is
+// used when err is not one apierr knows how to classify.
+func respondError(ctx *gin.Context, status int, err error) {
+	apiErr := apierr.From(status, err)
+	if apiErr.Status >= http.StatusInternalServerError {
+		ctx.Error(err)
+	}
+	ctx.AbortWithStatusJSON(apiErr.Status, apierr.Response{Error: apiErr})
+}
+
+// requestFieldName reports validation failures under the name the client
+// sent rather than the Go struct field.
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+var errStillReferenced = errors.New("Resource is still referenced by recorded lifts or exercises and cannot be deleted")
+
+func isForeignKeyViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "foreign_key_violation"
+}
+
+// validSlugName checks that a catalog name slugifies to a slug the database
+// accepts.
+func validSlugName(fl validator.FieldLevel) bool {
+	return util.ValidSlug(util.Slugify(fl.Field().String()))
+}
+
+// parseRef splits a catalog route reference into a numeric id or, when the
+// reference is not a number, the slug it should be looked up by. Slugs are
+// never all digits so the two can't be confused.
+func parseRef(ref string) (int64, string) {
+	if id, err := strconv.ParseInt(ref, 10, 32); err == nil {
+		return id, ""
+	}
+	return 0, util.Slugify(ref)
 }
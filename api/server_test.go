@@ -0,0 +1,70 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/revocation"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTokenMaker(t *testing.T) {
+	testCases := []struct {
+		tokenType string
+		maker     token.Maker
+	}{
+		{"", &token.JWTCreator{}},
+		{"jwt", &token.JWTCreator{}},
+		{"paseto_local", &token.PasetoLocalMaker{}},
+		{"paseto_public", &token.PasetoPublicMaker{}},
+		{"jwt_asymmetric", &token.AsymmetricJWTMaker{}},
+	}
+
+	for _, tc := range testCases {
+		config := newTestConfig(t)
+		config.TokenType = tc.tokenType
+
+		maker, err := newTokenMaker(config)
+		require.NoError(t, err)
+		require.IsType(t, tc.maker, maker)
+
+		accessToken, err := maker.CreateToken(uuid.New(), time.Minute)
+		require.NoError(t, err)
+		_, err = maker.VerifyToken(accessToken)
+		require.NoError(t, err)
+	}
+
+	config := newTestConfig(t)
+	config.TokenType = "paseto_local"
+	config.PasetoKeys = ""
+	_, err := newTokenMaker(config)
+	require.Error(t, err)
+
+	config.TokenType = "jwt_asymmetric"
+	config.JWTSigningKeys = "test:/does/not/exist.pem"
+	_, err = newTokenMaker(config)
+	require.Error(t, err)
+
+	config.TokenType = "opaque"
+	_, err = newTokenMaker(config)
+	require.Error(t, err)
+}
+
+func TestNewRevocationStore(t *testing.T) {
+	config := newTestConfig(t)
+
+	store, err := newRevocationStore(config, nil)
+	require.NoError(t, err)
+	require.IsType(t, &revocation.MemoryStore{}, store)
+
+	config.RevocationStore = "postgres"
+	store, err = newRevocationStore(config, nil)
+	require.NoError(t, err)
+	require.IsType(t, &revocation.Cache{}, store)
+
+	config.RevocationStore = "redis"
+	_, err = newRevocationStore(config, nil)
+	require.Error(t, err)
+}
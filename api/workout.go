@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"time"
 
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -23,18 +25,18 @@ func (server *Server) createWorkout(ctx *gin.Context) {
 	var uri getUserIdReq
 	var req createWorkoutReq
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(uri.UserId)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
@@ -45,7 +47,7 @@ func (server *Server) createWorkout(ctx *gin.Context) {
 		StartTime: startTime,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -59,23 +61,23 @@ type getWorkoutReq struct {
 func (server *Server) getWorkout(ctx *gin.Context) {
 	var req getWorkoutReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	workoutId, err := uuid.Parse(req.WorkoutId)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	lifts, err := server.store.GetWorkout(context.Background(), workoutId)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -86,42 +88,58 @@ type updateWorkoutReq struct {
 	WorkoutId string `uri:"workout_id" binding:"required"`
 }
 
-type updateDurationReq struct {
-	FinishTime int64 `json:"finish_time" binding:"required"`
+type updateWorkoutBody struct {
+	FinishTime *int64  `json:"finish_time"`
+	Notes      *string `json:"notes"`
+	Rating     *int16  `json:"rating" binding:"omitempty,min=1,max=5"`
+	Energy     *int16  `json:"energy" binding:"omitempty,min=1,max=5"`
 }
 
-func (server *Server) updateFinishTime(ctx *gin.Context) {
+func (server *Server) updateWorkout(ctx *gin.Context) {
 	var uri updateWorkoutReq
-	var req updateDurationReq
+	var req updateWorkoutBody
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	workoutId, err := uuid.Parse(uri.WorkoutId)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	endTime := util.FormatMSEpoch(req.FinishTime)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
+	args := db.UpdateWorkoutParams{
+		ID: workoutId,
+	}
+
+	if req.FinishTime != nil {
+		args.FinishTime = sql.NullTime{Time: util.FormatMSEpoch(*req.FinishTime), Valid: true}
+	}
+
+	if req.Notes != nil {
+		args.Notes = sql.NullString{String: *req.Notes, Valid: true}
+	}
+
+	if req.Rating != nil {
+		args.Rating = sql.NullInt16{Int16: *req.Rating, Valid: true}
 	}
 
-	args := db.UpdateFinishTimeParams{
-		ID:         workoutId,
-		FinishTime: endTime,
+	if req.Energy != nil {
+		args.Energy = sql.NullInt16{Int16: *req.Energy, Valid: true}
 	}
 
-	workout, err := server.store.UpdateFinishTime(context.Background(), args)
+	workout, err := server.store.UpdateWorkout(context.Background(), args)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -129,66 +147,257 @@ func (server *Server) updateFinishTime(ctx *gin.Context) {
 }
 
 type getWorkoutPagination struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+	Cursor   string `form:"cursor"`
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+type workoutCursor struct {
+	StartTime time.Time `json:"start_time"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func workoutCursorKey(workout db.Workout) interface{} {
+	return workoutCursor{StartTime: workout.StartTime, ID: workout.ID}
 }
 
 func (server *Server) listWorkouts(ctx *gin.Context) {
 	var uri getUserIdReq
 	var req getWorkoutPagination
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	userId, err := uuid.Parse(uri.UserId)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	limit, offset, err := pageArgs(req.PageID, req.PageSize, req.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	args := db.ListWorkoutsParams{
 		UserID: userId,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if req.Cursor != "" {
+		var after workoutCursor
+		if err := decodeCursor(req.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		args.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
+		args.AfterStartTime = after.StartTime
 	}
 
 	workouts, err := server.store.ListWorkouts(context.Background(), args)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			respondError(ctx, http.StatusNotFound, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListResp(workouts, req.PageSize, workoutCursorKey))
+}
+
+type searchWorkoutsReq struct {
+	Query    string `form:"query" binding:"required"`
+	Cursor   string `form:"cursor"`
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+func (server *Server) searchWorkouts(ctx *gin.Context) {
+	var uri getUserIdReq
+	var req searchWorkoutsReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userId, err := uuid.Parse(uri.UserId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if !authorizeUser(ctx, userId) {
+		return
+	}
+
+	limit, offset, err := pageArgs(req.PageID, req.PageSize, req.Cursor)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	args := db.SearchWorkoutsParams{
+		UserID: userId,
+		Query:  req.Query,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if req.Cursor != "" {
+		var after workoutCursor
+		if err := decodeCursor(req.Cursor, &after); err != nil {
+			respondError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		args.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
+		args.AfterStartTime = after.StartTime
+	}
+
+	workouts, err := server.store.SearchWorkouts(ctx, args)
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newListResp(workouts, req.PageSize, workoutCursorKey))
+}
+
+const defaultProgressionIncrement = 5
+
+// repeatWorkoutUri binds the workout id from a wildcard named user_id since
+// gin requires it to match POST /workout/:user_id.
+type repeatWorkoutUri struct {
+	WorkoutId string `uri:"user_id" binding:"required"`
+}
+
+type repeatWorkoutReq struct {
+	StartTime   int64   `json:"start_time" binding:"required"`
+	Progression bool    `json:"progression"`
+	Increment   float32 `json:"increment" binding:"omitempty,gt=0"`
+	Warmup      bool    `json:"warmup"`
+}
+
+type repeatWorkoutResp struct {
+	db.RepeatWorkoutTxResult
+	Warmups map[int32][]util.WarmupSet `json:"warmups,omitempty"`
+}
+
+func (server *Server) repeatWorkout(ctx *gin.Context) {
+	var uri repeatWorkoutUri
+	var req repeatWorkoutReq
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	workoutId, err := uuid.Parse(uri.WorkoutId)
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var increment float32
+	if req.Progression {
+		increment = req.Increment
+		if increment == 0 {
+			increment = defaultProgressionIncrement
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	res, err := server.store.RepeatWorkoutTx(ctx, db.RepeatWorkoutTxParams{
+		WorkoutID: workoutId,
+		UserID:    authPayload.UserID,
+		StartTime: util.FormatMSEpoch(req.StartTime),
+		Increment: increment,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(ctx, http.StatusNotFound, err)
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if err == db.ErrWorkoutNotOwned {
+			respondError(ctx, http.StatusForbidden, err)
+			return
+		}
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, workouts)
+	resp := repeatWorkoutResp{RepeatWorkoutTxResult: res}
+	if req.Warmup {
+		resp.Warmups, err = server.buildWarmups(ctx, authPayload.UserID, res.Lifts)
+		if err != nil {
+			respondError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// buildWarmups ramps up to the heaviest working set of each exercise, keyed
+// by exercise id.
+func (server *Server) buildWarmups(ctx *gin.Context, userID uuid.UUID, lifts []db.Lift) (map[int32][]util.WarmupSet, error) {
+	barWeight, inventory, err := server.loadEquipment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	working := make(map[int32]float32)
+	for _, lift := range lifts {
+		if lift.WeightLifted > working[lift.ExerciseID] {
+			working[lift.ExerciseID] = lift.WeightLifted
+		}
+	}
+
+	warmups := make(map[int32][]util.WarmupSet, len(working))
+	for exercise, weight := range working {
+		warmups[exercise] = util.WarmupSets(weight, barWeight, inventory)
+	}
+
+	return warmups, nil
 }
 
 func (server *Server) deleteWorkout(ctx *gin.Context) {
 	var req getWorkoutReq
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	id, err := uuid.Parse(req.WorkoutId)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
 	err = server.store.DeleteWorkout(ctx, id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	ctx.JSON(http.StatusNoContent, nil)
-}
\ No newline at end of file
+}
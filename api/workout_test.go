@@ -211,8 +211,11 @@ func TestListWorkouts(t *testing.T) {
 	type Query struct {
 		PageID   int
 		PageSize int
+		Cursor   string
 	}
 
+	after := workoutCursor{StartTime: time.Date(2023, 1, 2, 15, 0, 0, 0, time.UTC), ID: uuid.New()}
+
 	testCases := []struct {
 		name          string
 		query         Query
@@ -231,16 +234,83 @@ func TestListWorkouts(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListWorkoutsParams{
-					Limit:  int32(n),
+					Limit:  int32(n) + 1,
 					Offset: 0,
 					UserID: workouts[0].UserID,
 				}
 				store.EXPECT().ListWorkouts(gomock.Any(), gomock.Eq(args)).Times(1).Return(workouts, nil)
 			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.Workout](t, recorder.Body)
+				require.Len(t, res.Data, n)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name: "HasMore",
+			query: Query{
+				PageID:   1,
+				PageSize: n,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWorkouts(gomock.Any(), gomock.Any()).Times(1).Return(append(workouts, generateRandWorkout()), nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.Workout](t, recorder.Body)
+				require.Len(t, res.Data, n)
+				require.True(t, res.HasMore)
+
+				var next workoutCursor
+				require.NoError(t, decodeCursor(res.NextCursor, &next))
+				require.Equal(t, workouts[n-1].ID, next.ID)
+				require.True(t, workouts[n-1].StartTime.Equal(next.StartTime))
+			},
+		},
+		{
+			name: "Cursor",
+			query: Query{
+				PageSize: n,
+				Cursor:   encodeCursor(after),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.ListWorkoutsParams{
+					UserID:         workouts[0].UserID,
+					AfterID:        uuid.NullUUID{UUID: after.ID, Valid: true},
+					AfterStartTime: after.StartTime,
+					Limit:          int32(n) + 1,
+				}
+				store.EXPECT().ListWorkouts(gomock.Any(), gomock.Eq(args)).Times(1).Return([]db.Workout{}, nil)
+			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "InvalidCursor",
+			query: Query{
+				PageSize: n,
+				Cursor:   encodeCursor(exerciseCursor{Name: "Squat"}),
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWorkouts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "Unauthorized",
 			query: Query{
@@ -251,7 +321,7 @@ func TestListWorkouts(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				args := db.ListWorkoutsParams{
-					Limit:  int32(n),
+					Limit:  int32(n) + 1,
 					Offset: 0,
 					UserID: workouts[0].UserID,
 				}
@@ -280,7 +350,12 @@ func TestListWorkouts(t *testing.T) {
 			require.NoError(t, err)
 
 			qParams := req.URL.Query()
-			qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			if tc.query.PageID != 0 {
+				qParams.Add("page_id", fmt.Sprintf("%d", tc.query.PageID))
+			}
+			if tc.query.Cursor != "" {
+				qParams.Add("cursor", tc.query.Cursor)
+			}
 			qParams.Add("page_size", fmt.Sprintf("%d", tc.query.PageSize))
 			req.URL.RawQuery = qParams.Encode()
 
@@ -291,8 +366,11 @@ func TestListWorkouts(t *testing.T) {
 	}
 }
 
-func TestUpdateDurationEnd(t *testing.T) {
+func TestUpdateWorkout(t *testing.T) {
 	workout := generateRandWorkout()
+	workout.Notes = util.RandomString(20)
+	workout.Rating = 4
+	workout.Energy = 3
 
 	testCases := []struct {
 		name          string
@@ -305,21 +383,76 @@ func TestUpdateDurationEnd(t *testing.T) {
 			name: "OK",
 			body: gin.H{
 				"finish_time": workout.FinishTime.UnixMilli(),
+				"notes":       workout.Notes,
+				"rating":      workout.Rating,
+				"energy":      workout.Energy,
 			},
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				args := db.UpdateFinishTimeParams{
-					FinishTime: util.FormatMSEpoch(time.Now().UnixMilli()),
+				args := db.UpdateWorkoutParams{
 					ID:         workout.ID,
+					FinishTime: sql.NullTime{Time: util.FormatMSEpoch(workout.FinishTime.UnixMilli()), Valid: true},
+					Notes:      sql.NullString{String: workout.Notes, Valid: true},
+					Rating:     sql.NullInt16{Int16: workout.Rating, Valid: true},
+					Energy:     sql.NullInt16{Int16: workout.Energy, Valid: true},
 				}
-				store.EXPECT().UpdateFinishTime(gomock.Any(), gomock.Eq(args)).Times(1).Return(workout, nil)
+				store.EXPECT().UpdateWorkout(gomock.Any(), gomock.Eq(args)).Times(1).Return(workout, nil)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "PartialUpdate",
+			body: gin.H{
+				"notes": workout.Notes,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.UpdateWorkoutParams{
+					ID:    workout.ID,
+					Notes: sql.NullString{String: workout.Notes, Valid: true},
+				}
+				store.EXPECT().UpdateWorkout(gomock.Any(), gomock.Eq(args)).Times(1).Return(workout, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRating",
+			body: gin.H{
+				"rating": 6,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWorkout(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"notes": workout.Notes,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWorkout(gomock.Any(), gomock.Any()).Times(1).Return(db.Workout{}, sql.ErrNoRows)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 		{
 			name: "Unauthorized",
 			body: gin.H{
@@ -328,11 +461,7 @@ func TestUpdateDurationEnd(t *testing.T) {
 			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				args := db.UpdateFinishTimeParams{
-					FinishTime: util.FormatMSEpoch(time.Now().UnixMilli()),
-					ID:         workout.ID,
-				}
-				store.EXPECT().UpdateFinishTime(gomock.Any(), gomock.Eq(args)).Times(0)
+				store.EXPECT().UpdateWorkout(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkRes: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -366,6 +495,322 @@ func TestUpdateDurationEnd(t *testing.T) {
 	}
 }
 
+func TestSearchWorkouts(t *testing.T) {
+	userID := uuid.New()
+	n := 5
+	workouts := make([]db.Workout, n)
+	for i := 0; i < n; i++ {
+		workouts[i] = generateRandWorkout()
+		workouts[i].UserID = userID
+		workouts[i].Notes = "left shoulder tight"
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "shoulder",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.SearchWorkoutsParams{
+					UserID: userID,
+					Query:  "shoulder",
+					Limit:  int32(n) + 1,
+					Offset: 0,
+				}
+				store.EXPECT().SearchWorkouts(gomock.Any(), gomock.Eq(args)).Times(1).Return(workouts, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				res := decodeListResp[db.Workout](t, recorder.Body)
+				require.Len(t, res.Data, n)
+				require.False(t, res.HasMore)
+			},
+		},
+		{
+			name:  "MissingQuery",
+			query: "",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchWorkouts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "shoulder",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchWorkouts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Workout{}, sql.ErrConnDone)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:  "NotOwner",
+			query: "shoulder",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, uuid.New(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchWorkouts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "Unauthorized",
+			query: "shoulder",
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchWorkouts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workout/search/%s", userID)
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			qParams := req.URL.Query()
+			qParams.Add("query", tc.query)
+			qParams.Add("page_id", "1")
+			qParams.Add("page_size", fmt.Sprintf("%d", n))
+			req.URL.RawQuery = qParams.Encode()
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
+func TestRepeatWorkout(t *testing.T) {
+	userID := uuid.New()
+	source := generateRandWorkout()
+	repeated := generateRandWorkout()
+	repeated.UserID = userID
+	startTime := time.Now().UnixMilli()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		configureAuth func(t *testing.T, request *http.Request, tokenCreator token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkRes      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"start_time": startTime,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.RepeatWorkoutTxParams{
+					WorkoutID: source.ID,
+					UserID:    userID,
+					StartTime: util.FormatMSEpoch(startTime),
+				}
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.RepeatWorkoutTxResult{Workout: repeated}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DefaultProgression",
+			body: gin.H{
+				"start_time":  startTime,
+				"progression": true,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.RepeatWorkoutTxParams{
+					WorkoutID: source.ID,
+					UserID:    userID,
+					StartTime: util.FormatMSEpoch(startTime),
+					Increment: defaultProgressionIncrement,
+				}
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.RepeatWorkoutTxResult{Workout: repeated}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CustomProgression",
+			body: gin.H{
+				"start_time":  startTime,
+				"progression": true,
+				"increment":   2.5,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				args := db.RepeatWorkoutTxParams{
+					WorkoutID: source.ID,
+					UserID:    userID,
+					StartTime: util.FormatMSEpoch(startTime),
+					Increment: 2.5,
+				}
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Eq(args)).Times(1).Return(db.RepeatWorkoutTxResult{Workout: repeated}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Warmup",
+			body: gin.H{
+				"start_time": startTime,
+				"warmup":     true,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				res := db.RepeatWorkoutTxResult{
+					Workout: repeated,
+					Lifts: []db.Lift{
+						{ExerciseID: 7, WeightLifted: 185},
+						{ExerciseID: 7, WeightLifted: 225},
+					},
+				}
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Any()).Times(1).Return(res, nil)
+				store.EXPECT().GetEquipmentSettings(gomock.Any(), gomock.Eq(userID)).Times(1).Return(db.EquipmentSetting{}, sql.ErrNoRows)
+				store.EXPECT().ListPlates(gomock.Any(), gomock.Eq(userID)).Times(1).Return([]db.PlateInventory{}, nil)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res repeatWorkoutResp
+				err := json.NewDecoder(recorder.Body).Decode(&res)
+				require.NoError(t, err)
+				require.Equal(t, util.WarmupSets(225, util.DefaultBarWeight, util.DefaultPlates), res.Warmups[7])
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"start_time": startTime,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Any()).Times(1).Return(db.RepeatWorkoutTxResult{}, sql.ErrNoRows)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotOwned",
+			body: gin.H{
+				"start_time": startTime,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Any()).Times(1).Return(db.RepeatWorkoutTxResult{}, db.ErrWorkoutNotOwned)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: gin.H{},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+				addAuthHeader(t, request, tokenCreator, bearerType, userID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"start_time": startTime,
+			},
+			configureAuth: func(t *testing.T, request *http.Request, tokenCreator token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RepeatWorkoutTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkRes: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workout/%s/repeat", source.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.configureAuth(t, req, server.tokenCreator)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkRes(recorder)
+		})
+	}
+}
+
 func TestDeleteWorkout(t *testing.T) {
 	workout := generateRandWorkout()
 
@@ -0,0 +1,114 @@
+// Package catalog ships the curated exercise catalog a fresh database is
+// seeded with. Bump Version in catalog.json whenever the data changes so
+// existing databases pick the change up.
+package catalog
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+)
+
+//go:embed catalog.json
+var data []byte
+
+type Exercise struct {
+	Name            string `json:"name"`
+	MuscleGroup     string `json:"muscle_group"`
+	Category        string `json:"category"`
+	Equipment       string `json:"equipment"`
+	MovementPattern string `json:"movement_pattern"`
+}
+
+type Catalog struct {
+	Version      int32      `json:"version"`
+	MuscleGroups []string   `json:"muscle_groups"`
+	Categories   []string   `json:"categories"`
+	Exercises    []Exercise `json:"exercises"`
+}
+
+var equipment = map[string]bool{
+	"barbell": true, "dumbbell": true, "cable": true, "machine": true, "bands": true, "bodyweight": true,
+}
+
+var movementPatterns = map[string]bool{
+	"": true, "squat": true, "hinge": true, "lunge": true, "horizontal_push": true, "vertical_push": true,
+	"horizontal_pull": true, "vertical_pull": true, "carry": true, "isolation": true,
+}
+
+// Load parses the embedded catalog and checks that every exercise refers to
+// a listed muscle group and category.
+func Load() (Catalog, error) {
+	return parse(data)
+}
+
+func parse(b []byte) (Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Catalog{}, err
+	}
+
+	if c.Version < 1 {
+		return Catalog{}, fmt.Errorf("catalog version must be positive, got %d", c.Version)
+	}
+
+	muscleGroups := toSet(c.MuscleGroups)
+	categories := toSet(c.Categories)
+	names := make(map[string]bool, len(c.Exercises))
+	for _, ex := range c.Exercises {
+		switch {
+		case names[ex.Name]:
+			return Catalog{}, fmt.Errorf("exercise %q is listed twice", ex.Name)
+		case !muscleGroups[ex.MuscleGroup]:
+			return Catalog{}, fmt.Errorf("exercise %q has unknown muscle group %q", ex.Name, ex.MuscleGroup)
+		case !categories[ex.Category]:
+			return Catalog{}, fmt.Errorf("exercise %q has unknown category %q", ex.Name, ex.Category)
+		case !equipment[ex.Equipment]:
+			return Catalog{}, fmt.Errorf("exercise %q has unknown equipment %q", ex.Name, ex.Equipment)
+		case !movementPatterns[ex.MovementPattern]:
+			return Catalog{}, fmt.Errorf("exercise %q has unknown movement pattern %q", ex.Name, ex.MovementPattern)
+		}
+		names[ex.Name] = true
+	}
+
+	return c, nil
+}
+
+// Seed applies the embedded catalog. It is a no-op when the database already
+// holds this version or a newer one, so it is safe to run on every startup.
+func Seed(ctx context.Context, store db.Store) (db.SeedCatalogTxResult, error) {
+	c, err := Load()
+	if err != nil {
+		return db.SeedCatalogTxResult{}, err
+	}
+
+	exercises := make([]db.CatalogExercise, len(c.Exercises))
+	for i, ex := range c.Exercises {
+		exercises[i] = db.CatalogExercise{
+			Name:            ex.Name,
+			MuscleGroup:     ex.MuscleGroup,
+			Category:        ex.Category,
+			Equipment:       ex.Equipment,
+			MovementPattern: ex.MovementPattern,
+			Version:         c.Version,
+		}
+	}
+
+	return store.SeedCatalogTx(ctx, db.SeedCatalogTxParams{
+		Version:      c.Version,
+		MuscleGroups: c.MuscleGroups,
+		Categories:   c.Categories,
+		Exercises:    exercises,
+	})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,84 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	c, err := Load()
+	require.NoError(t, err)
+	require.Positive(t, c.Version)
+	require.NotEmpty(t, c.MuscleGroups)
+	require.NotEmpty(t, c.Categories)
+	require.NotEmpty(t, c.Exercises)
+}
+
+func TestParseInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "MissingVersion",
+			data: `{"muscle_groups": ["Chest"], "categories": ["Compound"], "exercises": []}`,
+		},
+		{
+			name: "UnknownMuscleGroup",
+			data: `{"version": 1, "muscle_groups": ["Chest"], "categories": ["Compound"], "exercises": [
+				{"name": "Row", "muscle_group": "Back", "category": "Compound", "equipment": "barbell"}
+			]}`,
+		},
+		{
+			name: "UnknownEquipment",
+			data: `{"version": 1, "muscle_groups": ["Chest"], "categories": ["Compound"], "exercises": [
+				{"name": "Press", "muscle_group": "Chest", "category": "Compound", "equipment": "kettlebell"}
+			]}`,
+		},
+		{
+			name: "Duplicate",
+			data: `{"version": 1, "muscle_groups": ["Chest"], "categories": ["Compound"], "exercises": [
+				{"name": "Press", "muscle_group": "Chest", "category": "Compound", "equipment": "barbell"},
+				{"name": "Press", "muscle_group": "Chest", "category": "Compound", "equipment": "dumbbell"}
+			]}`,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse([]byte(tc.data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	c, err := Load()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		SeedCatalogTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.SeedCatalogTxParams) (db.SeedCatalogTxResult, error) {
+			require.Equal(t, c.Version, arg.Version)
+			require.Equal(t, c.MuscleGroups, arg.MuscleGroups)
+			require.Len(t, arg.Exercises, len(c.Exercises))
+			require.Equal(t, c.Exercises[0].Name, arg.Exercises[0].Name)
+			require.Equal(t, c.Version, arg.Exercises[0].Version)
+			return db.SeedCatalogTxResult{Version: arg.Version, Applied: true, Created: len(arg.Exercises)}, nil
+		})
+
+	res, err := Seed(context.Background(), store)
+	require.NoError(t, err)
+	require.True(t, res.Applied)
+}
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS "lift" DROP COLUMN IF EXISTS "notes";
+ALTER TABLE IF EXISTS "workout" DROP COLUMN IF EXISTS "energy";
+ALTER TABLE IF EXISTS "workout" DROP COLUMN IF EXISTS "rating";
+ALTER TABLE IF EXISTS "workout" DROP COLUMN IF EXISTS "notes";
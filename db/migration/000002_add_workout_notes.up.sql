@@ -0,0 +1,9 @@
+ALTER TABLE "workout"
+  ADD COLUMN "notes" TEXT NOT NULL DEFAULT '',
+  ADD COLUMN "rating" SMALLINT NOT NULL DEFAULT 0 CHECK ("rating" BETWEEN 0 AND 5),
+  ADD COLUMN "energy" SMALLINT NOT NULL DEFAULT 0 CHECK ("energy" BETWEEN 0 AND 5);
+
+ALTER TABLE "lift" ADD COLUMN "notes" TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX ON "workout" USING GIN (to_tsvector('english', "notes"));
+CREATE INDEX ON "lift" USING GIN (to_tsvector('english', "notes"));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS plate_inventory;
+DROP TABLE IF EXISTS equipment_settings;
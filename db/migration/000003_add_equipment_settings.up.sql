@@ -0,0 +1,11 @@
+CREATE TABLE "equipment_settings" (
+  "user_id" uuid PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  "bar_weight" REAL NOT NULL DEFAULT 45.0 CHECK ("bar_weight" >= 0)
+);
+
+CREATE TABLE "plate_inventory" (
+  "user_id" uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "weight" REAL NOT NULL CHECK ("weight" > 0),
+  "pairs" SMALLINT NOT NULL CHECK ("pairs" >= 0),
+  PRIMARY KEY ("user_id", "weight")
+);
@@ -0,0 +1,11 @@
+ALTER TABLE IF EXISTS "lift"
+  DROP CONSTRAINT IF EXISTS "lift_exercise_name_fkey",
+  ADD CONSTRAINT "lift_exercise_name_fkey" FOREIGN KEY ("exercise_name") REFERENCES exercise(name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE IF EXISTS "exercise"
+  DROP CONSTRAINT IF EXISTS "exercise_category_fkey",
+  ADD CONSTRAINT "exercise_category_fkey" FOREIGN KEY ("category") REFERENCES category(name) ON DELETE CASCADE,
+  DROP CONSTRAINT IF EXISTS "exercise_muscle_group_fkey",
+  ADD CONSTRAINT "exercise_muscle_group_fkey" FOREIGN KEY ("muscle_group") REFERENCES muscle_group(name) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE IF EXISTS "exercise" DROP COLUMN IF EXISTS "archived";
@@ -0,0 +1,11 @@
+ALTER TABLE "exercise" ADD COLUMN "archived" BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE "exercise"
+  DROP CONSTRAINT "exercise_muscle_group_fkey",
+  ADD CONSTRAINT "exercise_muscle_group_fkey" FOREIGN KEY ("muscle_group") REFERENCES muscle_group(name) ON DELETE RESTRICT ON UPDATE CASCADE,
+  DROP CONSTRAINT "exercise_category_fkey",
+  ADD CONSTRAINT "exercise_category_fkey" FOREIGN KEY ("category") REFERENCES category(name) ON DELETE RESTRICT;
+
+ALTER TABLE "lift"
+  DROP CONSTRAINT "lift_exercise_name_fkey",
+  ADD CONSTRAINT "lift_exercise_name_fkey" FOREIGN KEY ("exercise_name") REFERENCES exercise(name) ON UPDATE CASCADE ON DELETE RESTRICT;
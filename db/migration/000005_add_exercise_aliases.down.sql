@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS exercise_name_trgm_idx;
+DROP TABLE IF EXISTS exercise_alias;
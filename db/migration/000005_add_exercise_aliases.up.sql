@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE "exercise_alias" (
+  "id" SERIAL PRIMARY KEY,
+  "exercise_name" VARCHAR NOT NULL REFERENCES exercise(name) ON DELETE CASCADE ON UPDATE CASCADE,
+  "alias" VARCHAR NOT NULL
+);
+
+CREATE UNIQUE INDEX ON "exercise_alias" (LOWER("alias"));
+CREATE INDEX "exercise_alias_trgm_idx" ON "exercise_alias" USING GIN ("alias" gin_trgm_ops);
+CREATE INDEX "exercise_name_trgm_idx" ON "exercise" USING GIN ("name" gin_trgm_ops);
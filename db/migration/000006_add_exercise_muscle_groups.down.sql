@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "exercise_primary_muscle_group" ON "exercise";
+DROP FUNCTION IF EXISTS sync_primary_muscle_group();
+DROP TABLE IF EXISTS exercise_muscle_group;
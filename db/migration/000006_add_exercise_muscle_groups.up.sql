@@ -0,0 +1,32 @@
+CREATE TABLE "exercise_muscle_group" (
+  "exercise_name" VARCHAR NOT NULL REFERENCES exercise(name) ON DELETE CASCADE ON UPDATE CASCADE,
+  "muscle_group" VARCHAR NOT NULL REFERENCES muscle_group(name) ON DELETE RESTRICT ON UPDATE CASCADE,
+  "is_primary" BOOLEAN NOT NULL DEFAULT false,
+  "contribution" REAL NOT NULL DEFAULT 1 CHECK ("contribution" > 0 AND "contribution" <= 1),
+  PRIMARY KEY ("exercise_name", "muscle_group")
+);
+
+CREATE UNIQUE INDEX ON "exercise_muscle_group" ("exercise_name") WHERE "is_primary";
+CREATE INDEX ON "exercise_muscle_group" ("muscle_group");
+
+INSERT INTO "exercise_muscle_group" ("exercise_name", "muscle_group", "is_primary", "contribution")
+SELECT "name", "muscle_group", true, 1 FROM "exercise";
+
+-- exercise.muscle_group remains the primary group; keep its join row in step
+-- with it so existing writes through CreateExercise/UpdateExercise still work.
+CREATE FUNCTION sync_primary_muscle_group() RETURNS TRIGGER AS $$
+BEGIN
+  DELETE FROM "exercise_muscle_group"
+  WHERE "exercise_name" = NEW."name" AND "is_primary" AND "muscle_group" <> NEW."muscle_group";
+
+  INSERT INTO "exercise_muscle_group" ("exercise_name", "muscle_group", "is_primary", "contribution")
+  VALUES (NEW."name", NEW."muscle_group", true, 1)
+  ON CONFLICT ("exercise_name", "muscle_group") DO UPDATE SET "is_primary" = true, "contribution" = 1;
+
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "exercise_primary_muscle_group"
+AFTER INSERT OR UPDATE OF "name", "muscle_group" ON "exercise"
+FOR EACH ROW EXECUTE FUNCTION sync_primary_muscle_group();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS gym_profile;
+ALTER TABLE IF EXISTS "exercise" DROP COLUMN IF EXISTS "equipment";
@@ -0,0 +1,21 @@
+ALTER TABLE "exercise" ADD COLUMN "equipment" VARCHAR NOT NULL DEFAULT 'barbell'
+  CHECK ("equipment" IN ('barbell', 'dumbbell', 'cable', 'machine', 'bands', 'bodyweight'));
+
+UPDATE "exercise" SET "equipment" = CASE
+  WHEN "name" ILIKE '%dumbbell%' THEN 'dumbbell'
+  WHEN "name" ILIKE '%cable%' THEN 'cable'
+  WHEN "name" ILIKE '%machine%' OR "name" ILIKE '%smith%' THEN 'machine'
+  WHEN "name" ILIKE '%band%' THEN 'bands'
+  WHEN "name" ILIKE '%bodyweight%' OR "name" ILIKE '%pull up%' OR "name" ILIKE '%push up%' OR "name" ILIKE '%dip%' THEN 'bodyweight'
+  ELSE 'barbell'
+END;
+
+CREATE INDEX ON "exercise" ("equipment");
+
+CREATE TABLE "gym_profile" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "name" VARCHAR NOT NULL,
+  "equipment" VARCHAR[] NOT NULL DEFAULT '{}',
+  UNIQUE ("user_id", "name")
+);
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "exercise" DROP COLUMN IF EXISTS "movement_pattern";
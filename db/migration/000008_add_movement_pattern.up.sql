@@ -0,0 +1,15 @@
+ALTER TABLE "exercise" ADD COLUMN "movement_pattern" VARCHAR NOT NULL DEFAULT ''
+  CHECK ("movement_pattern" IN ('', 'squat', 'hinge', 'lunge', 'horizontal_push', 'vertical_push', 'horizontal_pull', 'vertical_pull', 'carry', 'isolation'));
+
+UPDATE "exercise" SET "movement_pattern" = CASE
+  WHEN "name" ILIKE '%split squat%' OR "name" ILIKE '%lunge%' OR "name" ILIKE '%step up%' THEN 'lunge'
+  WHEN "name" ILIKE '%squat%' OR "name" ILIKE '%leg press%' OR "name" ILIKE '%hack%' THEN 'squat'
+  WHEN "name" ILIKE '%deadlift%' OR "name" ILIKE '%rdl%' OR "name" ILIKE '%hip thrust%' OR "name" ILIKE '%good morning%' THEN 'hinge'
+  WHEN "name" ILIKE '%overhead press%' OR "name" ILIKE '%shoulder press%' OR "name" ILIKE '%military%' THEN 'vertical_push'
+  WHEN "name" ILIKE '%bench%' OR "name" ILIKE '%push up%' OR "name" ILIKE '%chest press%' OR "name" ILIKE '%dip%' THEN 'horizontal_push'
+  WHEN "name" ILIKE '%pull up%' OR "name" ILIKE '%chin up%' OR "name" ILIKE '%pulldown%' THEN 'vertical_pull'
+  WHEN "name" ILIKE '%row%' THEN 'horizontal_pull'
+  WHEN "name" ILIKE '%carry%' OR "name" ILIKE '%farmer%' THEN 'carry'
+  WHEN "name" ILIKE '%curl%' OR "name" ILIKE '%extension%' OR "name" ILIKE '%raise%' OR "name" ILIKE '%fly%' THEN 'isolation'
+  ELSE ''
+END;
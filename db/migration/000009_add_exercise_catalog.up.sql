@@ -0,0 +1,15 @@
+CREATE TABLE "catalog_version" (
+  "version" INT PRIMARY KEY,
+  "applied_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- what the catalog last wrote for each exercise, used to tell untouched rows
+-- apart from ones a user has since edited
+CREATE TABLE "catalog_exercise" (
+  "name" VARCHAR PRIMARY KEY,
+  "muscle_group" VARCHAR NOT NULL,
+  "category" VARCHAR NOT NULL,
+  "equipment" VARCHAR NOT NULL,
+  "movement_pattern" VARCHAR NOT NULL,
+  "version" INT NOT NULL
+);
@@ -0,0 +1,20 @@
+ALTER TABLE IF EXISTS "lift" ADD COLUMN "exercise_name" VARCHAR REFERENCES exercise(name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+UPDATE "lift" AS l SET "exercise_name" = e.name
+FROM "exercise" AS e
+WHERE e.id = l.exercise_id;
+
+ALTER TABLE IF EXISTS "lift" ALTER COLUMN "exercise_name" SET NOT NULL;
+ALTER TABLE IF EXISTS "lift" DROP COLUMN IF EXISTS "exercise_id";
+
+DROP TRIGGER IF EXISTS "exercise_slug" ON "exercise";
+DROP TRIGGER IF EXISTS "muscle_group_slug" ON "muscle_group";
+DROP TRIGGER IF EXISTS "category_slug" ON "category";
+
+ALTER TABLE IF EXISTS "exercise" DROP COLUMN IF EXISTS "slug";
+ALTER TABLE IF EXISTS "muscle_group" DROP COLUMN IF EXISTS "slug";
+ALTER TABLE IF EXISTS "category" DROP COLUMN IF EXISTS "slug";
+
+DROP FUNCTION IF EXISTS set_slug();
+DROP FUNCTION IF EXISTS unique_slug(REGCLASS, INT, TEXT);
+DROP FUNCTION IF EXISTS slugify(TEXT);
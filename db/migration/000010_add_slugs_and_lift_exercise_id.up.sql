@@ -0,0 +1,95 @@
+CREATE FUNCTION slugify(name TEXT) RETURNS TEXT AS $$
+  SELECT btrim(regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'), '-');
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- unique_slug returns base, or base with the first numeric suffix that no
+-- other row of tbl uses
+CREATE FUNCTION unique_slug(tbl REGCLASS, row_id INT, base TEXT) RETURNS TEXT AS $$
+DECLARE
+  candidate TEXT := base;
+  n INT := 1;
+  taken BOOLEAN;
+BEGIN
+  LOOP
+    EXECUTE format('SELECT EXISTS (SELECT 1 FROM %s WHERE slug = $1 AND id <> $2)', tbl)
+      INTO taken USING candidate, row_id;
+    EXIT WHEN NOT taken;
+    n := n + 1;
+    candidate := base || '-' || n;
+  END LOOP;
+  RETURN candidate;
+END;
+$$ LANGUAGE plpgsql;
+
+-- set_slug keeps the slug of a catalog row in step with its name. Slugs are
+-- never empty or all digits so a route reference can't be both an id and a
+-- slug.
+CREATE FUNCTION set_slug() RETURNS TRIGGER AS $$
+DECLARE
+  base TEXT := slugify(NEW.name);
+BEGIN
+  IF TG_OP = 'UPDATE' AND NEW.name = OLD.name THEN
+    NEW.slug := OLD.slug;
+    RETURN NEW;
+  END IF;
+
+  IF base = '' OR base ~ '^[0-9]+$' THEN
+    RAISE EXCEPTION 'name % has no usable slug', quote_literal(NEW.name)
+      USING ERRCODE = 'check_violation';
+  END IF;
+
+  NEW.slug := unique_slug(TG_RELID::REGCLASS, NEW.id, base);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE "category" ADD COLUMN "slug" VARCHAR;
+ALTER TABLE "muscle_group" ADD COLUMN "slug" VARCHAR;
+ALTER TABLE "exercise" ADD COLUMN "slug" VARCHAR;
+
+-- existing names that have no usable slug fall back to the table and id,
+-- names that collide after slugify get a numeric suffix in id order
+DO $$
+DECLARE
+  tbl TEXT;
+  r RECORD;
+  base TEXT;
+BEGIN
+  FOREACH tbl IN ARRAY ARRAY['category', 'muscle_group', 'exercise'] LOOP
+    FOR r IN EXECUTE format('SELECT id, name FROM %I ORDER BY id', tbl) LOOP
+      base := slugify(r.name);
+      IF base = '' OR base ~ '^[0-9]+$' THEN
+        base := replace(tbl, '_', '-') || '-' || r.id;
+      END IF;
+
+      EXECUTE format('UPDATE %I SET slug = $1 WHERE id = $2', tbl)
+        USING unique_slug(tbl::REGCLASS, r.id, base), r.id;
+    END LOOP;
+  END LOOP;
+END;
+$$;
+
+ALTER TABLE "category" ALTER COLUMN "slug" SET NOT NULL;
+CREATE UNIQUE INDEX "category_slug_idx" ON "category" ("slug");
+CREATE TRIGGER "category_slug" BEFORE INSERT OR UPDATE OF "name" ON "category"
+  FOR EACH ROW EXECUTE FUNCTION set_slug();
+
+ALTER TABLE "muscle_group" ALTER COLUMN "slug" SET NOT NULL;
+CREATE UNIQUE INDEX "muscle_group_slug_idx" ON "muscle_group" ("slug");
+CREATE TRIGGER "muscle_group_slug" BEFORE INSERT OR UPDATE OF "name" ON "muscle_group"
+  FOR EACH ROW EXECUTE FUNCTION set_slug();
+
+ALTER TABLE "exercise" ALTER COLUMN "slug" SET NOT NULL;
+CREATE UNIQUE INDEX "exercise_slug_idx" ON "exercise" ("slug");
+CREATE TRIGGER "exercise_slug" BEFORE INSERT OR UPDATE OF "name" ON "exercise"
+  FOR EACH ROW EXECUTE FUNCTION set_slug();
+
+ALTER TABLE "lift" ADD COLUMN "exercise_id" INT REFERENCES exercise(id) ON DELETE RESTRICT;
+
+UPDATE "lift" AS l SET "exercise_id" = e.id
+FROM "exercise" AS e
+WHERE e.name = l.exercise_name;
+
+ALTER TABLE "lift" ALTER COLUMN "exercise_id" SET NOT NULL;
+ALTER TABLE "lift" DROP COLUMN "exercise_name";
+CREATE INDEX ON "lift" ("exercise_id");
@@ -0,0 +1,11 @@
+-- only a sha256 of the emailed token is stored so a leaked table cannot be
+-- used to reset passwords
+CREATE TABLE "password_reset" (
+  "token_hash" VARCHAR PRIMARY KEY,
+  "user_id" UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "expires_at" TIMESTAMP NOT NULL,
+  "used_at" TIMESTAMP,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON "password_reset" ("user_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "email_verification";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "email_verified_at";
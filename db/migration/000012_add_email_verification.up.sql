@@ -0,0 +1,12 @@
+ALTER TABLE "accounts" ADD COLUMN "email_verified_at" TIMESTAMP;
+
+-- tokens are stored hashed for the same reason as password_reset
+CREATE TABLE "email_verification" (
+  "token_hash" VARCHAR PRIMARY KEY,
+  "user_id" UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "expires_at" TIMESTAMP NOT NULL,
+  "used_at" TIMESTAMP,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON "email_verification" ("user_id");
@@ -0,0 +1,8 @@
+-- backs the postgres login limiter, key is prefixed with what it limits
+-- such as the account email or client ip
+CREATE TABLE "login_attempt" (
+  "key" VARCHAR PRIMARY KEY,
+  "failures" INT NOT NULL DEFAULT 0,
+  "last_failure" TIMESTAMP NOT NULL,
+  "locked_until" TIMESTAMP NOT NULL
+);
@@ -0,0 +1,17 @@
+-- secret has to be kept readable to compute codes, confirmed_at stays null
+-- until the user proves their authenticator was set up
+CREATE TABLE "account_mfa" (
+  "user_id" UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  "secret" VARCHAR NOT NULL,
+  "confirmed_at" TIMESTAMP,
+  "last_used_step" BIGINT NOT NULL DEFAULT 0,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE "mfa_recovery_code" (
+  "code_hash" VARCHAR PRIMARY KEY,
+  "user_id" UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "used_at" TIMESTAMP
+);
+
+CREATE INDEX ON "mfa_recovery_code" ("user_id");
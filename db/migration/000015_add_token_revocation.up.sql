@@ -0,0 +1,15 @@
+-- tokens revoked before they expire, rows are useless once expires_at passes
+-- and get cleaned up
+CREATE TABLE "revoked_token" (
+  "token_id" UUID PRIMARY KEY,
+  "user_id" UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "expires_at" TIMESTAMP NOT NULL
+);
+
+CREATE INDEX ON "revoked_token" ("expires_at");
+
+-- every token issued to the user before revoked_before is rejected
+CREATE TABLE "token_cutoff" (
+  "user_id" UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  "revoked_before" TIMESTAMP NOT NULL
+);
@@ -0,0 +1,15 @@
+-- personal api keys, only a hash of the key is kept and prefix is what the
+-- owner sees when listing them
+CREATE TABLE "api_key" (
+  "id" UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "name" VARCHAR NOT NULL,
+  "prefix" VARCHAR NOT NULL,
+  "key_hash" VARCHAR NOT NULL UNIQUE,
+  "scopes" VARCHAR[] NOT NULL,
+  "last_used_at" TIMESTAMP,
+  "revoked_at" TIMESTAMP,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON "api_key" ("user_id");
@@ -0,0 +1,23 @@
+-- in flight sign ins with an external provider, keyed by a hash of the state
+-- parameter and deleted when the callback consumes them
+CREATE TABLE "oidc_login" (
+  "state_hash" VARCHAR PRIMARY KEY,
+  "provider" VARCHAR NOT NULL,
+  "code_verifier" VARCHAR NOT NULL,
+  "nonce" VARCHAR NOT NULL,
+  "expires_at" TIMESTAMP NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- an account signs in with a provider through the provider's subject, the
+-- email is what it was when the identity was linked
+CREATE TABLE "account_identity" (
+  "provider" VARCHAR NOT NULL,
+  "subject" VARCHAR NOT NULL,
+  "user_id" UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  "email" VARCHAR NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("provider", "subject")
+);
+
+CREATE INDEX ON "account_identity" ("user_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "preferences";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "height";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "date_of_birth";
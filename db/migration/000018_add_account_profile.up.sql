@@ -0,0 +1,5 @@
+ALTER TABLE "accounts" ADD COLUMN "date_of_birth" DATE;
+ALTER TABLE "accounts" ADD COLUMN "height" REAL;
+-- client settings such as units, kept as an object so clients can add keys
+-- without a migration
+ALTER TABLE "accounts" ADD COLUMN "preferences" JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE "lift" DROP COLUMN IF EXISTS "seq";
@@ -0,0 +1,2 @@
+-- lift ids are random uuids, seq keeps the order sets were recorded in
+ALTER TABLE "lift" ADD COLUMN "seq" BIGSERIAL NOT NULL;
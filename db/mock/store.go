@@ -6,7 +6,9 @@ package mockdb
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +38,64 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// ArchiveExercise mocks base method.
+func (m *MockStore) ArchiveExercise(arg0 context.Context, arg1 db.ArchiveExerciseParams) (db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveExercise", arg0, arg1)
+	ret0, _ := ret[0].(db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveExercise indicates an expected call of ArchiveExercise.
+func (mr *MockStoreMockRecorder) ArchiveExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveExercise", reflect.TypeOf((*MockStore)(nil).ArchiveExercise), arg0, arg1)
+}
+
+// ConfirmAccountMFA mocks base method.
+func (m *MockStore) ConfirmAccountMFA(arg0 context.Context, arg1 db.ConfirmAccountMFAParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmAccountMFA", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmAccountMFA indicates an expected call of ConfirmAccountMFA.
+func (mr *MockStoreMockRecorder) ConfirmAccountMFA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAccountMFA", reflect.TypeOf((*MockStore)(nil).ConfirmAccountMFA), arg0, arg1)
+}
+
+// ConfirmMFATx mocks base method.
+func (m *MockStore) ConfirmMFATx(arg0 context.Context, arg1 db.ConfirmMFATxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFATx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmMFATx indicates an expected call of ConfirmMFATx.
+func (mr *MockStoreMockRecorder) ConfirmMFATx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFATx", reflect.TypeOf((*MockStore)(nil).ConfirmMFATx), arg0, arg1)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockStore) CreateAPIKey(arg0 context.Context, arg1 db.CreateAPIKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockStoreMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockStore)(nil).CreateAPIKey), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +111,35 @@ func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
 }
 
+// CreateAccountIdentity mocks base method.
+func (m *MockStore) CreateAccountIdentity(arg0 context.Context, arg1 db.CreateAccountIdentityParams) (db.AccountIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountIdentity indicates an expected call of CreateAccountIdentity.
+func (mr *MockStoreMockRecorder) CreateAccountIdentity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountIdentity", reflect.TypeOf((*MockStore)(nil).CreateAccountIdentity), arg0, arg1)
+}
+
+// CreateCatalogVersion mocks base method.
+func (m *MockStore) CreateCatalogVersion(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCatalogVersion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCatalogVersion indicates an expected call of CreateCatalogVersion.
+func (mr *MockStoreMockRecorder) CreateCatalogVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogVersion", reflect.TypeOf((*MockStore)(nil).CreateCatalogVersion), arg0, arg1)
+}
+
 // CreateCategory mocks base method.
 func (m *MockStore) CreateCategory(arg0 context.Context, arg1 string) (db.Category, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +155,21 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
 }
 
+// CreateEmailVerification mocks base method.
+func (m *MockStore) CreateEmailVerification(arg0 context.Context, arg1 db.CreateEmailVerificationParams) (db.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailVerification indicates an expected call of CreateEmailVerification.
+func (mr *MockStoreMockRecorder) CreateEmailVerification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerification", reflect.TypeOf((*MockStore)(nil).CreateEmailVerification), arg0, arg1)
+}
+
 // CreateExercise mocks base method.
 func (m *MockStore) CreateExercise(arg0 context.Context, arg1 db.CreateExerciseParams) (db.Exercise, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +185,36 @@ func (mr *MockStoreMockRecorder) CreateExercise(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExercise", reflect.TypeOf((*MockStore)(nil).CreateExercise), arg0, arg1)
 }
 
+// CreateExerciseAlias mocks base method.
+func (m *MockStore) CreateExerciseAlias(arg0 context.Context, arg1 db.CreateExerciseAliasParams) (db.ExerciseAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExerciseAlias", arg0, arg1)
+	ret0, _ := ret[0].(db.ExerciseAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExerciseAlias indicates an expected call of CreateExerciseAlias.
+func (mr *MockStoreMockRecorder) CreateExerciseAlias(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExerciseAlias", reflect.TypeOf((*MockStore)(nil).CreateExerciseAlias), arg0, arg1)
+}
+
+// CreateGymProfile mocks base method.
+func (m *MockStore) CreateGymProfile(arg0 context.Context, arg1 db.CreateGymProfileParams) (db.GymProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGymProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.GymProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGymProfile indicates an expected call of CreateGymProfile.
+func (mr *MockStoreMockRecorder) CreateGymProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGymProfile", reflect.TypeOf((*MockStore)(nil).CreateGymProfile), arg0, arg1)
+}
+
 // CreateLift mocks base method.
 func (m *MockStore) CreateLift(arg0 context.Context, arg1 db.CreateLiftParams) (db.Lift, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +260,79 @@ func (mr *MockStoreMockRecorder) CreateMuscleGroup(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMuscleGroup", reflect.TypeOf((*MockStore)(nil).CreateMuscleGroup), arg0, arg1)
 }
 
+// CreateOIDCLogin mocks base method.
+func (m *MockStore) CreateOIDCLogin(arg0 context.Context, arg1 db.CreateOIDCLoginParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOIDCLogin", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOIDCLogin indicates an expected call of CreateOIDCLogin.
+func (mr *MockStoreMockRecorder) CreateOIDCLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCLogin", reflect.TypeOf((*MockStore)(nil).CreateOIDCLogin), arg0, arg1)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockStore) CreatePasswordReset(arg0 context.Context, arg1 db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockStoreMockRecorder) CreatePasswordReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockStore)(nil).CreatePasswordReset), arg0, arg1)
+}
+
+// CreatePlates mocks base method.
+func (m *MockStore) CreatePlates(arg0 context.Context, arg1 db.CreatePlatesParams) ([]db.PlateInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlates", arg0, arg1)
+	ret0, _ := ret[0].([]db.PlateInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlates indicates an expected call of CreatePlates.
+func (mr *MockStoreMockRecorder) CreatePlates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlates", reflect.TypeOf((*MockStore)(nil).CreatePlates), arg0, arg1)
+}
+
+// CreateRecoveryCode mocks base method.
+func (m *MockStore) CreateRecoveryCode(arg0 context.Context, arg1 db.CreateRecoveryCodeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRecoveryCode indicates an expected call of CreateRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateRecoveryCode), arg0, arg1)
+}
+
+// CreateSecondaryMuscleGroups mocks base method.
+func (m *MockStore) CreateSecondaryMuscleGroups(arg0 context.Context, arg1 db.CreateSecondaryMuscleGroupsParams) ([]db.ExerciseMuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecondaryMuscleGroups", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExerciseMuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecondaryMuscleGroups indicates an expected call of CreateSecondaryMuscleGroups.
+func (mr *MockStoreMockRecorder) CreateSecondaryMuscleGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecondaryMuscleGroups", reflect.TypeOf((*MockStore)(nil).CreateSecondaryMuscleGroups), arg0, arg1)
+}
+
 // CreateWorkout mocks base method.
 func (m *MockStore) CreateWorkout(arg0 context.Context, arg1 db.CreateWorkoutParams) (db.Workout, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +377,20 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
 }
 
+// DeleteEmailVerifications mocks base method.
+func (m *MockStore) DeleteEmailVerifications(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailVerifications", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailVerifications indicates an expected call of DeleteEmailVerifications.
+func (mr *MockStoreMockRecorder) DeleteEmailVerifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailVerifications", reflect.TypeOf((*MockStore)(nil).DeleteEmailVerifications), arg0, arg1)
+}
+
 // DeleteExercise mocks base method.
 func (m *MockStore) DeleteExercise(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -184,8 +405,53 @@ func (mr *MockStoreMockRecorder) DeleteExercise(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExercise", reflect.TypeOf((*MockStore)(nil).DeleteExercise), arg0, arg1)
 }
 
+// DeleteExerciseAlias mocks base method.
+func (m *MockStore) DeleteExerciseAlias(arg0 context.Context, arg1 db.DeleteExerciseAliasParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExerciseAlias", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExerciseAlias indicates an expected call of DeleteExerciseAlias.
+func (mr *MockStoreMockRecorder) DeleteExerciseAlias(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExerciseAlias", reflect.TypeOf((*MockStore)(nil).DeleteExerciseAlias), arg0, arg1)
+}
+
+// DeleteExpiredOIDCLogins mocks base method.
+func (m *MockStore) DeleteExpiredOIDCLogins(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredOIDCLogins", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredOIDCLogins indicates an expected call of DeleteExpiredOIDCLogins.
+func (mr *MockStoreMockRecorder) DeleteExpiredOIDCLogins(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredOIDCLogins", reflect.TypeOf((*MockStore)(nil).DeleteExpiredOIDCLogins), arg0, arg1)
+}
+
+// DeleteExpiredRevokedTokens mocks base method.
+func (m *MockStore) DeleteExpiredRevokedTokens(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRevokedTokens", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRevokedTokens indicates an expected call of DeleteExpiredRevokedTokens.
+func (mr *MockStoreMockRecorder) DeleteExpiredRevokedTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRevokedTokens", reflect.TypeOf((*MockStore)(nil).DeleteExpiredRevokedTokens), arg0, arg1)
+}
+
 // DeleteGroup mocks base method.
-func (m *MockStore) DeleteGroup(arg0 context.Context, arg1 string) (db.MuscleGroup, error) {
+func (m *MockStore) DeleteGroup(arg0 context.Context, arg1 int16) (db.MuscleGroup, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteGroup", arg0, arg1)
 	ret0, _ := ret[0].(db.MuscleGroup)
@@ -199,6 +465,21 @@ func (mr *MockStoreMockRecorder) DeleteGroup(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockStore)(nil).DeleteGroup), arg0, arg1)
 }
 
+// DeleteGymProfile mocks base method.
+func (m *MockStore) DeleteGymProfile(arg0 context.Context, arg1 db.DeleteGymProfileParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGymProfile", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGymProfile indicates an expected call of DeleteGymProfile.
+func (mr *MockStoreMockRecorder) DeleteGymProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGymProfile", reflect.TypeOf((*MockStore)(nil).DeleteGymProfile), arg0, arg1)
+}
+
 // DeleteLift mocks base method.
 func (m *MockStore) DeleteLift(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -213,6 +494,76 @@ func (mr *MockStoreMockRecorder) DeleteLift(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLift", reflect.TypeOf((*MockStore)(nil).DeleteLift), arg0, arg1)
 }
 
+// DeleteLoginAttempt mocks base method.
+func (m *MockStore) DeleteLoginAttempt(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempt indicates an expected call of DeleteLoginAttempt.
+func (mr *MockStoreMockRecorder) DeleteLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempt", reflect.TypeOf((*MockStore)(nil).DeleteLoginAttempt), arg0, arg1)
+}
+
+// DeletePasswordResets mocks base method.
+func (m *MockStore) DeletePasswordResets(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordResets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordResets indicates an expected call of DeletePasswordResets.
+func (mr *MockStoreMockRecorder) DeletePasswordResets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordResets", reflect.TypeOf((*MockStore)(nil).DeletePasswordResets), arg0, arg1)
+}
+
+// DeletePlates mocks base method.
+func (m *MockStore) DeletePlates(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlates", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlates indicates an expected call of DeletePlates.
+func (mr *MockStoreMockRecorder) DeletePlates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlates", reflect.TypeOf((*MockStore)(nil).DeletePlates), arg0, arg1)
+}
+
+// DeleteRecoveryCodes mocks base method.
+func (m *MockStore) DeleteRecoveryCodes(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteRecoveryCodes), arg0, arg1)
+}
+
+// DeleteSecondaryMuscleGroups mocks base method.
+func (m *MockStore) DeleteSecondaryMuscleGroups(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecondaryMuscleGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecondaryMuscleGroups indicates an expected call of DeleteSecondaryMuscleGroups.
+func (mr *MockStoreMockRecorder) DeleteSecondaryMuscleGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecondaryMuscleGroups", reflect.TypeOf((*MockStore)(nil).DeleteSecondaryMuscleGroups), arg0, arg1)
+}
+
 // DeleteWorkout mocks base method.
 func (m *MockStore) DeleteWorkout(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -227,6 +578,21 @@ func (mr *MockStoreMockRecorder) DeleteWorkout(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkout", reflect.TypeOf((*MockStore)(nil).DeleteWorkout), arg0, arg1)
 }
 
+// GetAPIKeyByHash mocks base method.
+func (m *MockStore) GetAPIKeyByHash(arg0 context.Context, arg1 string) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockStoreMockRecorder) GetAPIKeyByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockStore)(nil).GetAPIKeyByHash), arg0, arg1)
+}
+
 // GetAccount mocks base method.
 func (m *MockStore) GetAccount(arg0 context.Context, arg1 uuid.UUID) (db.Account, error) {
 	m.ctrl.T.Helper()
@@ -257,229 +623,957 @@ func (mr *MockStoreMockRecorder) GetAccountByEmail(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByEmail", reflect.TypeOf((*MockStore)(nil).GetAccountByEmail), arg0, arg1)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 context.Context, arg1 int16) (db.Category, error) {
+// GetAccountIdentity mocks base method.
+func (m *MockStore) GetAccountIdentity(arg0 context.Context, arg1 db.GetAccountIdentityParams) (db.AccountIdentity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "GetAccountIdentity", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountIdentity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountIdentity indicates an expected call of GetAccountIdentity.
+func (mr *MockStoreMockRecorder) GetAccountIdentity(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountIdentity", reflect.TypeOf((*MockStore)(nil).GetAccountIdentity), arg0, arg1)
 }
 
-// GetExercise mocks base method.
-func (m *MockStore) GetExercise(arg0 context.Context, arg1 string) (db.Exercise, error) {
+// GetAccountMFA mocks base method.
+func (m *MockStore) GetAccountMFA(arg0 context.Context, arg1 uuid.UUID) (db.AccountMfa, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExercise", arg0, arg1)
-	ret0, _ := ret[0].(db.Exercise)
+	ret := m.ctrl.Call(m, "GetAccountMFA", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountMfa)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExercise indicates an expected call of GetExercise.
-func (mr *MockStoreMockRecorder) GetExercise(arg0, arg1 interface{}) *gomock.Call {
+// GetAccountMFA indicates an expected call of GetAccountMFA.
+func (mr *MockStoreMockRecorder) GetAccountMFA(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExercise", reflect.TypeOf((*MockStore)(nil).GetExercise), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMFA", reflect.TypeOf((*MockStore)(nil).GetAccountMFA), arg0, arg1)
 }
 
-// GetLift mocks base method.
-func (m *MockStore) GetLift(arg0 context.Context, arg1 db.GetLiftParams) (db.Lift, error) {
+// GetCatalogExercise mocks base method.
+func (m *MockStore) GetCatalogExercise(arg0 context.Context, arg1 string) (db.CatalogExercise, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLift", arg0, arg1)
-	ret0, _ := ret[0].(db.Lift)
+	ret := m.ctrl.Call(m, "GetCatalogExercise", arg0, arg1)
+	ret0, _ := ret[0].(db.CatalogExercise)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLift indicates an expected call of GetLift.
-func (mr *MockStoreMockRecorder) GetLift(arg0, arg1 interface{}) *gomock.Call {
+// GetCatalogExercise indicates an expected call of GetCatalogExercise.
+func (mr *MockStoreMockRecorder) GetCatalogExercise(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLift", reflect.TypeOf((*MockStore)(nil).GetLift), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogExercise", reflect.TypeOf((*MockStore)(nil).GetCatalogExercise), arg0, arg1)
 }
 
-// GetMuscleGroup mocks base method.
-func (m *MockStore) GetMuscleGroup(arg0 context.Context, arg1 string) (db.MuscleGroup, error) {
+// GetCatalogVersion mocks base method.
+func (m *MockStore) GetCatalogVersion(arg0 context.Context) (int32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMuscleGroup", arg0, arg1)
-	ret0, _ := ret[0].(db.MuscleGroup)
+	ret := m.ctrl.Call(m, "GetCatalogVersion", arg0)
+	ret0, _ := ret[0].(int32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMuscleGroup indicates an expected call of GetMuscleGroup.
-func (mr *MockStoreMockRecorder) GetMuscleGroup(arg0, arg1 interface{}) *gomock.Call {
+// GetCatalogVersion indicates an expected call of GetCatalogVersion.
+func (mr *MockStoreMockRecorder) GetCatalogVersion(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuscleGroup", reflect.TypeOf((*MockStore)(nil).GetMuscleGroup), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogVersion", reflect.TypeOf((*MockStore)(nil).GetCatalogVersion), arg0)
 }
 
-// GetMuscleGroups mocks base method.
-func (m *MockStore) GetMuscleGroups(arg0 context.Context) ([]db.MuscleGroup, error) {
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 int16) (db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMuscleGroups", arg0)
-	ret0, _ := ret[0].([]db.MuscleGroup)
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMuscleGroups indicates an expected call of GetMuscleGroups.
-func (mr *MockStoreMockRecorder) GetMuscleGroups(arg0 interface{}) *gomock.Call {
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuscleGroups", reflect.TypeOf((*MockStore)(nil).GetMuscleGroups), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
 }
 
-// GetWorkout mocks base method.
-func (m *MockStore) GetWorkout(arg0 context.Context, arg1 uuid.UUID) ([]db.GetWorkoutRow, error) {
+// GetCategoryBySlug mocks base method.
+func (m *MockStore) GetCategoryBySlug(arg0 context.Context, arg1 string) (db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkout", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetWorkoutRow)
+	ret := m.ctrl.Call(m, "GetCategoryBySlug", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkout indicates an expected call of GetWorkout.
-func (mr *MockStoreMockRecorder) GetWorkout(arg0, arg1 interface{}) *gomock.Call {
+// GetCategoryBySlug indicates an expected call of GetCategoryBySlug.
+func (mr *MockStoreMockRecorder) GetCategoryBySlug(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkout", reflect.TypeOf((*MockStore)(nil).GetWorkout), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryBySlug", reflect.TypeOf((*MockStore)(nil).GetCategoryBySlug), arg0, arg1)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// GetEmailVerifiedAt mocks base method.
+func (m *MockStore) GetEmailVerifiedAt(arg0 context.Context, arg1 uuid.UUID) (sql.NullTime, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "GetEmailVerifiedAt", arg0, arg1)
+	ret0, _ := ret[0].(sql.NullTime)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
+// GetEmailVerifiedAt indicates an expected call of GetEmailVerifiedAt.
+func (mr *MockStoreMockRecorder) GetEmailVerifiedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailVerifiedAt", reflect.TypeOf((*MockStore)(nil).GetEmailVerifiedAt), arg0, arg1)
+}
+
+// GetEquipmentSettings mocks base method.
+func (m *MockStore) GetEquipmentSettings(arg0 context.Context, arg1 uuid.UUID) (db.EquipmentSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEquipmentSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.EquipmentSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEquipmentSettings indicates an expected call of GetEquipmentSettings.
+func (mr *MockStoreMockRecorder) GetEquipmentSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEquipmentSettings", reflect.TypeOf((*MockStore)(nil).GetEquipmentSettings), arg0, arg1)
+}
+
+// GetExercise mocks base method.
+func (m *MockStore) GetExercise(arg0 context.Context, arg1 string) (db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExercise", arg0, arg1)
+	ret0, _ := ret[0].(db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExercise indicates an expected call of GetExercise.
+func (mr *MockStoreMockRecorder) GetExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExercise", reflect.TypeOf((*MockStore)(nil).GetExercise), arg0, arg1)
+}
+
+// GetExerciseByID mocks base method.
+func (m *MockStore) GetExerciseByID(arg0 context.Context, arg1 int32) (db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExerciseByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExerciseByID indicates an expected call of GetExerciseByID.
+func (mr *MockStoreMockRecorder) GetExerciseByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExerciseByID", reflect.TypeOf((*MockStore)(nil).GetExerciseByID), arg0, arg1)
+}
+
+// GetExerciseBySlug mocks base method.
+func (m *MockStore) GetExerciseBySlug(arg0 context.Context, arg1 string) (db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExerciseBySlug", arg0, arg1)
+	ret0, _ := ret[0].(db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExerciseBySlug indicates an expected call of GetExerciseBySlug.
+func (mr *MockStoreMockRecorder) GetExerciseBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExerciseBySlug", reflect.TypeOf((*MockStore)(nil).GetExerciseBySlug), arg0, arg1)
+}
+
+// GetGymProfile mocks base method.
+func (m *MockStore) GetGymProfile(arg0 context.Context, arg1 uuid.UUID) (db.GymProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGymProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.GymProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGymProfile indicates an expected call of GetGymProfile.
+func (mr *MockStoreMockRecorder) GetGymProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGymProfile", reflect.TypeOf((*MockStore)(nil).GetGymProfile), arg0, arg1)
+}
+
+// GetLatestEmailVerification mocks base method.
+func (m *MockStore) GetLatestEmailVerification(arg0 context.Context, arg1 uuid.UUID) (db.EmailVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEmailVerification indicates an expected call of GetLatestEmailVerification.
+func (mr *MockStoreMockRecorder) GetLatestEmailVerification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEmailVerification", reflect.TypeOf((*MockStore)(nil).GetLatestEmailVerification), arg0, arg1)
+}
+
+// GetLift mocks base method.
+func (m *MockStore) GetLift(arg0 context.Context, arg1 db.GetLiftParams) (db.Lift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLift", arg0, arg1)
+	ret0, _ := ret[0].(db.Lift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLift indicates an expected call of GetLift.
+func (mr *MockStoreMockRecorder) GetLift(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLift", reflect.TypeOf((*MockStore)(nil).GetLift), arg0, arg1)
+}
+
+// GetLoginAttempt mocks base method.
+func (m *MockStore) GetLoginAttempt(arg0 context.Context, arg1 string) (db.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempt indicates an expected call of GetLoginAttempt.
+func (mr *MockStoreMockRecorder) GetLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempt", reflect.TypeOf((*MockStore)(nil).GetLoginAttempt), arg0, arg1)
+}
+
+// GetMuscleGroup mocks base method.
+func (m *MockStore) GetMuscleGroup(arg0 context.Context, arg1 string) (db.MuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuscleGroup", arg0, arg1)
+	ret0, _ := ret[0].(db.MuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMuscleGroup indicates an expected call of GetMuscleGroup.
+func (mr *MockStoreMockRecorder) GetMuscleGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuscleGroup", reflect.TypeOf((*MockStore)(nil).GetMuscleGroup), arg0, arg1)
+}
+
+// GetMuscleGroupByID mocks base method.
+func (m *MockStore) GetMuscleGroupByID(arg0 context.Context, arg1 int16) (db.MuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuscleGroupByID", arg0, arg1)
+	ret0, _ := ret[0].(db.MuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMuscleGroupByID indicates an expected call of GetMuscleGroupByID.
+func (mr *MockStoreMockRecorder) GetMuscleGroupByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuscleGroupByID", reflect.TypeOf((*MockStore)(nil).GetMuscleGroupByID), arg0, arg1)
+}
+
+// GetMuscleGroupBySlug mocks base method.
+func (m *MockStore) GetMuscleGroupBySlug(arg0 context.Context, arg1 string) (db.MuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuscleGroupBySlug", arg0, arg1)
+	ret0, _ := ret[0].(db.MuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMuscleGroupBySlug indicates an expected call of GetMuscleGroupBySlug.
+func (mr *MockStoreMockRecorder) GetMuscleGroupBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuscleGroupBySlug", reflect.TypeOf((*MockStore)(nil).GetMuscleGroupBySlug), arg0, arg1)
+}
+
+// GetMuscleGroups mocks base method.
+func (m *MockStore) GetMuscleGroups(arg0 context.Context) ([]db.MuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuscleGroups", arg0)
+	ret0, _ := ret[0].([]db.MuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMuscleGroups indicates an expected call of GetMuscleGroups.
+func (mr *MockStoreMockRecorder) GetMuscleGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuscleGroups", reflect.TypeOf((*MockStore)(nil).GetMuscleGroups), arg0)
+}
+
+// GetPasswordChangedAt mocks base method.
+func (m *MockStore) GetPasswordChangedAt(arg0 context.Context, arg1 uuid.UUID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordChangedAt", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordChangedAt indicates an expected call of GetPasswordChangedAt.
+func (mr *MockStoreMockRecorder) GetPasswordChangedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordChangedAt", reflect.TypeOf((*MockStore)(nil).GetPasswordChangedAt), arg0, arg1)
+}
+
+// GetWorkout mocks base method.
+func (m *MockStore) GetWorkout(arg0 context.Context, arg1 uuid.UUID) ([]db.GetWorkoutRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkout", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetWorkoutRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkout indicates an expected call of GetWorkout.
+func (mr *MockStoreMockRecorder) GetWorkout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkout", reflect.TypeOf((*MockStore)(nil).GetWorkout), arg0, arg1)
+}
+
+// ImportWorkoutsTx mocks base method.
+func (m *MockStore) ImportWorkoutsTx(arg0 context.Context, arg1 db.ImportWorkoutsTxParams) (db.ImportWorkoutsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportWorkoutsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportWorkoutsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportWorkoutsTx indicates an expected call of ImportWorkoutsTx.
+func (mr *MockStoreMockRecorder) ImportWorkoutsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportWorkoutsTx", reflect.TypeOf((*MockStore)(nil).ImportWorkoutsTx), arg0, arg1)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockStore) IsTokenRevoked(arg0 context.Context, arg1 db.IsTokenRevokedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockStoreMockRecorder) IsTokenRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockStore)(nil).IsTokenRevoked), arg0, arg1)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockStore) ListAPIKeys(arg0 context.Context, arg1 uuid.UUID) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockStoreMockRecorder) ListAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockStore)(nil).ListAPIKeys), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
 func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListByMuscleGroup mocks base method.
+func (m *MockStore) ListByMuscleGroup(arg0 context.Context, arg1 db.ListByMuscleGroupParams) ([]db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMuscleGroup", arg0, arg1)
+	ret0, _ := ret[0].([]db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMuscleGroup indicates an expected call of ListByMuscleGroup.
+func (mr *MockStoreMockRecorder) ListByMuscleGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMuscleGroup", reflect.TypeOf((*MockStore)(nil).ListByMuscleGroup), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0)
+}
+
+// ListExerciseAliases mocks base method.
+func (m *MockStore) ListExerciseAliases(arg0 context.Context, arg1 string) ([]db.ExerciseAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExerciseAliases", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExerciseAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExerciseAliases indicates an expected call of ListExerciseAliases.
+func (mr *MockStoreMockRecorder) ListExerciseAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExerciseAliases", reflect.TypeOf((*MockStore)(nil).ListExerciseAliases), arg0, arg1)
+}
+
+// ListExerciseMuscleGroups mocks base method.
+func (m *MockStore) ListExerciseMuscleGroups(arg0 context.Context, arg1 string) ([]db.ExerciseMuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExerciseMuscleGroups", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExerciseMuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExerciseMuscleGroups indicates an expected call of ListExerciseMuscleGroups.
+func (mr *MockStoreMockRecorder) ListExerciseMuscleGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExerciseMuscleGroups", reflect.TypeOf((*MockStore)(nil).ListExerciseMuscleGroups), arg0, arg1)
+}
+
+// ListExerciseNames mocks base method.
+func (m *MockStore) ListExerciseNames(arg0 context.Context) ([]db.ListExerciseNamesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExerciseNames", arg0)
+	ret0, _ := ret[0].([]db.ListExerciseNamesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExerciseNames indicates an expected call of ListExerciseNames.
+func (mr *MockStoreMockRecorder) ListExerciseNames(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExerciseNames", reflect.TypeOf((*MockStore)(nil).ListExerciseNames), arg0)
+}
+
+// ListExerciseSubstitutes mocks base method.
+func (m *MockStore) ListExerciseSubstitutes(arg0 context.Context, arg1 db.ListExerciseSubstitutesParams) ([]db.ListExerciseSubstitutesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExerciseSubstitutes", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExerciseSubstitutesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExerciseSubstitutes indicates an expected call of ListExerciseSubstitutes.
+func (mr *MockStoreMockRecorder) ListExerciseSubstitutes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExerciseSubstitutes", reflect.TypeOf((*MockStore)(nil).ListExerciseSubstitutes), arg0, arg1)
+}
+
+// ListExercises mocks base method.
+func (m *MockStore) ListExercises(arg0 context.Context, arg1 db.ListExercisesParams) ([]db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExercises", arg0, arg1)
+	ret0, _ := ret[0].([]db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExercises indicates an expected call of ListExercises.
+func (mr *MockStoreMockRecorder) ListExercises(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExercises", reflect.TypeOf((*MockStore)(nil).ListExercises), arg0, arg1)
+}
+
+// ListGymProfiles mocks base method.
+func (m *MockStore) ListGymProfiles(arg0 context.Context, arg1 uuid.UUID) ([]db.GymProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGymProfiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.GymProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGymProfiles indicates an expected call of ListGymProfiles.
+func (mr *MockStoreMockRecorder) ListGymProfiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGymProfiles", reflect.TypeOf((*MockStore)(nil).ListGymProfiles), arg0, arg1)
+}
+
+// ListLifts mocks base method.
+func (m *MockStore) ListLifts(arg0 context.Context, arg1 db.ListLiftsParams) ([]db.ListLiftsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLifts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLiftsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLifts indicates an expected call of ListLifts.
+func (mr *MockStoreMockRecorder) ListLifts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLifts", reflect.TypeOf((*MockStore)(nil).ListLifts), arg0, arg1)
+}
+
+// ListMuscleGroupVolume mocks base method.
+func (m *MockStore) ListMuscleGroupVolume(arg0 context.Context, arg1 db.ListMuscleGroupVolumeParams) ([]db.ListMuscleGroupVolumeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMuscleGroupVolume", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMuscleGroupVolumeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMuscleGroupVolume indicates an expected call of ListMuscleGroupVolume.
+func (mr *MockStoreMockRecorder) ListMuscleGroupVolume(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMuscleGroupVolume", reflect.TypeOf((*MockStore)(nil).ListMuscleGroupVolume), arg0, arg1)
+}
+
+// ListPRs mocks base method.
+func (m *MockStore) ListPRs(arg0 context.Context, arg1 db.ListPRsParams) ([]db.Lift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPRs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Lift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPRs indicates an expected call of ListPRs.
+func (mr *MockStoreMockRecorder) ListPRs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRs", reflect.TypeOf((*MockStore)(nil).ListPRs), arg0, arg1)
+}
+
+// ListPRsByExercise mocks base method.
+func (m *MockStore) ListPRsByExercise(arg0 context.Context, arg1 db.ListPRsByExerciseParams) ([]db.Lift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPRsByExercise", arg0, arg1)
+	ret0, _ := ret[0].([]db.Lift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPRsByExercise indicates an expected call of ListPRsByExercise.
+func (mr *MockStoreMockRecorder) ListPRsByExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRsByExercise", reflect.TypeOf((*MockStore)(nil).ListPRsByExercise), arg0, arg1)
+}
+
+// ListPRsByMuscleGroup mocks base method.
+func (m *MockStore) ListPRsByMuscleGroup(arg0 context.Context, arg1 db.ListPRsByMuscleGroupParams) ([]db.ListPRsByMuscleGroupRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPRsByMuscleGroup", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPRsByMuscleGroupRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPRsByMuscleGroup indicates an expected call of ListPRsByMuscleGroup.
+func (mr *MockStoreMockRecorder) ListPRsByMuscleGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRsByMuscleGroup", reflect.TypeOf((*MockStore)(nil).ListPRsByMuscleGroup), arg0, arg1)
+}
+
+// ListPlates mocks base method.
+func (m *MockStore) ListPlates(arg0 context.Context, arg1 uuid.UUID) ([]db.PlateInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlates", arg0, arg1)
+	ret0, _ := ret[0].([]db.PlateInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlates indicates an expected call of ListPlates.
+func (mr *MockStoreMockRecorder) ListPlates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlates", reflect.TypeOf((*MockStore)(nil).ListPlates), arg0, arg1)
+}
+
+// ListUserExercises mocks base method.
+func (m *MockStore) ListUserExercises(arg0 context.Context, arg1 db.ListUserExercisesParams) ([]db.Exercise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserExercises", arg0, arg1)
+	ret0, _ := ret[0].([]db.Exercise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserExercises indicates an expected call of ListUserExercises.
+func (mr *MockStoreMockRecorder) ListUserExercises(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserExercises", reflect.TypeOf((*MockStore)(nil).ListUserExercises), arg0, arg1)
+}
+
+// ListWorkoutLifts mocks base method.
+func (m *MockStore) ListWorkoutLifts(arg0 context.Context, arg1 uuid.UUID) ([]db.Lift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkoutLifts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Lift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkoutLifts indicates an expected call of ListWorkoutLifts.
+func (mr *MockStoreMockRecorder) ListWorkoutLifts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkoutLifts", reflect.TypeOf((*MockStore)(nil).ListWorkoutLifts), arg0, arg1)
+}
+
+// ListWorkouts mocks base method.
+func (m *MockStore) ListWorkouts(arg0 context.Context, arg1 db.ListWorkoutsParams) ([]db.Workout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkouts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Workout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkouts indicates an expected call of ListWorkouts.
+func (mr *MockStoreMockRecorder) ListWorkouts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkouts", reflect.TypeOf((*MockStore)(nil).ListWorkouts), arg0, arg1)
+}
+
+// LockCatalog mocks base method.
+func (m *MockStore) LockCatalog(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCatalog", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockCatalog indicates an expected call of LockCatalog.
+func (mr *MockStoreMockRecorder) LockCatalog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCatalog", reflect.TypeOf((*MockStore)(nil).LockCatalog), arg0)
+}
+
+// LockLoginAttempt mocks base method.
+func (m *MockStore) LockLoginAttempt(arg0 context.Context, arg1 db.LockLoginAttemptParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLoginAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLoginAttempt indicates an expected call of LockLoginAttempt.
+func (mr *MockStoreMockRecorder) LockLoginAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoginAttempt", reflect.TypeOf((*MockStore)(nil).LockLoginAttempt), arg0, arg1)
+}
+
+// MergeExercisesTx mocks base method.
+func (m *MockStore) MergeExercisesTx(arg0 context.Context, arg1 db.MergeExercisesTxParams) (db.MergeExercisesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeExercisesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MergeExercisesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeExercisesTx indicates an expected call of MergeExercisesTx.
+func (mr *MockStoreMockRecorder) MergeExercisesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeExercisesTx", reflect.TypeOf((*MockStore)(nil).MergeExercisesTx), arg0, arg1)
+}
+
+// OIDCLoginTx mocks base method.
+func (m *MockStore) OIDCLoginTx(arg0 context.Context, arg1 db.OIDCLoginTxParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCLoginTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCLoginTx indicates an expected call of OIDCLoginTx.
+func (mr *MockStoreMockRecorder) OIDCLoginTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCLoginTx", reflect.TypeOf((*MockStore)(nil).OIDCLoginTx), arg0, arg1)
+}
+
+// ReassignAliases mocks base method.
+func (m *MockStore) ReassignAliases(arg0 context.Context, arg1 db.ReassignAliasesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignAliases", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReassignAliases indicates an expected call of ReassignAliases.
+func (mr *MockStoreMockRecorder) ReassignAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignAliases", reflect.TypeOf((*MockStore)(nil).ReassignAliases), arg0, arg1)
+}
+
+// ReassignLifts mocks base method.
+func (m *MockStore) ReassignLifts(arg0 context.Context, arg1 db.ReassignLiftsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignLifts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignLifts indicates an expected call of ReassignLifts.
+func (mr *MockStoreMockRecorder) ReassignLifts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignLifts", reflect.TypeOf((*MockStore)(nil).ReassignLifts), arg0, arg1)
 }
 
-// ListByMuscleGroup mocks base method.
-func (m *MockStore) ListByMuscleGroup(arg0 context.Context, arg1 db.ListByMuscleGroupParams) ([]db.Exercise, error) {
+// RecordLoginFailure mocks base method.
+func (m *MockStore) RecordLoginFailure(arg0 context.Context, arg1 db.RecordLoginFailureParams) (db.LoginAttempt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByMuscleGroup", arg0, arg1)
-	ret0, _ := ret[0].([]db.Exercise)
+	ret := m.ctrl.Call(m, "RecordLoginFailure", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginAttempt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListByMuscleGroup indicates an expected call of ListByMuscleGroup.
-func (mr *MockStoreMockRecorder) ListByMuscleGroup(arg0, arg1 interface{}) *gomock.Call {
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockStoreMockRecorder) RecordLoginFailure(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMuscleGroup", reflect.TypeOf((*MockStore)(nil).ListByMuscleGroup), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockStore)(nil).RecordLoginFailure), arg0, arg1)
 }
 
-// ListCategories mocks base method.
-func (m *MockStore) ListCategories(arg0 context.Context) ([]db.Category, error) {
+// RepeatWorkoutTx mocks base method.
+func (m *MockStore) RepeatWorkoutTx(arg0 context.Context, arg1 db.RepeatWorkoutTxParams) (db.RepeatWorkoutTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", arg0)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "RepeatWorkoutTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RepeatWorkoutTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockStoreMockRecorder) ListCategories(arg0 interface{}) *gomock.Call {
+// RepeatWorkoutTx indicates an expected call of RepeatWorkoutTx.
+func (mr *MockStoreMockRecorder) RepeatWorkoutTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepeatWorkoutTx", reflect.TypeOf((*MockStore)(nil).RepeatWorkoutTx), arg0, arg1)
 }
 
-// ListExercises mocks base method.
-func (m *MockStore) ListExercises(arg0 context.Context, arg1 db.ListExercisesParams) ([]db.Exercise, error) {
+// ResetPasswordTx mocks base method.
+func (m *MockStore) ResetPasswordTx(arg0 context.Context, arg1 db.ResetPasswordTxParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExercises", arg0, arg1)
-	ret0, _ := ret[0].([]db.Exercise)
+	ret := m.ctrl.Call(m, "ResetPasswordTx", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExercises indicates an expected call of ListExercises.
-func (mr *MockStoreMockRecorder) ListExercises(arg0, arg1 interface{}) *gomock.Call {
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockStoreMockRecorder) ResetPasswordTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExercises", reflect.TypeOf((*MockStore)(nil).ListExercises), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockStore)(nil).ResetPasswordTx), arg0, arg1)
 }
 
-// ListLifts mocks base method.
-func (m *MockStore) ListLifts(arg0 context.Context, arg1 db.ListLiftsParams) ([]db.Lift, error) {
+// RevokeAPIKey mocks base method.
+func (m *MockStore) RevokeAPIKey(arg0 context.Context, arg1 db.RevokeAPIKeyParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLifts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Lift)
+	ret := m.ctrl.Call(m, "RevokeAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListLifts indicates an expected call of ListLifts.
-func (mr *MockStoreMockRecorder) ListLifts(arg0, arg1 interface{}) *gomock.Call {
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockStoreMockRecorder) RevokeAPIKey(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLifts", reflect.TypeOf((*MockStore)(nil).ListLifts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockStore)(nil).RevokeAPIKey), arg0, arg1)
 }
 
-// ListPRs mocks base method.
-func (m *MockStore) ListPRs(arg0 context.Context, arg1 db.ListPRsParams) ([]db.Lift, error) {
+// RevokeAPIKeys mocks base method.
+func (m *MockStore) RevokeAPIKeys(arg0 context.Context, arg1 db.RevokeAPIKeysParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPRs", arg0, arg1)
-	ret0, _ := ret[0].([]db.Lift)
+	ret := m.ctrl.Call(m, "RevokeAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPRs indicates an expected call of ListPRs.
-func (mr *MockStoreMockRecorder) ListPRs(arg0, arg1 interface{}) *gomock.Call {
+// RevokeAPIKeys indicates an expected call of RevokeAPIKeys.
+func (mr *MockStoreMockRecorder) RevokeAPIKeys(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRs", reflect.TypeOf((*MockStore)(nil).ListPRs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKeys", reflect.TypeOf((*MockStore)(nil).RevokeAPIKeys), arg0, arg1)
 }
 
-// ListPRsByExercise mocks base method.
-func (m *MockStore) ListPRsByExercise(arg0 context.Context, arg1 db.ListPRsByExerciseParams) ([]db.Lift, error) {
+// RevokeToken mocks base method.
+func (m *MockStore) RevokeToken(arg0 context.Context, arg1 db.RevokeTokenParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPRsByExercise", arg0, arg1)
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockStoreMockRecorder) RevokeToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockStore)(nil).RevokeToken), arg0, arg1)
+}
+
+// SearchExercises mocks base method.
+func (m *MockStore) SearchExercises(arg0 context.Context, arg1 db.SearchExercisesParams) ([]db.SearchExercisesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchExercises", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchExercisesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchExercises indicates an expected call of SearchExercises.
+func (mr *MockStoreMockRecorder) SearchExercises(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchExercises", reflect.TypeOf((*MockStore)(nil).SearchExercises), arg0, arg1)
+}
+
+// SearchWorkouts mocks base method.
+func (m *MockStore) SearchWorkouts(arg0 context.Context, arg1 db.SearchWorkoutsParams) ([]db.Workout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchWorkouts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Workout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchWorkouts indicates an expected call of SearchWorkouts.
+func (mr *MockStoreMockRecorder) SearchWorkouts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchWorkouts", reflect.TypeOf((*MockStore)(nil).SearchWorkouts), arg0, arg1)
+}
+
+// SeedCatalogTx mocks base method.
+func (m *MockStore) SeedCatalogTx(arg0 context.Context, arg1 db.SeedCatalogTxParams) (db.SeedCatalogTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedCatalogTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SeedCatalogTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeedCatalogTx indicates an expected call of SeedCatalogTx.
+func (mr *MockStoreMockRecorder) SeedCatalogTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedCatalogTx", reflect.TypeOf((*MockStore)(nil).SeedCatalogTx), arg0, arg1)
+}
+
+// SeedCategories mocks base method.
+func (m *MockStore) SeedCategories(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SeedCategories indicates an expected call of SeedCategories.
+func (mr *MockStoreMockRecorder) SeedCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedCategories", reflect.TypeOf((*MockStore)(nil).SeedCategories), arg0, arg1)
+}
+
+// SeedMuscleGroups mocks base method.
+func (m *MockStore) SeedMuscleGroups(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedMuscleGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SeedMuscleGroups indicates an expected call of SeedMuscleGroups.
+func (mr *MockStoreMockRecorder) SeedMuscleGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedMuscleGroups", reflect.TypeOf((*MockStore)(nil).SeedMuscleGroups), arg0, arg1)
+}
+
+// SetExerciseMuscleGroupsTx mocks base method.
+func (m *MockStore) SetExerciseMuscleGroupsTx(arg0 context.Context, arg1 db.SetExerciseMuscleGroupsTxParams) ([]db.ExerciseMuscleGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExerciseMuscleGroupsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExerciseMuscleGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExerciseMuscleGroupsTx indicates an expected call of SetExerciseMuscleGroupsTx.
+func (mr *MockStoreMockRecorder) SetExerciseMuscleGroupsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExerciseMuscleGroupsTx", reflect.TypeOf((*MockStore)(nil).SetExerciseMuscleGroupsTx), arg0, arg1)
+}
+
+// SwapWorkoutExercise mocks base method.
+func (m *MockStore) SwapWorkoutExercise(arg0 context.Context, arg1 db.SwapWorkoutExerciseParams) ([]db.Lift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapWorkoutExercise", arg0, arg1)
 	ret0, _ := ret[0].([]db.Lift)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPRsByExercise indicates an expected call of ListPRsByExercise.
-func (mr *MockStoreMockRecorder) ListPRsByExercise(arg0, arg1 interface{}) *gomock.Call {
+// SwapWorkoutExercise indicates an expected call of SwapWorkoutExercise.
+func (mr *MockStoreMockRecorder) SwapWorkoutExercise(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRsByExercise", reflect.TypeOf((*MockStore)(nil).ListPRsByExercise), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapWorkoutExercise", reflect.TypeOf((*MockStore)(nil).SwapWorkoutExercise), arg0, arg1)
 }
 
-// ListPRsByMuscleGroup mocks base method.
-func (m *MockStore) ListPRsByMuscleGroup(arg0 context.Context, arg1 db.ListPRsByMuscleGroupParams) ([]db.ListPRsByMuscleGroupRow, error) {
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockStore) UpdateAPIKeyLastUsed(arg0 context.Context, arg1 db.UpdateAPIKeyLastUsedParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPRsByMuscleGroup", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListPRsByMuscleGroupRow)
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockStoreMockRecorder) UpdateAPIKeyLastUsed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockStore)(nil).UpdateAPIKeyLastUsed), arg0, arg1)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockStore) UpdateAccount(arg0 context.Context, arg1 db.UpdateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPRsByMuscleGroup indicates an expected call of ListPRsByMuscleGroup.
-func (mr *MockStoreMockRecorder) ListPRsByMuscleGroup(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockStoreMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRsByMuscleGroup", reflect.TypeOf((*MockStore)(nil).ListPRsByMuscleGroup), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), arg0, arg1)
 }
 
-// ListWorkouts mocks base method.
-func (m *MockStore) ListWorkouts(arg0 context.Context, arg1 db.ListWorkoutsParams) ([]db.Workout, error) {
+// UpdateAccountTx mocks base method.
+func (m *MockStore) UpdateAccountTx(arg0 context.Context, arg1 db.UpdateAccountParams) (db.UpdateAccountTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWorkouts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Workout)
+	ret := m.ctrl.Call(m, "UpdateAccountTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateAccountTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWorkouts indicates an expected call of ListWorkouts.
-func (mr *MockStoreMockRecorder) ListWorkouts(arg0, arg1 interface{}) *gomock.Call {
+// UpdateAccountTx indicates an expected call of UpdateAccountTx.
+func (mr *MockStoreMockRecorder) UpdateAccountTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkouts", reflect.TypeOf((*MockStore)(nil).ListWorkouts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountTx", reflect.TypeOf((*MockStore)(nil).UpdateAccountTx), arg0, arg1)
 }
 
 // UpdateCategory mocks base method.
@@ -496,34 +1590,34 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0, arg1)
 }
 
-// UpdateExercise mocks base method.
-func (m *MockStore) UpdateExercise(arg0 context.Context, arg1 db.UpdateExerciseParams) (db.Exercise, error) {
+// UpdateEquipmentTx mocks base method.
+func (m *MockStore) UpdateEquipmentTx(arg0 context.Context, arg1 db.UpdateEquipmentTxParams) (db.UpdateEquipmentTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateExercise", arg0, arg1)
-	ret0, _ := ret[0].(db.Exercise)
+	ret := m.ctrl.Call(m, "UpdateEquipmentTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateEquipmentTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateExercise indicates an expected call of UpdateExercise.
-func (mr *MockStoreMockRecorder) UpdateExercise(arg0, arg1 interface{}) *gomock.Call {
+// UpdateEquipmentTx indicates an expected call of UpdateEquipmentTx.
+func (mr *MockStoreMockRecorder) UpdateEquipmentTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExercise", reflect.TypeOf((*MockStore)(nil).UpdateExercise), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEquipmentTx", reflect.TypeOf((*MockStore)(nil).UpdateEquipmentTx), arg0, arg1)
 }
 
-// UpdateFinishTime mocks base method.
-func (m *MockStore) UpdateFinishTime(arg0 context.Context, arg1 db.UpdateFinishTimeParams) (db.Workout, error) {
+// UpdateExercise mocks base method.
+func (m *MockStore) UpdateExercise(arg0 context.Context, arg1 db.UpdateExerciseParams) (db.Exercise, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFinishTime", arg0, arg1)
-	ret0, _ := ret[0].(db.Workout)
+	ret := m.ctrl.Call(m, "UpdateExercise", arg0, arg1)
+	ret0, _ := ret[0].(db.Exercise)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateFinishTime indicates an expected call of UpdateFinishTime.
-func (mr *MockStoreMockRecorder) UpdateFinishTime(arg0, arg1 interface{}) *gomock.Call {
+// UpdateExercise indicates an expected call of UpdateExercise.
+func (mr *MockStoreMockRecorder) UpdateExercise(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFinishTime", reflect.TypeOf((*MockStore)(nil).UpdateFinishTime), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExercise", reflect.TypeOf((*MockStore)(nil).UpdateExercise), arg0, arg1)
 }
 
 // UpdateGroup mocks base method.
@@ -541,6 +1635,21 @@ func (mr *MockStoreMockRecorder) UpdateGroup(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockStore)(nil).UpdateGroup), arg0, arg1)
 }
 
+// UpdateGymProfile mocks base method.
+func (m *MockStore) UpdateGymProfile(arg0 context.Context, arg1 db.UpdateGymProfileParams) (db.GymProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGymProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.GymProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGymProfile indicates an expected call of UpdateGymProfile.
+func (mr *MockStoreMockRecorder) UpdateGymProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGymProfile", reflect.TypeOf((*MockStore)(nil).UpdateGymProfile), arg0, arg1)
+}
+
 // UpdateLift mocks base method.
 func (m *MockStore) UpdateLift(arg0 context.Context, arg1 db.UpdateLiftParams) (db.Lift, error) {
 	m.ctrl.T.Helper()
@@ -556,6 +1665,35 @@ func (mr *MockStoreMockRecorder) UpdateLift(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLift", reflect.TypeOf((*MockStore)(nil).UpdateLift), arg0, arg1)
 }
 
+// UpdateMFALastUsedStep mocks base method.
+func (m *MockStore) UpdateMFALastUsedStep(arg0 context.Context, arg1 db.UpdateMFALastUsedStepParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMFALastUsedStep", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMFALastUsedStep indicates an expected call of UpdateMFALastUsedStep.
+func (mr *MockStoreMockRecorder) UpdateMFALastUsedStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFALastUsedStep", reflect.TypeOf((*MockStore)(nil).UpdateMFALastUsedStep), arg0, arg1)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockStore) UpdatePassword(arg0 context.Context, arg1 db.UpdatePasswordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockStoreMockRecorder) UpdatePassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockStore)(nil).UpdatePassword), arg0, arg1)
+}
+
 // UpdateWeight mocks base method.
 func (m *MockStore) UpdateWeight(arg0 context.Context, arg1 db.UpdateWeightParams) error {
 	m.ctrl.T.Helper()
@@ -569,3 +1707,165 @@ func (mr *MockStoreMockRecorder) UpdateWeight(arg0, arg1 interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWeight", reflect.TypeOf((*MockStore)(nil).UpdateWeight), arg0, arg1)
 }
+
+// UpdateWorkout mocks base method.
+func (m *MockStore) UpdateWorkout(arg0 context.Context, arg1 db.UpdateWorkoutParams) (db.Workout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkout", arg0, arg1)
+	ret0, _ := ret[0].(db.Workout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkout indicates an expected call of UpdateWorkout.
+func (mr *MockStoreMockRecorder) UpdateWorkout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkout", reflect.TypeOf((*MockStore)(nil).UpdateWorkout), arg0, arg1)
+}
+
+// UpsertAccountMFA mocks base method.
+func (m *MockStore) UpsertAccountMFA(arg0 context.Context, arg1 db.UpsertAccountMFAParams) (db.AccountMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAccountMFA", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertAccountMFA indicates an expected call of UpsertAccountMFA.
+func (mr *MockStoreMockRecorder) UpsertAccountMFA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAccountMFA", reflect.TypeOf((*MockStore)(nil).UpsertAccountMFA), arg0, arg1)
+}
+
+// UpsertCatalogExercise mocks base method.
+func (m *MockStore) UpsertCatalogExercise(arg0 context.Context, arg1 db.UpsertCatalogExerciseParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCatalogExercise", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCatalogExercise indicates an expected call of UpsertCatalogExercise.
+func (mr *MockStoreMockRecorder) UpsertCatalogExercise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCatalogExercise", reflect.TypeOf((*MockStore)(nil).UpsertCatalogExercise), arg0, arg1)
+}
+
+// UpsertEquipmentSettings mocks base method.
+func (m *MockStore) UpsertEquipmentSettings(arg0 context.Context, arg1 db.UpsertEquipmentSettingsParams) (db.EquipmentSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEquipmentSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.EquipmentSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertEquipmentSettings indicates an expected call of UpsertEquipmentSettings.
+func (mr *MockStoreMockRecorder) UpsertEquipmentSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEquipmentSettings", reflect.TypeOf((*MockStore)(nil).UpsertEquipmentSettings), arg0, arg1)
+}
+
+// UpsertTokenCutoff mocks base method.
+func (m *MockStore) UpsertTokenCutoff(arg0 context.Context, arg1 db.UpsertTokenCutoffParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTokenCutoff", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertTokenCutoff indicates an expected call of UpsertTokenCutoff.
+func (mr *MockStoreMockRecorder) UpsertTokenCutoff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTokenCutoff", reflect.TypeOf((*MockStore)(nil).UpsertTokenCutoff), arg0, arg1)
+}
+
+// UseEmailVerification mocks base method.
+func (m *MockStore) UseEmailVerification(arg0 context.Context, arg1 db.UseEmailVerificationParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseEmailVerification indicates an expected call of UseEmailVerification.
+func (mr *MockStoreMockRecorder) UseEmailVerification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailVerification", reflect.TypeOf((*MockStore)(nil).UseEmailVerification), arg0, arg1)
+}
+
+// UseOIDCLogin mocks base method.
+func (m *MockStore) UseOIDCLogin(arg0 context.Context, arg1 db.UseOIDCLoginParams) (db.OidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseOIDCLogin", arg0, arg1)
+	ret0, _ := ret[0].(db.OidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseOIDCLogin indicates an expected call of UseOIDCLogin.
+func (mr *MockStoreMockRecorder) UseOIDCLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseOIDCLogin", reflect.TypeOf((*MockStore)(nil).UseOIDCLogin), arg0, arg1)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockStore) UsePasswordReset(arg0 context.Context, arg1 db.UsePasswordResetParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockStoreMockRecorder) UsePasswordReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockStore)(nil).UsePasswordReset), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockStore) UseRecoveryCode(arg0 context.Context, arg1 db.UseRecoveryCodeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockStoreMockRecorder) UseRecoveryCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseRecoveryCode), arg0, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockStore) VerifyEmail(arg0 context.Context, arg1 db.VerifyEmailParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockStoreMockRecorder) VerifyEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockStore)(nil).VerifyEmail), arg0, arg1)
+}
+
+// VerifyEmailTx mocks base method.
+func (m *MockStore) VerifyEmailTx(arg0 context.Context, arg1 db.VerifyEmailTxParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailTx", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailTx indicates an expected call of VerifyEmailTx.
+func (mr *MockStoreMockRecorder) VerifyEmailTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailTx", reflect.TypeOf((*MockStore)(nil).VerifyEmailTx), arg0, arg1)
+}
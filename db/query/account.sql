@@ -20,9 +20,44 @@ accounts WHERE email = $1 LIMIT 1;
 
 -- name: ListAccounts :many
 SELECT * FROM accounts
-WHERE id = $1
-LIMIT $2
-OFFSET $3;
+WHERE id = sqlc.arg('id')
+AND (sqlc.narg('after_id')::UUID IS NULL OR id > sqlc.narg('after_id')::UUID)
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetEmailVerifiedAt :one
+SELECT email_verified_at FROM accounts
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPasswordChangedAt :one
+SELECT password_changed_at FROM accounts
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdatePassword :exec
+UPDATE accounts SET
+password = $1,
+password_changed_at = $2
+WHERE id = $3;
+
+-- name: VerifyEmail :exec
+UPDATE accounts SET
+email_verified_at = $1
+WHERE id = $2 AND email_verified_at IS NULL;
+
+-- name: UpdateAccount :one
+UPDATE accounts SET
+name = COALESCE(sqlc.narg('name'), name),
+email = COALESCE(sqlc.narg('email'), email),
+email_verified_at = CASE WHEN sqlc.narg('email') IS NULL OR sqlc.narg('email') = email THEN email_verified_at END,
+weight = COALESCE(sqlc.narg('weight'), weight),
+body_fat = COALESCE(sqlc.narg('body_fat'), body_fat),
+start_date = COALESCE(sqlc.narg('start_date'), start_date),
+date_of_birth = COALESCE(sqlc.narg('date_of_birth'), date_of_birth),
+height = COALESCE(sqlc.narg('height'), height),
+preferences = jsonb_strip_nulls(preferences || sqlc.arg('preferences')::jsonb)
+WHERE id = sqlc.arg('id')
+RETURNING *;
 
 -- name: UpdateWeight :exec
 UPDATE accounts SET
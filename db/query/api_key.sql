@@ -0,0 +1,34 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_key (
+  user_id,
+  name,
+  prefix,
+  key_hash,
+  scopes
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetAPIKeyByHash :one
+SELECT * FROM api_key
+WHERE key_hash = $1 AND revoked_at IS NULL LIMIT 1;
+
+-- name: ListAPIKeys :many
+SELECT * FROM api_key
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: UpdateAPIKeyLastUsed :exec
+UPDATE api_key SET
+last_used_at = $1
+WHERE id = $2;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_key SET
+revoked_at = $1
+WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL;
+
+-- name: RevokeAPIKeys :execrows
+UPDATE api_key SET
+revoked_at = $1
+WHERE user_id = $2 AND revoked_at IS NULL;
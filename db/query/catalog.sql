@@ -0,0 +1,40 @@
+-- name: LockCatalog :exec
+SELECT pg_advisory_xact_lock(hashtext('catalog'));
+
+-- name: GetCatalogVersion :one
+SELECT COALESCE(MAX(version), 0)::INT AS version FROM catalog_version;
+
+-- name: CreateCatalogVersion :exec
+INSERT INTO catalog_version (version) VALUES ($1);
+
+-- name: SeedMuscleGroups :exec
+INSERT INTO muscle_group (name)
+SELECT UNNEST(sqlc.arg('names')::VARCHAR[])
+ON CONFLICT (name) DO NOTHING;
+
+-- name: SeedCategories :exec
+INSERT INTO category (name)
+SELECT UNNEST(sqlc.arg('names')::VARCHAR[])
+ON CONFLICT (name) DO NOTHING;
+
+-- name: GetCatalogExercise :one
+SELECT * FROM catalog_exercise
+WHERE name = $1 LIMIT 1;
+
+-- name: UpsertCatalogExercise :exec
+INSERT INTO catalog_exercise (
+  name,
+  muscle_group,
+  category,
+  equipment,
+  movement_pattern,
+  version
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (name) DO UPDATE SET
+muscle_group = EXCLUDED.muscle_group,
+category = EXCLUDED.category,
+equipment = EXCLUDED.equipment,
+movement_pattern = EXCLUDED.movement_pattern,
+version = EXCLUDED.version;
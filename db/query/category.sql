@@ -8,6 +8,10 @@ RETURNING *;
 SELECT * FROM category
 WHERE id = $1 LIMIT 1;
 
+-- name: GetCategoryBySlug :one
+SELECT * FROM category
+WHERE slug = $1 LIMIT 1;
+
 -- name: ListCategories :many
 SELECT * FROM category;
 
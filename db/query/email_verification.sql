@@ -0,0 +1,26 @@
+-- name: CreateEmailVerification :one
+INSERT INTO email_verification (
+  token_hash,
+  user_id,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetLatestEmailVerification :one
+SELECT * FROM email_verification
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UseEmailVerification :one
+UPDATE email_verification SET
+used_at = sqlc.arg(now)::timestamp
+WHERE token_hash = sqlc.arg(token_hash)
+AND used_at IS NULL
+AND expires_at > sqlc.arg(now)::timestamp
+RETURNING user_id;
+
+-- name: DeleteEmailVerifications :exec
+DELETE FROM email_verification
+WHERE user_id = $1 AND used_at IS NULL;
@@ -0,0 +1,34 @@
+-- name: UpsertEquipmentSettings :one
+INSERT INTO equipment_settings (
+  user_id,
+  bar_weight
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE SET
+bar_weight = EXCLUDED.bar_weight
+RETURNING *;
+
+-- name: GetEquipmentSettings :one
+SELECT * FROM equipment_settings
+WHERE user_id = $1 LIMIT 1;
+
+-- name: CreatePlates :many
+INSERT INTO plate_inventory (
+  user_id,
+  weight,
+  pairs
+) VALUES (
+  UNNEST(@user_id::UUID[]),
+  UNNEST(@weights::REAL[]),
+  UNNEST(@pairs::SMALLINT[])
+)
+RETURNING *;
+
+-- name: ListPlates :many
+SELECT * FROM plate_inventory
+WHERE user_id = $1
+ORDER BY weight DESC;
+
+-- name: DeletePlates :exec
+DELETE FROM plate_inventory WHERE user_id = $1;
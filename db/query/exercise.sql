@@ -2,34 +2,115 @@
 INSERT INTO exercise (
   name,
   muscle_group,
-  category
+  category,
+  equipment,
+  movement_pattern
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetExercise :one
 SELECT * FROM exercise
 WHERE name = ($1) LIMIT 1;
 
+-- name: GetExerciseByID :one
+SELECT * FROM exercise
+WHERE id = $1 LIMIT 1;
+
+-- name: GetExerciseBySlug :one
+SELECT * FROM exercise
+WHERE slug = $1 LIMIT 1;
+
 -- name: ListExercises :many
 SELECT * FROM exercise
-ORDER BY name 
-LIMIT $1
-OFFSET $2;
+WHERE (NOT archived OR sqlc.arg('include_archived')::BOOLEAN)
+AND (cardinality(sqlc.arg('equipment')::VARCHAR[]) = 0 OR equipment = ANY(sqlc.arg('equipment')::VARCHAR[]))
+AND (sqlc.narg('after_name')::VARCHAR IS NULL OR name > sqlc.narg('after_name')::VARCHAR)
+ORDER BY name
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListExerciseNames :many
+SELECT id, name FROM exercise
+ORDER BY name;
+
+-- name: ListUserExercises :many
+SELECT * FROM exercise
+WHERE id IN (SELECT exercise_id FROM lift WHERE user_id = sqlc.arg('user_id'))
+AND id > sqlc.arg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('limit');
 
 -- name: ListByMuscleGroup :many
-SELECT * FROM exercise 
-WHERE muscle_group = ($1)
-ORDER BY name
-LIMIT $2
-OFFSET $3;
+SELECT e.* FROM exercise AS e
+JOIN exercise_muscle_group AS emg ON emg.exercise_name = e.name
+WHERE emg.muscle_group = sqlc.arg('muscle_group')
+AND NOT e.archived
+AND (sqlc.narg('after_name')::VARCHAR IS NULL OR (NOT emg.is_primary, e.name) > (NOT sqlc.arg('after_primary')::BOOLEAN, sqlc.narg('after_name')::VARCHAR))
+ORDER BY emg.is_primary DESC, e.name
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: SearchExercises :many
+WITH ranked AS (
+  SELECT e.id, e.name, e.muscle_group, e.category, e.archived, e.equipment, e.movement_pattern, e.slug,
+  GREATEST(
+    word_similarity(sqlc.arg('query')::TEXT, e.name),
+    COALESCE(MAX(word_similarity(sqlc.arg('query')::TEXT, a.alias)), 0)
+  )::REAL AS score
+  FROM exercise AS e
+  LEFT JOIN exercise_alias AS a ON a.exercise_name = e.name
+  WHERE NOT e.archived
+  GROUP BY e.id
+)
+SELECT r.id, r.name, r.muscle_group, r.category, r.archived, r.equipment, r.movement_pattern, r.slug, r.score,
+COUNT(l.id) AS times_performed
+FROM ranked AS r
+LEFT JOIN lift AS l ON l.exercise_id = r.id AND l.user_id = sqlc.arg('user_id')
+WHERE r.score >= 0.3
+GROUP BY r.id, r.name, r.muscle_group, r.category, r.archived, r.equipment, r.movement_pattern, r.slug, r.score
+ORDER BY r.score + 0.1 * LN(1 + COUNT(l.id)) DESC, r.name
+LIMIT sqlc.arg('limit');
+
+-- name: ListExerciseSubstitutes :many
+WITH target AS (
+  SELECT name, equipment, movement_pattern FROM exercise
+  WHERE name = sqlc.arg('name')
+), shared AS (
+  SELECT o.exercise_name, SUM(LEAST(t.contribution, o.contribution)) AS overlap
+  FROM exercise_muscle_group AS t
+  JOIN exercise_muscle_group AS o ON o.muscle_group = t.muscle_group
+  WHERE t.exercise_name = sqlc.arg('name')
+  AND o.exercise_name <> sqlc.arg('name')
+  GROUP BY o.exercise_name
+)
+SELECT e.*,
+(
+  s.overlap
+  + CASE WHEN e.movement_pattern <> '' AND e.movement_pattern = t.movement_pattern THEN 0.5 ELSE 0 END
+  + CASE WHEN e.equipment = t.equipment THEN 0.1 ELSE 0 END
+)::REAL AS score
+FROM shared AS s
+JOIN exercise AS e ON e.name = s.exercise_name
+CROSS JOIN target AS t
+WHERE NOT e.archived
+AND (cardinality(sqlc.arg('equipment')::VARCHAR[]) = 0 OR e.equipment = ANY(sqlc.arg('equipment')::VARCHAR[]))
+ORDER BY score DESC, e.name
+LIMIT sqlc.arg('limit');
 
 -- name: UpdateExercise :one
 UPDATE exercise SET
 name = COALESCE(NULLIF($1, ''), name),
 muscle_group = COALESCE(NULLIF($2, ''), muscle_group),
-category = COALESCE(NULLIF($3, ''), category)
-WHERE name = $4
+category = COALESCE(NULLIF($3, ''), category),
+equipment = COALESCE(NULLIF($4, ''), equipment),
+movement_pattern = COALESCE(NULLIF($5, ''), movement_pattern)
+WHERE name = $6
+RETURNING *;
+
+-- name: ArchiveExercise :one
+UPDATE exercise SET archived = $1
+WHERE name = $2
 RETURNING *;
 
 -- name: DeleteExercise :exec
@@ -0,0 +1,20 @@
+-- name: CreateExerciseAlias :one
+INSERT INTO exercise_alias (
+  exercise_name,
+  alias
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: ListExerciseAliases :many
+SELECT * FROM exercise_alias
+WHERE exercise_name = $1
+ORDER BY alias;
+
+-- name: ReassignAliases :exec
+UPDATE exercise_alias SET exercise_name = sqlc.arg('target')
+WHERE exercise_name = sqlc.arg('source');
+
+-- name: DeleteExerciseAlias :execrows
+DELETE FROM exercise_alias
+WHERE exercise_name = sqlc.arg('exercise_name') AND LOWER(alias) = LOWER(sqlc.arg('alias'));
@@ -0,0 +1,36 @@
+-- name: ListExerciseMuscleGroups :many
+SELECT * FROM exercise_muscle_group
+WHERE exercise_name = $1
+ORDER BY is_primary DESC, contribution DESC, muscle_group;
+
+-- name: CreateSecondaryMuscleGroups :many
+INSERT INTO exercise_muscle_group (
+  exercise_name,
+  muscle_group,
+  is_primary,
+  contribution
+) VALUES (
+  sqlc.arg('exercise_name'),
+  UNNEST(@muscle_groups::VARCHAR[]),
+  false,
+  UNNEST(@contributions::REAL[])
+)
+RETURNING *;
+
+-- name: DeleteSecondaryMuscleGroups :exec
+DELETE FROM exercise_muscle_group
+WHERE exercise_name = $1 AND NOT is_primary;
+
+-- name: ListMuscleGroupVolume :many
+SELECT emg.muscle_group,
+SUM(l.weight_lifted * l.reps * emg.contribution)::REAL AS volume,
+SUM(emg.contribution)::REAL AS sets
+FROM lift AS l
+JOIN workout AS w ON w.id = l.workout_id
+JOIN exercise AS e ON e.id = l.exercise_id
+JOIN exercise_muscle_group AS emg ON emg.exercise_name = e.name
+WHERE l.user_id = sqlc.arg('user_id')
+AND w.start_time >= sqlc.arg('start_time')
+AND w.start_time < sqlc.arg('end_time')
+GROUP BY emg.muscle_group
+ORDER BY volume DESC;
@@ -0,0 +1,28 @@
+-- name: CreateGymProfile :one
+INSERT INTO gym_profile (
+  user_id,
+  name,
+  equipment
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetGymProfile :one
+SELECT * FROM gym_profile
+WHERE id = $1 LIMIT 1;
+
+-- name: ListGymProfiles :many
+SELECT * FROM gym_profile
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: UpdateGymProfile :one
+UPDATE gym_profile SET
+name = $1,
+equipment = $2
+WHERE id = $3 AND user_id = $4
+RETURNING *;
+
+-- name: DeleteGymProfile :execrows
+DELETE FROM gym_profile
+WHERE id = $1 AND user_id = $2;
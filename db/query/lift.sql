@@ -1,28 +1,31 @@
 -- name: CreateLift :one
 INSERT INTO lift (
-  exercise_name,
+  exercise_id,
   weight_lifted,
   reps,
   user_id,
-  workout_id
+  workout_id,
+  notes
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
 -- name: CreateLifts :many
 INSERT INTO lift (
-  exercise_name,
+  exercise_id,
   weight_lifted,
   reps,
   user_id,
-  workout_id
+  workout_id,
+  notes
 ) VALUES (
-  UNNEST(@exerciseNames::VARCHAR[]),
+  UNNEST(@exercise_ids::INT[]),
   UNNEST(@weights::REAL[]),
   UNNEST(@reps::SMALLINT[]),
   UNNEST(@user_id::UUID[]),
-  UNNEST(@workout_id::UUID[])
+  UNNEST(@workout_id::UUID[]),
+  UNNEST(@notes::TEXT[])
 )
 RETURNING *;
 
@@ -35,52 +38,69 @@ AND id = $2
 LIMIT 1;
 
 -- name: ListLifts :many
+SELECT l.*, e.name AS exercise_name FROM lift AS l
+JOIN exercise AS e ON e.id = l.exercise_id
+WHERE l.user_id = sqlc.arg('user_id')
+AND (sqlc.narg('after_id')::UUID IS NULL OR (e.name, l.id) > (sqlc.arg('after_name')::VARCHAR, sqlc.narg('after_id')::UUID))
+ORDER BY e.name, l.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListWorkoutLifts :many
 SELECT * FROM lift
-WHERE user_id = $1
-ORDER BY exercise_name 
-LIMIT $2
-OFFSET $3;
+WHERE workout_id = $1
+ORDER BY seq;
 
 -- name: ListPRs :many
 SELECT * FROM lift
-WHERE user_id = $1
-ORDER BY
-  CASE
-    WHEN $2 = 'weight' THEN weight_lifted
-    WHEN $3 = 'reps' THEN reps
-END DESC
-LIMIT $4
-OFFSET $5;
+WHERE user_id = sqlc.arg('user_id')
+AND (sqlc.narg('after_id')::UUID IS NULL OR (
+  CASE WHEN sqlc.arg('order_by')::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END, id
+) < (sqlc.arg('after_value')::REAL, sqlc.narg('after_id')::UUID))
+ORDER BY CASE WHEN sqlc.arg('order_by')::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: ListPRsByExercise :many
 SELECT * FROM lift
-WHERE user_id = $1 AND exercise_name = $2
-ORDER BY
-  CASE
-    WHEN $3 = 'weight' THEN weight_lifted
-    WHEN $4 = 'reps' THEN reps 
-END DESC
-LIMIT $5
-OFFSET $6;
+WHERE user_id = sqlc.arg('user_id') AND exercise_id = sqlc.arg('exercise_id')
+AND (sqlc.narg('after_id')::UUID IS NULL OR (
+  CASE WHEN sqlc.arg('order_by')::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END, id
+) < (sqlc.arg('after_value')::REAL, sqlc.narg('after_id')::UUID))
+ORDER BY CASE WHEN sqlc.arg('order_by')::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: ListPRsByMuscleGroup :many
-SELECT l.id, l.exercise_name, weight_lifted, reps, ex.muscle_group FROM lift AS l
-JOIN exercise AS ex on l.exercise_name = ex.name
-WHERE ex.muscle_group = $1
-AND l.user_id = $2
-ORDER BY
-  CASE
-    WHEN $3 = 'weight' THEN weight_lifted
-    WHEN $4 = 'reps' THEN reps
-END DESC
-LIMIT $5
-OFFSET $6;
+SELECT l.id, l.exercise_id, e.name AS exercise_name, weight_lifted, reps, emg.muscle_group, emg.is_primary, emg.contribution FROM lift AS l
+JOIN exercise AS e ON e.id = l.exercise_id
+JOIN exercise_muscle_group AS emg on e.name = emg.exercise_name
+WHERE emg.muscle_group = sqlc.arg('muscle_group')
+AND l.user_id = sqlc.arg('user_id')
+AND (sqlc.narg('after_id')::UUID IS NULL OR (
+  CASE WHEN sqlc.arg('order_by')::TEXT = 'reps' THEN l.reps::REAL ELSE l.weight_lifted END, l.id
+) < (sqlc.arg('after_value')::REAL, sqlc.narg('after_id')::UUID))
+ORDER BY CASE WHEN sqlc.arg('order_by')::TEXT = 'reps' THEN l.reps::REAL ELSE l.weight_lifted END DESC, l.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateLift :one
 UPDATE lift SET
-weight_lifted = COALESCE(NULLIF($1, 0::REAL), weight_lifted),
-reps = COALESCE(NULLIF($2, 0::SMALLINT), reps)
-WHERE id = $3
+weight_lifted = COALESCE(sqlc.narg('weight_lifted'), weight_lifted),
+reps = COALESCE(sqlc.narg('reps'), reps),
+notes = COALESCE(sqlc.narg('notes'), notes)
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: ReassignLifts :execrows
+UPDATE lift SET exercise_id = sqlc.arg('target')
+WHERE exercise_id = sqlc.arg('source');
+
+-- name: SwapWorkoutExercise :many
+UPDATE lift SET exercise_id = sqlc.arg('target')
+WHERE workout_id = sqlc.arg('workout_id')
+AND user_id = sqlc.arg('user_id')
+AND exercise_id = sqlc.arg('source')
 RETURNING *;
 
 -- name: DeleteLift :exec
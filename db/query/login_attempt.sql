@@ -0,0 +1,24 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempt
+WHERE key = $1 LIMIT 1;
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_attempt (
+  key,
+  failures,
+  last_failure,
+  locked_until
+) VALUES (
+  sqlc.arg('key'), 1, sqlc.arg('now'), sqlc.arg('now')
+) ON CONFLICT (key) DO UPDATE SET
+failures = CASE WHEN login_attempt.last_failure <= sqlc.arg('reset_before') THEN 1 ELSE login_attempt.failures + 1 END,
+last_failure = EXCLUDED.last_failure
+RETURNING *;
+
+-- name: LockLoginAttempt :exec
+UPDATE login_attempt SET
+locked_until = GREATEST(locked_until, sqlc.arg('locked_until'))
+WHERE key = sqlc.arg('key');
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempt WHERE key = $1;
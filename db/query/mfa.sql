@@ -0,0 +1,45 @@
+-- name: UpsertAccountMFA :one
+INSERT INTO account_mfa (
+  user_id,
+  secret
+) VALUES (
+  $1, $2
+) ON CONFLICT (user_id) DO UPDATE SET
+secret = EXCLUDED.secret,
+confirmed_at = NULL,
+last_used_step = 0,
+created_at = NOW()
+RETURNING *;
+
+-- name: GetAccountMFA :one
+SELECT * FROM account_mfa
+WHERE user_id = $1 LIMIT 1;
+
+-- name: ConfirmAccountMFA :exec
+UPDATE account_mfa SET
+confirmed_at = $1,
+last_used_step = $2
+WHERE user_id = $3;
+
+-- name: UpdateMFALastUsedStep :execrows
+UPDATE account_mfa SET
+last_used_step = sqlc.arg(step)
+WHERE user_id = sqlc.arg(user_id) AND last_used_step < sqlc.arg(step);
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO mfa_recovery_code (
+  code_hash,
+  user_id
+) VALUES (
+  $1, $2
+);
+
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_code SET
+used_at = sqlc.arg(now)::timestamp
+WHERE code_hash = sqlc.arg(code_hash)
+AND user_id = sqlc.arg(user_id)
+AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_code WHERE user_id = $1;
@@ -10,12 +10,20 @@ RETURNING *;
 SELECT * FROM muscle_group
 WHERE name = $1;
 
+-- name: GetMuscleGroupByID :one
+SELECT * FROM muscle_group
+WHERE id = $1;
+
+-- name: GetMuscleGroupBySlug :one
+SELECT * FROM muscle_group
+WHERE slug = $1;
+
 -- name: GetMuscleGroups :many
 SELECT * FROM muscle_group
 ORDER BY name;
 
 -- name: UpdateGroup :one
-UPDATE muscle_group SET name = $1 WHERE name = $2 RETURNING *;
+UPDATE muscle_group SET name = $1 WHERE id = $2 RETURNING *;
 
 -- name: DeleteGroup :one
-DELETE FROM muscle_group WHERE name = $1 RETURNING *;
+DELETE FROM muscle_group WHERE id = $1 RETURNING *;
@@ -0,0 +1,34 @@
+-- name: CreateOIDCLogin :exec
+INSERT INTO oidc_login (
+  state_hash,
+  provider,
+  code_verifier,
+  nonce,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: UseOIDCLogin :one
+DELETE FROM oidc_login
+WHERE state_hash = sqlc.arg(state_hash)
+AND expires_at > sqlc.arg(now)::timestamp
+RETURNING *;
+
+-- name: DeleteExpiredOIDCLogins :execrows
+DELETE FROM oidc_login
+WHERE expires_at <= $1;
+
+-- name: GetAccountIdentity :one
+SELECT * FROM account_identity
+WHERE provider = $1 AND subject = $2 LIMIT 1;
+
+-- name: CreateAccountIdentity :one
+INSERT INTO account_identity (
+  provider,
+  subject,
+  user_id,
+  email
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
@@ -0,0 +1,20 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_reset (
+  token_hash,
+  user_id,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: UsePasswordReset :one
+UPDATE password_reset SET
+used_at = sqlc.arg(now)::timestamp
+WHERE token_hash = sqlc.arg(token_hash)
+AND used_at IS NULL
+AND expires_at > sqlc.arg(now)::timestamp
+RETURNING user_id;
+
+-- name: DeletePasswordResets :exec
+DELETE FROM password_reset
+WHERE user_id = $1 AND used_at IS NULL;
@@ -0,0 +1,27 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_token (
+  token_id,
+  user_id,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (token_id) DO NOTHING;
+
+-- name: UpsertTokenCutoff :exec
+INSERT INTO token_cutoff (
+  user_id,
+  revoked_before
+) VALUES (
+  $1, $2
+) ON CONFLICT (user_id) DO UPDATE SET
+revoked_before = GREATEST(token_cutoff.revoked_before, EXCLUDED.revoked_before);
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS (
+  SELECT 1 FROM revoked_token WHERE token_id = $1
+) OR EXISTS (
+  SELECT 1 FROM token_cutoff WHERE user_id = $2 AND revoked_before > $3
+) AS revoked;
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_token WHERE expires_at <= $1;
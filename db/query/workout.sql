@@ -4,23 +4,42 @@ VALUES ($1, $2)
 RETURNING *;
 
 -- name: GetWorkout :many
-SELECT w.id, exercise_name, weight_lifted, reps, start_time, finish_time, l.user_id
+SELECT w.id, l.exercise_id, e.name AS exercise_name, weight_lifted, reps, start_time, finish_time, l.user_id,
+w.notes, w.rating, w.energy, l.notes AS lift_notes
 FROM workout AS w
 JOIN lift AS l ON l.workout_id = w.id
+JOIN exercise AS e ON e.id = l.exercise_id
 WHERE w.id = $1;
 
--- name: UpdateFinishTime :one
+-- name: UpdateWorkout :one
 UPDATE workout SET
-finish_time = $1
-WHERE id = $2
+finish_time = COALESCE(sqlc.narg('finish_time'), finish_time),
+notes = COALESCE(sqlc.narg('notes'), notes),
+rating = COALESCE(sqlc.narg('rating'), rating),
+energy = COALESCE(sqlc.narg('energy'), energy)
+WHERE id = sqlc.arg('id')
 RETURNING *;
 
 -- name: ListWorkouts :many
 SELECT * FROM workout
-WHERE user_id = $1
-ORDER BY start_time DESC
-LIMIT $2
-OFFSET $3;
+WHERE user_id = sqlc.arg('user_id')
+AND (sqlc.narg('after_id')::UUID IS NULL OR (start_time, id) < (sqlc.arg('after_start_time')::TIMESTAMP, sqlc.narg('after_id')::UUID))
+ORDER BY start_time DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: SearchWorkouts :many
+SELECT DISTINCT w.* FROM workout AS w
+LEFT JOIN lift AS l ON l.workout_id = w.id
+WHERE w.user_id = sqlc.arg('user_id')
+AND (
+  to_tsvector('english', w.notes) @@ plainto_tsquery('english', sqlc.arg('query'))
+  OR to_tsvector('english', l.notes) @@ plainto_tsquery('english', sqlc.arg('query'))
+)
+AND (sqlc.narg('after_id')::UUID IS NULL OR (w.start_time, w.id) < (sqlc.arg('after_start_time')::TIMESTAMP, sqlc.narg('after_id')::UUID))
+ORDER BY w.start_time DESC, w.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: DeleteWorkout :exec
 DELETE FROM workout
@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,7 +24,7 @@ INSERT INTO accounts (
 ) VALUES (
   $1, $2, $3, $4, $5
 )
-RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date
+RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date, email_verified_at, date_of_birth, height, preferences
 `
 
 type CreateAccountParams struct {
@@ -51,12 +53,16 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Weight,
 		&i.BodyFat,
 		&i.StartDate,
+		&i.EmailVerifiedAt,
+		&i.DateOfBirth,
+		&i.Height,
+		&i.Preferences,
 	)
 	return i, err
 }
 
 const deleteAccount = `-- name: DeleteAccount :one
-DELETE FROM accounts WHERE id = $1 RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date
+DELETE FROM accounts WHERE id = $1 RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date, email_verified_at, date_of_birth, height, preferences
 `
 
 func (q *Queries) DeleteAccount(ctx context.Context, id uuid.UUID) (Account, error) {
@@ -71,12 +77,16 @@ func (q *Queries) DeleteAccount(ctx context.Context, id uuid.UUID) (Account, err
 		&i.Weight,
 		&i.BodyFat,
 		&i.StartDate,
+		&i.EmailVerifiedAt,
+		&i.DateOfBirth,
+		&i.Height,
+		&i.Preferences,
 	)
 	return i, err
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, name, email, password, password_changed_at, weight, body_fat, start_date FROM accounts
+SELECT id, name, email, password, password_changed_at, weight, body_fat, start_date, email_verified_at, date_of_birth, height, preferences FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -92,6 +102,10 @@ func (q *Queries) GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
 		&i.Weight,
 		&i.BodyFat,
 		&i.StartDate,
+		&i.EmailVerifiedAt,
+		&i.DateOfBirth,
+		&i.Height,
+		&i.Preferences,
 	)
 	return i, err
 }
@@ -122,21 +136,53 @@ func (q *Queries) GetAccountByEmail(ctx context.Context, email string) (GetAccou
 	return i, err
 }
 
+const getEmailVerifiedAt = `-- name: GetEmailVerifiedAt :one
+SELECT email_verified_at FROM accounts
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetEmailVerifiedAt(ctx context.Context, id uuid.UUID) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, getEmailVerifiedAt, id)
+	var email_verified_at sql.NullTime
+	err := row.Scan(&email_verified_at)
+	return email_verified_at, err
+}
+
+const getPasswordChangedAt = `-- name: GetPasswordChangedAt :one
+SELECT password_changed_at FROM accounts
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPasswordChangedAt(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getPasswordChangedAt, id)
+	var password_changed_at time.Time
+	err := row.Scan(&password_changed_at)
+	return password_changed_at, err
+}
+
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, name, email, password, password_changed_at, weight, body_fat, start_date FROM accounts
+SELECT id, name, email, password, password_changed_at, weight, body_fat, start_date, email_verified_at, date_of_birth, height, preferences FROM accounts
 WHERE id = $1
-LIMIT $2
-OFFSET $3
+AND ($2::UUID IS NULL OR id > $2::UUID)
+ORDER BY id
+LIMIT $3
+OFFSET $4
 `
 
 type ListAccountsParams struct {
-	ID     uuid.UUID `json:"id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	ID      uuid.UUID     `json:"id"`
+	AfterID uuid.NullUUID `json:"after_id"`
+	Limit   int32         `json:"limit"`
+	Offset  int32         `json:"offset"`
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, listAccounts, arg.ID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAccounts,
+		arg.ID,
+		arg.AfterID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +199,10 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Weight,
 			&i.BodyFat,
 			&i.StartDate,
+			&i.EmailVerifiedAt,
+			&i.DateOfBirth,
+			&i.Height,
+			&i.Preferences,
 		); err != nil {
 			return nil, err
 		}
@@ -167,10 +217,85 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const updateAccount = `-- name: UpdateAccount :one
+UPDATE accounts SET
+name = COALESCE($1, name),
+email = COALESCE($2, email),
+email_verified_at = CASE WHEN $2 IS NULL OR $2 = email THEN email_verified_at END,
+weight = COALESCE($3, weight),
+body_fat = COALESCE($4, body_fat),
+start_date = COALESCE($5, start_date),
+date_of_birth = COALESCE($6, date_of_birth),
+height = COALESCE($7, height),
+preferences = jsonb_strip_nulls(preferences || $8::jsonb)
+WHERE id = $9
+RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date, email_verified_at, date_of_birth, height, preferences
+`
+
+type UpdateAccountParams struct {
+	Name        sql.NullString  `json:"name"`
+	Email       sql.NullString  `json:"email"`
+	Weight      sql.NullFloat64 `json:"weight"`
+	BodyFat     sql.NullFloat64 `json:"body_fat"`
+	StartDate   sql.NullTime    `json:"start_date"`
+	DateOfBirth sql.NullTime    `json:"date_of_birth"`
+	Height      sql.NullFloat64 `json:"height"`
+	Preferences json.RawMessage `json:"preferences"`
+	ID          uuid.UUID       `json:"id"`
+}
+
+func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccount,
+		arg.Name,
+		arg.Email,
+		arg.Weight,
+		arg.BodyFat,
+		arg.StartDate,
+		arg.DateOfBirth,
+		arg.Height,
+		arg.Preferences,
+		arg.ID,
+	)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Password,
+		&i.PasswordChangedAt,
+		&i.Weight,
+		&i.BodyFat,
+		&i.StartDate,
+		&i.EmailVerifiedAt,
+		&i.DateOfBirth,
+		&i.Height,
+		&i.Preferences,
+	)
+	return i, err
+}
+
+const updatePassword = `-- name: UpdatePassword :exec
+UPDATE accounts SET
+password = $1,
+password_changed_at = $2
+WHERE id = $3
+`
+
+type UpdatePasswordParams struct {
+	Password          string    `json:"password"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	ID                uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updatePassword, arg.Password, arg.PasswordChangedAt, arg.ID)
+	return err
+}
+
 const updateWeight = `-- name: UpdateWeight :exec
 UPDATE accounts SET
 weight = $1 WHERE 
-id = $2 RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date
+id = $2 RETURNING id, name, email, password, password_changed_at, weight, body_fat, start_date, email_verified_at, date_of_birth, height, preferences
 `
 
 type UpdateWeightParams struct {
@@ -182,3 +307,19 @@ func (q *Queries) UpdateWeight(ctx context.Context, arg UpdateWeightParams) erro
 	_, err := q.db.ExecContext(ctx, updateWeight, arg.Weight, arg.ID)
 	return err
 }
+
+const verifyEmail = `-- name: VerifyEmail :exec
+UPDATE accounts SET
+email_verified_at = $1
+WHERE id = $2 AND email_verified_at IS NULL
+`
+
+type VerifyEmailParams struct {
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	ID              uuid.UUID    `json:"id"`
+}
+
+func (q *Queries) VerifyEmail(ctx context.Context, arg VerifyEmailParams) error {
+	_, err := q.db.ExecContext(ctx, verifyEmail, arg.EmailVerifiedAt, arg.ID)
+	return err
+}
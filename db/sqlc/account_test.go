@@ -2,10 +2,13 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/google/uuid"
 
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +36,7 @@ func GenerateRandAccount(t *testing.T) Account {
 	require.Equal(t, account.Weight, args.Weight)
 	require.Equal(t, account.BodyFat, args.BodyFat)
 	require.True(t, account.PasswordChangedAt.IsZero())
+	require.JSONEq(t, "{}", string(account.Preferences))
 	return account
 }
 
@@ -82,9 +86,94 @@ func TestListAccounts(t *testing.T) {
 		Offset: 0,
 	})
 	require.NoError(t, err)
-	for _, v := range query {
-		require.NotEmpty(t, v)
-	}
+	require.Len(t, query, 1)
+	require.Equal(t, lastAccount.ID, query[0].ID)
+
+	query, err = testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		ID:      lastAccount.ID,
+		AfterID: uuid.NullUUID{UUID: lastAccount.ID, Valid: true},
+		Limit:   int32(n),
+	})
+	require.NoError(t, err)
+	require.Empty(t, query)
+}
+
+func TestUpdatePassword(t *testing.T) {
+	account := GenerateRandAccount(t)
+	hashedPassword, err := util.HashPassword(util.RandomString(10))
+	require.NoError(t, err)
+
+	changedAt := time.Now().UTC()
+	err = testQueries.UpdatePassword(context.Background(), UpdatePasswordParams{
+		Password:          hashedPassword,
+		PasswordChangedAt: changedAt,
+		ID:                account.ID,
+	})
+	require.NoError(t, err)
+
+	query, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, query.Password)
+
+	stored, err := testQueries.GetPasswordChangedAt(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, changedAt, stored, time.Millisecond)
+}
+
+func TestUpdateAccount(t *testing.T) {
+	account := GenerateRandAccount(t)
+	err := testQueries.VerifyEmail(context.Background(), VerifyEmailParams{
+		EmailVerifiedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:              account.ID,
+	})
+	require.NoError(t, err)
+
+	dateOfBirth := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	updated, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		Height:      sql.NullFloat64{Float64: 180, Valid: true},
+		DateOfBirth: sql.NullTime{Time: dateOfBirth, Valid: true},
+		Preferences: json.RawMessage(`{"units":"kg","theme":"dark"}`),
+		ID:          account.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Name, updated.Name)
+	require.Equal(t, account.Email, updated.Email)
+	require.Equal(t, account.Weight, updated.Weight)
+	require.Equal(t, account.BodyFat, updated.BodyFat)
+	require.True(t, updated.EmailVerifiedAt.Valid)
+	require.Equal(t, 180.0, updated.Height.Float64)
+	require.True(t, dateOfBirth.Equal(updated.DateOfBirth.Time))
+	require.JSONEq(t, `{"units":"kg","theme":"dark"}`, string(updated.Preferences))
+
+	// preferences merge and keys set to null are removed
+	updated, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		Name:        sql.NullString{String: util.RandomString(6), Valid: true},
+		Email:       sql.NullString{String: account.Email, Valid: true},
+		Preferences: json.RawMessage(`{"theme":null,"rest_timer":90}`),
+		ID:          account.ID,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, account.Name, updated.Name)
+	require.Equal(t, 180.0, updated.Height.Float64)
+	require.JSONEq(t, `{"units":"kg","rest_timer":90}`, string(updated.Preferences))
+	// sending the same email keeps it verified
+	require.True(t, updated.EmailVerifiedAt.Valid)
+
+	updated, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		Preferences: json.RawMessage("{}"),
+		ID:          account.ID,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, account.Email, updated.Email)
+	require.False(t, updated.EmailVerifiedAt.Valid)
+
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		Email:       sql.NullString{String: GenerateRandAccount(t).Email, Valid: true},
+		Preferences: json.RawMessage("{}"),
+		ID:          account.ID,
+	})
+	require.Error(t, err)
 }
 
 func TestDeleteAccount(t *testing.T) {
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandAPIKey(t *testing.T, account Account) ApiKey {
+	arg := CreateAPIKeyParams{
+		UserID:  account.ID,
+		Name:    util.RandomString(8),
+		Prefix:  "mog_" + util.RandomString(8),
+		KeyHash: util.HashSecret(util.RandomString(32)),
+		Scopes:  []string{"read", "lifts:write"},
+	}
+
+	key, err := testQueries.CreateAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.UserID, key.UserID)
+	require.Equal(t, arg.Name, key.Name)
+	require.Equal(t, arg.KeyHash, key.KeyHash)
+	require.Equal(t, arg.Scopes, key.Scopes)
+	require.False(t, key.LastUsedAt.Valid)
+	require.False(t, key.RevokedAt.Valid)
+
+	return key
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	GenerateRandAPIKey(t, GenerateRandAccount(t))
+}
+
+func TestGetAPIKeyByHash(t *testing.T) {
+	key := GenerateRandAPIKey(t, GenerateRandAccount(t))
+
+	got, err := testQueries.GetAPIKeyByHash(context.Background(), key.KeyHash)
+	require.NoError(t, err)
+	require.Equal(t, key.ID, got.ID)
+
+	now := time.Now().UTC()
+	err = testQueries.UpdateAPIKeyLastUsed(context.Background(), UpdateAPIKeyLastUsedParams{
+		LastUsedAt: sql.NullTime{Time: now, Valid: true},
+		ID:         key.ID,
+	})
+	require.NoError(t, err)
+
+	got, err = testQueries.GetAPIKeyByHash(context.Background(), key.KeyHash)
+	require.NoError(t, err)
+	require.WithinDuration(t, now, got.LastUsedAt.Time, time.Second)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	account := GenerateRandAccount(t)
+	key := GenerateRandAPIKey(t, account)
+	other := GenerateRandAccount(t)
+
+	arg := RevokeAPIKeyParams{
+		RevokedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:        key.ID,
+		UserID:    other.ID,
+	}
+
+	n, err := testQueries.RevokeAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	arg.UserID = account.ID
+	n, err = testQueries.RevokeAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = testQueries.RevokeAPIKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	_, err = testQueries.GetAPIKeyByHash(context.Background(), key.KeyHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListAPIKeys(t *testing.T) {
+	account := GenerateRandAccount(t)
+	for i := 0; i < 3; i++ {
+		GenerateRandAPIKey(t, account)
+	}
+
+	keys, err := testQueries.ListAPIKeys(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	for _, key := range keys {
+		require.Equal(t, account.ID, key.UserID)
+	}
+}
@@ -13,13 +13,13 @@ const createCategory = `-- name: CreateCategory :one
 INSERT INTO category (
   name
 ) VALUES ($1)
-RETURNING id, name
+RETURNING id, name, slug
 `
 
 func (q *Queries) CreateCategory(ctx context.Context, name string) (Category, error) {
 	row := q.db.QueryRowContext(ctx, createCategory, name)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
 	return i, err
 }
 
@@ -33,19 +33,31 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int16) error {
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, name FROM category
+SELECT id, name, slug FROM category
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetCategory(ctx context.Context, id int16) (Category, error) {
 	row := q.db.QueryRowContext(ctx, getCategory, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
+	return i, err
+}
+
+const getCategoryBySlug = `-- name: GetCategoryBySlug :one
+SELECT id, name, slug FROM category
+WHERE slug = $1 LIMIT 1
+`
+
+func (q *Queries) GetCategoryBySlug(ctx context.Context, slug string) (Category, error) {
+	row := q.db.QueryRowContext(ctx, getCategoryBySlug, slug)
+	var i Category
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
 	return i, err
 }
 
 const listCategories = `-- name: ListCategories :many
-SELECT id, name FROM category
+SELECT id, name, slug FROM category
 `
 
 func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
@@ -57,7 +69,7 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.Slug); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -74,7 +86,7 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 const updateCategory = `-- name: UpdateCategory :exec
 UPDATE category SET
 name = $1 WHERE 
-id = $2 RETURNING id, name
+id = $2 RETURNING id, name, slug
 `
 
 type UpdateCategoryParams struct {
@@ -2,9 +2,11 @@ package db
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,6 +25,29 @@ func TestCreateCategory(t *testing.T) {
 	GenerateRandomCategory(t)
 }
 
+func TestCategorySlug(t *testing.T) {
+	name := util.RandomString(6)
+
+	first, err := testQueries.CreateCategory(context.Background(), name+" press")
+	require.NoError(t, err)
+	require.Equal(t, name+"-press", first.Slug)
+
+	// a name that slugifies to a taken slug gets a numeric suffix
+	second, err := testQueries.CreateCategory(context.Background(), name+"-Press!")
+	require.NoError(t, err)
+	require.Equal(t, name+"-press-2", second.Slug)
+
+	// slugs that are empty or would read as an id are refused
+	for _, bad := range []string{"--", strconv.FormatInt(util.RandomInt(100000, 999999), 10)} {
+		_, err = testQueries.CreateCategory(context.Background(), bad)
+		require.Error(t, err)
+
+		var pqErr *pq.Error
+		require.ErrorAs(t, err, &pqErr)
+		require.Equal(t, "check_violation", pqErr.Code.Name())
+	}
+}
+
 func TestGetCategory(t *testing.T) {
 	category := GenerateRandomCategory(t)
 
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandEmailVerification(t *testing.T, account Account, expiresAt time.Time) EmailVerification {
+	args := CreateEmailVerificationParams{
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		UserID:    account.ID,
+		ExpiresAt: expiresAt,
+	}
+
+	verification, err := testQueries.CreateEmailVerification(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, args.TokenHash, verification.TokenHash)
+	require.Equal(t, args.UserID, verification.UserID)
+	require.False(t, verification.UsedAt.Valid)
+
+	return verification
+}
+
+func TestCreateEmailVerification(t *testing.T) {
+	GenerateRandEmailVerification(t, GenerateRandAccount(t), time.Now().UTC().Add(time.Hour))
+}
+
+func TestGetLatestEmailVerification(t *testing.T) {
+	account := GenerateRandAccount(t)
+
+	_, err := testQueries.GetLatestEmailVerification(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	verification := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(time.Hour))
+
+	latest, err := testQueries.GetLatestEmailVerification(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, verification.TokenHash, latest.TokenHash)
+}
+
+func TestUseEmailVerification(t *testing.T) {
+	account := GenerateRandAccount(t)
+	verification := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(time.Hour))
+
+	args := UseEmailVerificationParams{Now: time.Now().UTC(), TokenHash: verification.TokenHash}
+	userID, err := testQueries.UseEmailVerification(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, userID)
+
+	_, err = testQueries.UseEmailVerification(context.Background(), args)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	expired := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(-time.Minute))
+	_, err = testQueries.UseEmailVerification(context.Background(), UseEmailVerificationParams{Now: time.Now().UTC(), TokenHash: expired.TokenHash})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteEmailVerifications(t *testing.T) {
+	account := GenerateRandAccount(t)
+	verification := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(time.Hour))
+
+	err := testQueries.DeleteEmailVerifications(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.UseEmailVerification(context.Background(), UseEmailVerificationParams{Now: time.Now().UTC(), TokenHash: verification.TokenHash})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertEquipmentSettings(t *testing.T) {
+	account := GenerateRandAccount(t)
+
+	settings, err := testQueries.UpsertEquipmentSettings(context.Background(), UpsertEquipmentSettingsParams{
+		UserID:    account.ID,
+		BarWeight: 45,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float32(45), settings.BarWeight)
+
+	settings, err = testQueries.UpsertEquipmentSettings(context.Background(), UpsertEquipmentSettingsParams{
+		UserID:    account.ID,
+		BarWeight: 35,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float32(35), settings.BarWeight)
+
+	query, err := testQueries.GetEquipmentSettings(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, settings, query)
+}
+
+func TestCreatePlates(t *testing.T) {
+	account := GenerateRandAccount(t)
+	weights := []float32{2.5, 45, 10}
+	pairs := []int16{1, 4, 2}
+
+	plates, err := testQueries.CreatePlates(context.Background(), CreatePlatesParams{
+		UserID:  []uuid.UUID{account.ID, account.ID, account.ID},
+		Weights: weights,
+		Pairs:   pairs,
+	})
+	require.NoError(t, err)
+	require.Len(t, plates, len(weights))
+
+	query, err := testQueries.ListPlates(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, query, len(weights))
+	require.Equal(t, float32(45), query[0].Weight)
+	require.Equal(t, float32(2.5), query[2].Weight)
+
+	err = testQueries.DeletePlates(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	query, err = testQueries.ListPlates(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Empty(t, query)
+}
@@ -7,32 +7,77 @@ package db
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const archiveExercise = `-- name: ArchiveExercise :one
+UPDATE exercise SET archived = $1
+WHERE name = $2
+RETURNING id, name, muscle_group, category, archived, equipment, movement_pattern, slug
+`
+
+type ArchiveExerciseParams struct {
+	Archived bool   `json:"archived"`
+	Name     string `json:"name"`
+}
+
+func (q *Queries) ArchiveExercise(ctx context.Context, arg ArchiveExerciseParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, archiveExercise, arg.Archived, arg.Name)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MuscleGroup,
+		&i.Category,
+		&i.Archived,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Slug,
+	)
+	return i, err
+}
+
 const createExercise = `-- name: CreateExercise :one
 INSERT INTO exercise (
   name,
   muscle_group,
-  category
+  category,
+  equipment,
+  movement_pattern
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, name, muscle_group, category
+  $1, $2, $3, $4, $5
+) RETURNING id, name, muscle_group, category, archived, equipment, movement_pattern, slug
 `
 
 type CreateExerciseParams struct {
-	Name        string `json:"name"`
-	MuscleGroup string `json:"muscle_group"`
-	Category    string `json:"category"`
+	Name            string `json:"name"`
+	MuscleGroup     string `json:"muscle_group"`
+	Category        string `json:"category"`
+	Equipment       string `json:"equipment"`
+	MovementPattern string `json:"movement_pattern"`
 }
 
 func (q *Queries) CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error) {
-	row := q.db.QueryRowContext(ctx, createExercise, arg.Name, arg.MuscleGroup, arg.Category)
+	row := q.db.QueryRowContext(ctx, createExercise,
+		arg.Name,
+		arg.MuscleGroup,
+		arg.Category,
+		arg.Equipment,
+		arg.MovementPattern,
+	)
 	var i Exercise
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.MuscleGroup,
 		&i.Category,
+		&i.Archived,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Slug,
 	)
 	return i, err
 }
@@ -47,7 +92,7 @@ func (q *Queries) DeleteExercise(ctx context.Context, name string) error {
 }
 
 const getExercise = `-- name: GetExercise :one
-SELECT id, name, muscle_group, category FROM exercise
+SELECT id, name, muscle_group, category, archived, equipment, movement_pattern, slug FROM exercise
 WHERE name = ($1) LIMIT 1
 `
 
@@ -59,26 +104,83 @@ func (q *Queries) GetExercise(ctx context.Context, name string) (Exercise, error
 		&i.Name,
 		&i.MuscleGroup,
 		&i.Category,
+		&i.Archived,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Slug,
+	)
+	return i, err
+}
+
+const getExerciseByID = `-- name: GetExerciseByID :one
+SELECT id, name, muscle_group, category, archived, equipment, movement_pattern, slug FROM exercise
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetExerciseByID(ctx context.Context, id int32) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, getExerciseByID, id)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MuscleGroup,
+		&i.Category,
+		&i.Archived,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Slug,
+	)
+	return i, err
+}
+
+const getExerciseBySlug = `-- name: GetExerciseBySlug :one
+SELECT id, name, muscle_group, category, archived, equipment, movement_pattern, slug FROM exercise
+WHERE slug = $1 LIMIT 1
+`
+
+func (q *Queries) GetExerciseBySlug(ctx context.Context, slug string) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, getExerciseBySlug, slug)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MuscleGroup,
+		&i.Category,
+		&i.Archived,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Slug,
 	)
 	return i, err
 }
 
 const listByMuscleGroup = `-- name: ListByMuscleGroup :many
-SELECT id, name, muscle_group, category FROM exercise 
-WHERE muscle_group = ($1)
-ORDER BY name
-LIMIT $2
-OFFSET $3
+SELECT e.id, e.name, e.muscle_group, e.category, e.archived, e.equipment, e.movement_pattern, e.slug FROM exercise AS e
+JOIN exercise_muscle_group AS emg ON emg.exercise_name = e.name
+WHERE emg.muscle_group = $1
+AND NOT e.archived
+AND ($2::VARCHAR IS NULL OR (NOT emg.is_primary, e.name) > (NOT $3::BOOLEAN, $2::VARCHAR))
+ORDER BY emg.is_primary DESC, e.name
+LIMIT $4
+OFFSET $5
 `
 
 type ListByMuscleGroupParams struct {
-	MuscleGroup string `json:"muscle_group"`
-	Limit       int32  `json:"limit"`
-	Offset      int32  `json:"offset"`
+	MuscleGroup  string         `json:"muscle_group"`
+	AfterName    sql.NullString `json:"after_name"`
+	AfterPrimary bool           `json:"after_primary"`
+	Limit        int32          `json:"limit"`
+	Offset       int32          `json:"offset"`
 }
 
 func (q *Queries) ListByMuscleGroup(ctx context.Context, arg ListByMuscleGroupParams) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, listByMuscleGroup, arg.MuscleGroup, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listByMuscleGroup,
+		arg.MuscleGroup,
+		arg.AfterName,
+		arg.AfterPrimary,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +193,121 @@ func (q *Queries) ListByMuscleGroup(ctx context.Context, arg ListByMuscleGroupPa
 			&i.Name,
 			&i.MuscleGroup,
 			&i.Category,
+			&i.Archived,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Slug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExerciseNames = `-- name: ListExerciseNames :many
+SELECT id, name FROM exercise
+ORDER BY name
+`
+
+type ListExerciseNamesRow struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) ListExerciseNames(ctx context.Context) ([]ListExerciseNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExerciseNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExerciseNamesRow{}
+	for rows.Next() {
+		var i ListExerciseNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExerciseSubstitutes = `-- name: ListExerciseSubstitutes :many
+WITH target AS (
+  SELECT name, equipment, movement_pattern FROM exercise
+  WHERE name = $1
+), shared AS (
+  SELECT o.exercise_name, SUM(LEAST(t.contribution, o.contribution)) AS overlap
+  FROM exercise_muscle_group AS t
+  JOIN exercise_muscle_group AS o ON o.muscle_group = t.muscle_group
+  WHERE t.exercise_name = $1
+  AND o.exercise_name <> $1
+  GROUP BY o.exercise_name
+)
+SELECT e.id, e.name, e.muscle_group, e.category, e.archived, e.equipment, e.movement_pattern, e.slug,
+(
+  s.overlap
+  + CASE WHEN e.movement_pattern <> '' AND e.movement_pattern = t.movement_pattern THEN 0.5 ELSE 0 END
+  + CASE WHEN e.equipment = t.equipment THEN 0.1 ELSE 0 END
+)::REAL AS score
+FROM shared AS s
+JOIN exercise AS e ON e.name = s.exercise_name
+CROSS JOIN target AS t
+WHERE NOT e.archived
+AND (cardinality($2::VARCHAR[]) = 0 OR e.equipment = ANY($2::VARCHAR[]))
+ORDER BY score DESC, e.name
+LIMIT $3
+`
+
+type ListExerciseSubstitutesParams struct {
+	Name      string   `json:"name"`
+	Equipment []string `json:"equipment"`
+	Limit     int32    `json:"limit"`
+}
+
+type ListExerciseSubstitutesRow struct {
+	ID              int32   `json:"id"`
+	Name            string  `json:"name"`
+	MuscleGroup     string  `json:"muscle_group"`
+	Category        string  `json:"category"`
+	Archived        bool    `json:"archived"`
+	Equipment       string  `json:"equipment"`
+	MovementPattern string  `json:"movement_pattern"`
+	Slug            string  `json:"slug"`
+	Score           float32 `json:"score"`
+}
+
+func (q *Queries) ListExerciseSubstitutes(ctx context.Context, arg ListExerciseSubstitutesParams) ([]ListExerciseSubstitutesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExerciseSubstitutes, arg.Name, pq.Array(arg.Equipment), arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExerciseSubstitutesRow{}
+	for rows.Next() {
+		var i ListExerciseSubstitutesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MuscleGroup,
+			&i.Category,
+			&i.Archived,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Slug,
+			&i.Score,
 		); err != nil {
 			return nil, err
 		}
@@ -106,19 +323,77 @@ func (q *Queries) ListByMuscleGroup(ctx context.Context, arg ListByMuscleGroupPa
 }
 
 const listExercises = `-- name: ListExercises :many
-SELECT id, name, muscle_group, category FROM exercise
-ORDER BY name 
-LIMIT $1
-OFFSET $2
+SELECT id, name, muscle_group, category, archived, equipment, movement_pattern, slug FROM exercise
+WHERE (NOT archived OR $1::BOOLEAN)
+AND (cardinality($2::VARCHAR[]) = 0 OR equipment = ANY($2::VARCHAR[]))
+AND ($3::VARCHAR IS NULL OR name > $3::VARCHAR)
+ORDER BY name
+LIMIT $4
+OFFSET $5
 `
 
 type ListExercisesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	IncludeArchived bool           `json:"include_archived"`
+	Equipment       []string       `json:"equipment"`
+	AfterName       sql.NullString `json:"after_name"`
+	Limit           int32          `json:"limit"`
+	Offset          int32          `json:"offset"`
 }
 
 func (q *Queries) ListExercises(ctx context.Context, arg ListExercisesParams) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, listExercises, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listExercises,
+		arg.IncludeArchived,
+		pq.Array(arg.Equipment),
+		arg.AfterName,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Exercise{}
+	for rows.Next() {
+		var i Exercise
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MuscleGroup,
+			&i.Category,
+			&i.Archived,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Slug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserExercises = `-- name: ListUserExercises :many
+SELECT id, name, muscle_group, category, archived, equipment, movement_pattern, slug FROM exercise
+WHERE id IN (SELECT exercise_id FROM lift WHERE user_id = $1)
+AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListUserExercisesParams struct {
+	UserID  uuid.UUID `json:"user_id"`
+	AfterID int32     `json:"after_id"`
+	Limit   int32     `json:"limit"`
+}
+
+func (q *Queries) ListUserExercises(ctx context.Context, arg ListUserExercisesParams) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, listUserExercises, arg.UserID, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +406,85 @@ func (q *Queries) ListExercises(ctx context.Context, arg ListExercisesParams) ([
 			&i.Name,
 			&i.MuscleGroup,
 			&i.Category,
+			&i.Archived,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Slug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchExercises = `-- name: SearchExercises :many
+WITH ranked AS (
+  SELECT e.id, e.name, e.muscle_group, e.category, e.archived, e.equipment, e.movement_pattern, e.slug,
+  GREATEST(
+    word_similarity($1::TEXT, e.name),
+    COALESCE(MAX(word_similarity($1::TEXT, a.alias)), 0)
+  )::REAL AS score
+  FROM exercise AS e
+  LEFT JOIN exercise_alias AS a ON a.exercise_name = e.name
+  WHERE NOT e.archived
+  GROUP BY e.id
+)
+SELECT r.id, r.name, r.muscle_group, r.category, r.archived, r.equipment, r.movement_pattern, r.slug, r.score,
+COUNT(l.id) AS times_performed
+FROM ranked AS r
+LEFT JOIN lift AS l ON l.exercise_id = r.id AND l.user_id = $2
+WHERE r.score >= 0.3
+GROUP BY r.id, r.name, r.muscle_group, r.category, r.archived, r.equipment, r.movement_pattern, r.slug, r.score
+ORDER BY r.score + 0.1 * LN(1 + COUNT(l.id)) DESC, r.name
+LIMIT $3
+`
+
+type SearchExercisesParams struct {
+	Query  string    `json:"query"`
+	UserID uuid.UUID `json:"user_id"`
+	Limit  int32     `json:"limit"`
+}
+
+type SearchExercisesRow struct {
+	ID              int32   `json:"id"`
+	Name            string  `json:"name"`
+	MuscleGroup     string  `json:"muscle_group"`
+	Category        string  `json:"category"`
+	Archived        bool    `json:"archived"`
+	Equipment       string  `json:"equipment"`
+	MovementPattern string  `json:"movement_pattern"`
+	Slug            string  `json:"slug"`
+	Score           float32 `json:"score"`
+	TimesPerformed  int64   `json:"times_performed"`
+}
+
+func (q *Queries) SearchExercises(ctx context.Context, arg SearchExercisesParams) ([]SearchExercisesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchExercises, arg.Query, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchExercisesRow{}
+	for rows.Next() {
+		var i SearchExercisesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MuscleGroup,
+			&i.Category,
+			&i.Archived,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Slug,
+			&i.Score,
+			&i.TimesPerformed,
 		); err != nil {
 			return nil, err
 		}
@@ -149,15 +503,19 @@ const updateExercise = `-- name: UpdateExercise :one
 UPDATE exercise SET
 name = COALESCE(NULLIF($1, ''), name),
 muscle_group = COALESCE(NULLIF($2, ''), muscle_group),
-category = COALESCE(NULLIF($3, ''), category)
-WHERE name = $4
-RETURNING id, name, muscle_group, category
+category = COALESCE(NULLIF($3, ''), category),
+equipment = COALESCE(NULLIF($4, ''), equipment),
+movement_pattern = COALESCE(NULLIF($5, ''), movement_pattern)
+WHERE name = $6
+RETURNING id, name, muscle_group, category, archived, equipment, movement_pattern, slug
 `
 
 type UpdateExerciseParams struct {
 	Column1 interface{} `json:"column_1"`
 	Column2 interface{} `json:"column_2"`
 	Column3 interface{} `json:"column_3"`
+	Column4 interface{} `json:"column_4"`
+	Column5 interface{} `json:"column_5"`
 	Name    string      `json:"name"`
 }
 
@@ -166,6 +524,8 @@ func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams)
 		arg.Column1,
 		arg.Column2,
 		arg.Column3,
+		arg.Column4,
+		arg.Column5,
 		arg.Name,
 	)
 	var i Exercise
@@ -174,6 +534,10 @@ func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams)
 		&i.Name,
 		&i.MuscleGroup,
 		&i.Category,
+		&i.Archived,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Slug,
 	)
 	return i, err
 }
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandExerciseAlias(t *testing.T, exercise Exercise) ExerciseAlias {
+	args := CreateExerciseAliasParams{
+		ExerciseName: exercise.Name,
+		Alias:        util.RandomString(8),
+	}
+
+	alias, err := testQueries.CreateExerciseAlias(context.Background(), args)
+	require.NoError(t, err)
+	require.NotZero(t, alias.ID)
+	require.Equal(t, args.ExerciseName, alias.ExerciseName)
+	require.Equal(t, args.Alias, alias.Alias)
+	return alias
+}
+
+func TestCreateExerciseAlias(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+	alias := GenerateRandExerciseAlias(t, exercise)
+
+	_, err := testQueries.CreateExerciseAlias(context.Background(), CreateExerciseAliasParams{
+		ExerciseName: exercise.Name,
+		Alias:        alias.Alias,
+	})
+	require.Error(t, err)
+}
+
+func TestListExerciseAliases(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+	n := 3
+	for i := 0; i < n; i++ {
+		GenerateRandExerciseAlias(t, exercise)
+	}
+
+	aliases, err := testQueries.ListExerciseAliases(context.Background(), exercise.Name)
+	require.NoError(t, err)
+	require.Len(t, aliases, n)
+}
+
+func TestDeleteExerciseAlias(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+	alias := GenerateRandExerciseAlias(t, exercise)
+
+	n, err := testQueries.DeleteExerciseAlias(context.Background(), DeleteExerciseAliasParams{
+		ExerciseName: exercise.Name,
+		Alias:        alias.Alias,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
+
+func TestSearchExercises(t *testing.T) {
+	lift := GenerateRandLift(t)
+	exercise, err := testQueries.GetExerciseByID(context.Background(), lift.ExerciseID)
+	require.NoError(t, err)
+	alias := GenerateRandExerciseAlias(t, exercise)
+
+	results, err := testQueries.SearchExercises(context.Background(), SearchExercisesParams{
+		Query:  alias.Alias,
+		UserID: lift.UserID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, exercise.Name, results[0].Name)
+	require.Equal(t, int64(1), results[0].TimesPerformed)
+	require.InDelta(t, 1, results[0].Score, 0.001)
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrimaryMuscleGroupTrigger(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+
+	groups, err := testQueries.ListExerciseMuscleGroups(context.Background(), exercise.Name)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, exercise.MuscleGroup, groups[0].MuscleGroup)
+	require.True(t, groups[0].IsPrimary)
+	require.Equal(t, float32(1), groups[0].Contribution)
+
+	muscleGroup := GenerateRandMuscleGroup(t)
+	_, err = testQueries.UpdateExercise(context.Background(), UpdateExerciseParams{
+		Column1: "",
+		Column2: muscleGroup.Name,
+		Column3: "",
+		Name:    exercise.Name,
+	})
+	require.NoError(t, err)
+
+	groups, err = testQueries.ListExerciseMuscleGroups(context.Background(), exercise.Name)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, muscleGroup.Name, groups[0].MuscleGroup)
+}
+
+func TestSetExerciseMuscleGroupsTx(t *testing.T) {
+	store := NewStore(testDB)
+	exercise := GenerateRandomExercise(t)
+	secondary := GenerateRandMuscleGroup(t)
+
+	groups, err := store.SetExerciseMuscleGroupsTx(context.Background(), SetExerciseMuscleGroupsTxParams{
+		ExerciseName:  exercise.Name,
+		Primary:       exercise.MuscleGroup,
+		MuscleGroups:  []string{secondary.Name},
+		Contributions: []float32{0.5},
+	})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.True(t, groups[0].IsPrimary)
+	require.Equal(t, secondary.Name, groups[1].MuscleGroup)
+	require.Equal(t, float32(0.5), groups[1].Contribution)
+
+	exercises, err := testQueries.ListByMuscleGroup(context.Background(), ListByMuscleGroupParams{
+		MuscleGroup: secondary.Name,
+		Limit:       5,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Len(t, exercises, 1)
+	require.Equal(t, exercise.Name, exercises[0].Name)
+
+	groups, err = store.SetExerciseMuscleGroupsTx(context.Background(), SetExerciseMuscleGroupsTxParams{
+		ExerciseName:  exercise.Name,
+		Primary:       exercise.MuscleGroup,
+		MuscleGroups:  []string{},
+		Contributions: []float32{},
+	})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+}
+
+func TestListMuscleGroupVolume(t *testing.T) {
+	store := NewStore(testDB)
+	lift := GenerateRandLift(t)
+	exercise, err := testQueries.GetExerciseByID(context.Background(), lift.ExerciseID)
+	require.NoError(t, err)
+	secondary := GenerateRandMuscleGroup(t)
+
+	_, err = store.SetExerciseMuscleGroupsTx(context.Background(), SetExerciseMuscleGroupsTxParams{
+		ExerciseName:  exercise.Name,
+		Primary:       exercise.MuscleGroup,
+		MuscleGroups:  []string{secondary.Name},
+		Contributions: []float32{0.5},
+	})
+	require.NoError(t, err)
+
+	volume, err := testQueries.ListMuscleGroupVolume(context.Background(), ListMuscleGroupVolumeParams{
+		UserID:    lift.UserID,
+		StartTime: time.Now().Add(-24 * time.Hour),
+		EndTime:   time.Now().Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, volume, 2)
+
+	full := lift.WeightLifted * float32(lift.Reps)
+	require.Equal(t, exercise.MuscleGroup, volume[0].MuscleGroup)
+	require.InDelta(t, full, volume[0].Volume, 0.01)
+	require.Equal(t, float32(1), volume[0].Sets)
+	require.Equal(t, secondary.Name, volume[1].MuscleGroup)
+	require.InDelta(t, full/2, volume[1].Volume, 0.01)
+	require.Equal(t, float32(0.5), volume[1].Sets)
+}
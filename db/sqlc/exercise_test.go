@@ -17,6 +17,7 @@ func GenerateRandomExercise(t *testing.T) Exercise {
 		Name:        exerciseName,
 		MuscleGroup: muscleGroup.Name,
 		Category:    category.Name,
+		Equipment:   "barbell",
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, exercise)
@@ -49,8 +50,9 @@ func TestListExercises(t *testing.T) {
 	}
 
 	query, err := testQueries.ListExercises(context.Background(), ListExercisesParams{
-		Limit:  int32(n),
-		Offset: 0,
+		Equipment: []string{},
+		Limit:     int32(n),
+		Offset:    0,
 	})
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, len(query), n)
@@ -111,3 +113,147 @@ func TestDeleteExercise(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, query)
 }
+
+func TestDeleteReferencedExercise(t *testing.T) {
+	lift := GenerateRandLift(t)
+
+	exercise, err := testQueries.GetExerciseByID(context.Background(), lift.ExerciseID)
+	require.NoError(t, err)
+
+	err = testQueries.DeleteExercise(context.Background(), exercise.Name)
+	require.Error(t, err)
+
+	query, err := testQueries.GetLift(context.Background(), GetLiftParams{
+		UserID: lift.UserID,
+		ID:     lift.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, lift.ExerciseID, query.ExerciseID)
+}
+
+func TestArchiveExercise(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+
+	archived, err := testQueries.ArchiveExercise(context.Background(), ArchiveExerciseParams{
+		Archived: true,
+		Name:     exercise.Name,
+	})
+	require.NoError(t, err)
+	require.True(t, archived.Archived)
+
+	exercises, err := testQueries.ListByMuscleGroup(context.Background(), ListByMuscleGroupParams{
+		MuscleGroup: exercise.MuscleGroup,
+		Limit:       5,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, exercises)
+
+	restored, err := testQueries.ArchiveExercise(context.Background(), ArchiveExerciseParams{
+		Archived: false,
+		Name:     exercise.Name,
+	})
+	require.NoError(t, err)
+	require.False(t, restored.Archived)
+}
+
+func TestListExercisesByEquipment(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+
+	_, err := testQueries.UpdateExercise(context.Background(), UpdateExerciseParams{
+		Column4: "cable",
+		Name:    exercise.Name,
+	})
+	require.NoError(t, err)
+
+	query, err := testQueries.ListExercises(context.Background(), ListExercisesParams{
+		Equipment: []string{"cable"},
+		Limit:     1000,
+		Offset:    0,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, query)
+	for _, v := range query {
+		require.Equal(t, "cable", v.Equipment)
+	}
+
+	_, err = testQueries.UpdateExercise(context.Background(), UpdateExerciseParams{
+		Column4: "kettlebell",
+		Name:    exercise.Name,
+	})
+	require.Error(t, err)
+}
+
+func TestListExerciseSubstitutes(t *testing.T) {
+	exercise := GenerateRandomExercise(t)
+	noOverlap := GenerateRandomExercise(t)
+
+	samePattern, err := testQueries.CreateExercise(context.Background(), CreateExerciseParams{
+		Name:            util.RandomString(6),
+		MuscleGroup:     exercise.MuscleGroup,
+		Category:        exercise.Category,
+		Equipment:       "dumbbell",
+		MovementPattern: "horizontal_push",
+	})
+	require.NoError(t, err)
+
+	otherPattern, err := testQueries.CreateExercise(context.Background(), CreateExerciseParams{
+		Name:            util.RandomString(6),
+		MuscleGroup:     exercise.MuscleGroup,
+		Category:        exercise.Category,
+		Equipment:       "cable",
+		MovementPattern: "isolation",
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateExercise(context.Background(), UpdateExerciseParams{
+		Column5: "horizontal_push",
+		Name:    exercise.Name,
+	})
+	require.NoError(t, err)
+
+	substitutes, err := testQueries.ListExerciseSubstitutes(context.Background(), ListExerciseSubstitutesParams{
+		Name:      exercise.Name,
+		Equipment: []string{},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, substitutes, 2)
+	require.Equal(t, samePattern.Name, substitutes[0].Name)
+	require.Equal(t, otherPattern.Name, substitutes[1].Name)
+	require.Greater(t, substitutes[0].Score, substitutes[1].Score)
+	for _, v := range substitutes {
+		require.NotEqual(t, exercise.Name, v.Name)
+		require.NotEqual(t, noOverlap.Name, v.Name)
+	}
+
+	substitutes, err = testQueries.ListExerciseSubstitutes(context.Background(), ListExerciseSubstitutesParams{
+		Name:      exercise.Name,
+		Equipment: []string{"cable"},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, substitutes, 1)
+	require.Equal(t, otherPattern.Name, substitutes[0].Name)
+}
+
+func TestListUserExercises(t *testing.T) {
+	lift := GenerateRandLift(t)
+	GenerateRandomExercise(t)
+
+	exercises, err := testQueries.ListUserExercises(context.Background(), ListUserExercisesParams{
+		UserID: lift.UserID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, exercises, 1)
+	require.Equal(t, lift.ExerciseID, exercises[0].ID)
+
+	exercises, err = testQueries.ListUserExercises(context.Background(), ListUserExercisesParams{
+		UserID:  lift.UserID,
+		AfterID: lift.ExerciseID,
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, exercises)
+}
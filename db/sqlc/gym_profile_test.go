@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandGymProfile(t *testing.T) GymProfile {
+	account := GenerateRandAccount(t)
+
+	profile, err := testQueries.CreateGymProfile(context.Background(), CreateGymProfileParams{
+		UserID:    account.ID,
+		Name:      util.RandomString(8),
+		Equipment: []string{"dumbbell", "bands"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, profile.ID)
+	require.Equal(t, account.ID, profile.UserID)
+	require.Equal(t, []string{"dumbbell", "bands"}, profile.Equipment)
+	return profile
+}
+
+func TestCreateGymProfile(t *testing.T) {
+	profile := GenerateRandGymProfile(t)
+
+	_, err := testQueries.CreateGymProfile(context.Background(), CreateGymProfileParams{
+		UserID:    profile.UserID,
+		Name:      profile.Name,
+		Equipment: []string{},
+	})
+	require.Error(t, err)
+}
+
+func TestGetGymProfile(t *testing.T) {
+	profile := GenerateRandGymProfile(t)
+
+	query, err := testQueries.GetGymProfile(context.Background(), profile.ID)
+	require.NoError(t, err)
+	require.Equal(t, profile, query)
+}
+
+func TestListGymProfiles(t *testing.T) {
+	profile := GenerateRandGymProfile(t)
+	_, err := testQueries.CreateGymProfile(context.Background(), CreateGymProfileParams{
+		UserID:    profile.UserID,
+		Name:      util.RandomString(8),
+		Equipment: []string{"barbell"},
+	})
+	require.NoError(t, err)
+
+	query, err := testQueries.ListGymProfiles(context.Background(), profile.UserID)
+	require.NoError(t, err)
+	require.Len(t, query, 2)
+}
+
+func TestUpdateGymProfile(t *testing.T) {
+	profile := GenerateRandGymProfile(t)
+	name := util.RandomString(8)
+
+	patched, err := testQueries.UpdateGymProfile(context.Background(), UpdateGymProfileParams{
+		Name:      name,
+		Equipment: []string{"machine", "cable"},
+		ID:        profile.ID,
+		UserID:    profile.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, name, patched.Name)
+	require.Equal(t, []string{"machine", "cable"}, patched.Equipment)
+
+	other := GenerateRandAccount(t)
+	_, err = testQueries.UpdateGymProfile(context.Background(), UpdateGymProfileParams{
+		Name:      name,
+		Equipment: []string{},
+		ID:        profile.ID,
+		UserID:    other.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteGymProfile(t *testing.T) {
+	profile := GenerateRandGymProfile(t)
+
+	n, err := testQueries.DeleteGymProfile(context.Background(), DeleteGymProfileParams{
+		ID:     profile.ID,
+		UserID: profile.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	_, err = testQueries.GetGymProfile(context.Background(), profile.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -14,77 +15,86 @@ import (
 
 const createLift = `-- name: CreateLift :one
 INSERT INTO lift (
-  exercise_name,
+  exercise_id,
   weight_lifted,
   reps,
   user_id,
-  workout_id
+  workout_id,
+  notes
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6
 )
-RETURNING id, exercise_name, weight_lifted, reps, user_id, workout_id
+RETURNING id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq
 `
 
 type CreateLiftParams struct {
-	ExerciseName string    `json:"exercise_name"`
+	ExerciseID   int32     `json:"exercise_id"`
 	WeightLifted float32   `json:"weight_lifted"`
 	Reps         int16     `json:"reps"`
 	UserID       uuid.UUID `json:"user_id"`
 	WorkoutID    uuid.UUID `json:"workout_id"`
+	Notes        string    `json:"notes"`
 }
 
 func (q *Queries) CreateLift(ctx context.Context, arg CreateLiftParams) (Lift, error) {
 	row := q.db.QueryRowContext(ctx, createLift,
-		arg.ExerciseName,
+		arg.ExerciseID,
 		arg.WeightLifted,
 		arg.Reps,
 		arg.UserID,
 		arg.WorkoutID,
+		arg.Notes,
 	)
 	var i Lift
 	err := row.Scan(
 		&i.ID,
-		&i.ExerciseName,
 		&i.WeightLifted,
 		&i.Reps,
 		&i.UserID,
 		&i.WorkoutID,
+		&i.Notes,
+		&i.ExerciseID,
+		&i.Seq,
 	)
 	return i, err
 }
 
 const createLifts = `-- name: CreateLifts :many
 INSERT INTO lift (
-  exercise_name,
+  exercise_id,
   weight_lifted,
   reps,
   user_id,
-  workout_id
+  workout_id,
+  notes
 ) VALUES (
-  UNNEST($1::VARCHAR[]),
+  UNNEST($1::INT[]),
   UNNEST($2::REAL[]),
   UNNEST($3::SMALLINT[]),
   UNNEST($4::UUID[]),
-  UNNEST($5::UUID[])
+  UNNEST($5::UUID[]),
+  UNNEST($6::TEXT[])
 )
-RETURNING id, exercise_name, weight_lifted, reps, user_id, workout_id
+RETURNING id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq
 `
 
 type CreateLiftsParams struct {
-	Exercisenames []string    `json:"exercisenames"`
-	Weights       []float32   `json:"weights"`
-	Reps          []int16     `json:"reps"`
-	UserID        []uuid.UUID `json:"user_id"`
-	WorkoutID     []uuid.UUID `json:"workout_id"`
+	ExerciseIds []int32     `json:"exercise_ids"`
+	Weights     []float32   `json:"weights"`
+	Reps        []int16     `json:"reps"`
+	UserID      []uuid.UUID `json:"user_id"`
+	WorkoutID   []uuid.UUID `json:"workout_id"`
+	Notes       []string    `json:"notes"`
 }
 
 func (q *Queries) CreateLifts(ctx context.Context, arg CreateLiftsParams) ([]Lift, error) {
 	rows, err := q.db.QueryContext(ctx, createLifts,
-		pq.Array(arg.Exercisenames),
+		pq.Array(arg.ExerciseIds),
 		pq.Array(arg.Weights),
 		pq.Array(arg.Reps),
 		pq.Array(arg.UserID),
 		pq.Array(arg.WorkoutID),
+		pq.Array(arg.Notes),
 	)
 	if err != nil {
 		return nil, err
@@ -95,11 +105,13 @@ func (q *Queries) CreateLifts(ctx context.Context, arg CreateLiftsParams) ([]Lif
 		var i Lift
 		if err := rows.Scan(
 			&i.ID,
-			&i.ExerciseName,
 			&i.WeightLifted,
 			&i.Reps,
 			&i.UserID,
 			&i.WorkoutID,
+			&i.Notes,
+			&i.ExerciseID,
+			&i.Seq,
 		); err != nil {
 			return nil, err
 		}
@@ -124,7 +136,7 @@ func (q *Queries) DeleteLift(ctx context.Context, id uuid.UUID) error {
 }
 
 const getLift = `-- name: GetLift :one
-SELECT id, exercise_name, weight_lifted, reps, user_id, workout_id FROM lift
+SELECT id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq FROM lift
 WHERE user_id = $1
 AND id = $2
 LIMIT 1
@@ -140,45 +152,72 @@ func (q *Queries) GetLift(ctx context.Context, arg GetLiftParams) (Lift, error)
 	var i Lift
 	err := row.Scan(
 		&i.ID,
-		&i.ExerciseName,
 		&i.WeightLifted,
 		&i.Reps,
 		&i.UserID,
 		&i.WorkoutID,
+		&i.Notes,
+		&i.ExerciseID,
+		&i.Seq,
 	)
 	return i, err
 }
 
 const listLifts = `-- name: ListLifts :many
-SELECT id, exercise_name, weight_lifted, reps, user_id, workout_id FROM lift
-WHERE user_id = $1
-ORDER BY exercise_name 
-LIMIT $2
-OFFSET $3
+SELECT l.id, l.weight_lifted, l.reps, l.user_id, l.workout_id, l.notes, l.exercise_id, l.seq, e.name AS exercise_name FROM lift AS l
+JOIN exercise AS e ON e.id = l.exercise_id
+WHERE l.user_id = $1
+AND ($2::UUID IS NULL OR (e.name, l.id) > ($3::VARCHAR, $2::UUID))
+ORDER BY e.name, l.id
+LIMIT $4
+OFFSET $5
 `
 
 type ListLiftsParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	UserID    uuid.UUID     `json:"user_id"`
+	AfterID   uuid.NullUUID `json:"after_id"`
+	AfterName string        `json:"after_name"`
+	Limit     int32         `json:"limit"`
+	Offset    int32         `json:"offset"`
+}
+
+type ListLiftsRow struct {
+	ID           uuid.UUID `json:"id"`
+	WeightLifted float32   `json:"weight_lifted"`
+	Reps         int16     `json:"reps"`
+	UserID       uuid.UUID `json:"user_id"`
+	WorkoutID    uuid.UUID `json:"workout_id"`
+	Notes        string    `json:"notes"`
+	ExerciseID   int32     `json:"exercise_id"`
+	Seq          int64     `json:"seq"`
+	ExerciseName string    `json:"exercise_name"`
 }
 
-func (q *Queries) ListLifts(ctx context.Context, arg ListLiftsParams) ([]Lift, error) {
-	rows, err := q.db.QueryContext(ctx, listLifts, arg.UserID, arg.Limit, arg.Offset)
+func (q *Queries) ListLifts(ctx context.Context, arg ListLiftsParams) ([]ListLiftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLifts,
+		arg.UserID,
+		arg.AfterID,
+		arg.AfterName,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Lift{}
+	items := []ListLiftsRow{}
 	for rows.Next() {
-		var i Lift
+		var i ListLiftsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.ExerciseName,
 			&i.WeightLifted,
 			&i.Reps,
 			&i.UserID,
 			&i.WorkoutID,
+			&i.Notes,
+			&i.ExerciseID,
+			&i.Seq,
+			&i.ExerciseName,
 		); err != nil {
 			return nil, err
 		}
@@ -194,30 +233,31 @@ func (q *Queries) ListLifts(ctx context.Context, arg ListLiftsParams) ([]Lift, e
 }
 
 const listPRs = `-- name: ListPRs :many
-SELECT id, exercise_name, weight_lifted, reps, user_id, workout_id FROM lift
+SELECT id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq FROM lift
 WHERE user_id = $1
-ORDER BY
-  CASE
-    WHEN $2 = 'weight' THEN weight_lifted
-    WHEN $3 = 'reps' THEN reps
-END DESC
-LIMIT $4
-OFFSET $5
+AND ($3::UUID IS NULL OR (
+  CASE WHEN $2::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END, id
+) < ($4::REAL, $3::UUID))
+ORDER BY CASE WHEN $2::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END DESC, id DESC
+LIMIT $5
+OFFSET $6
 `
 
 type ListPRsParams struct {
-	UserID  uuid.UUID   `json:"user_id"`
-	Column2 interface{} `json:"column_2"`
-	Column3 interface{} `json:"column_3"`
-	Limit   int32       `json:"limit"`
-	Offset  int32       `json:"offset"`
+	UserID     uuid.UUID     `json:"user_id"`
+	OrderBy    string        `json:"order_by"`
+	AfterID    uuid.NullUUID `json:"after_id"`
+	AfterValue float32       `json:"after_value"`
+	Limit      int32         `json:"limit"`
+	Offset     int32         `json:"offset"`
 }
 
 func (q *Queries) ListPRs(ctx context.Context, arg ListPRsParams) ([]Lift, error) {
 	rows, err := q.db.QueryContext(ctx, listPRs,
 		arg.UserID,
-		arg.Column2,
-		arg.Column3,
+		arg.OrderBy,
+		arg.AfterID,
+		arg.AfterValue,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -230,11 +270,13 @@ func (q *Queries) ListPRs(ctx context.Context, arg ListPRsParams) ([]Lift, error
 		var i Lift
 		if err := rows.Scan(
 			&i.ID,
-			&i.ExerciseName,
 			&i.WeightLifted,
 			&i.Reps,
 			&i.UserID,
 			&i.WorkoutID,
+			&i.Notes,
+			&i.ExerciseID,
+			&i.Seq,
 		); err != nil {
 			return nil, err
 		}
@@ -250,32 +292,33 @@ func (q *Queries) ListPRs(ctx context.Context, arg ListPRsParams) ([]Lift, error
 }
 
 const listPRsByExercise = `-- name: ListPRsByExercise :many
-SELECT id, exercise_name, weight_lifted, reps, user_id, workout_id FROM lift
-WHERE user_id = $1 AND exercise_name = $2
-ORDER BY
-  CASE
-    WHEN $3 = 'weight' THEN weight_lifted
-    WHEN $4 = 'reps' THEN reps 
-END DESC
-LIMIT $5
-OFFSET $6
+SELECT id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq FROM lift
+WHERE user_id = $1 AND exercise_id = $2
+AND ($4::UUID IS NULL OR (
+  CASE WHEN $3::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END, id
+) < ($5::REAL, $4::UUID))
+ORDER BY CASE WHEN $3::TEXT = 'reps' THEN reps::REAL ELSE weight_lifted END DESC, id DESC
+LIMIT $6
+OFFSET $7
 `
 
 type ListPRsByExerciseParams struct {
-	UserID       uuid.UUID   `json:"user_id"`
-	ExerciseName string      `json:"exercise_name"`
-	Column3      interface{} `json:"column_3"`
-	Column4      interface{} `json:"column_4"`
-	Limit        int32       `json:"limit"`
-	Offset       int32       `json:"offset"`
+	UserID     uuid.UUID     `json:"user_id"`
+	ExerciseID int32         `json:"exercise_id"`
+	OrderBy    string        `json:"order_by"`
+	AfterID    uuid.NullUUID `json:"after_id"`
+	AfterValue float32       `json:"after_value"`
+	Limit      int32         `json:"limit"`
+	Offset     int32         `json:"offset"`
 }
 
 func (q *Queries) ListPRsByExercise(ctx context.Context, arg ListPRsByExerciseParams) ([]Lift, error) {
 	rows, err := q.db.QueryContext(ctx, listPRsByExercise,
 		arg.UserID,
-		arg.ExerciseName,
-		arg.Column3,
-		arg.Column4,
+		arg.ExerciseID,
+		arg.OrderBy,
+		arg.AfterID,
+		arg.AfterValue,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -288,11 +331,13 @@ func (q *Queries) ListPRsByExercise(ctx context.Context, arg ListPRsByExercisePa
 		var i Lift
 		if err := rows.Scan(
 			&i.ID,
-			&i.ExerciseName,
 			&i.WeightLifted,
 			&i.Reps,
 			&i.UserID,
 			&i.WorkoutID,
+			&i.Notes,
+			&i.ExerciseID,
+			&i.Seq,
 		); err != nil {
 			return nil, err
 		}
@@ -308,42 +353,47 @@ func (q *Queries) ListPRsByExercise(ctx context.Context, arg ListPRsByExercisePa
 }
 
 const listPRsByMuscleGroup = `-- name: ListPRsByMuscleGroup :many
-SELECT l.id, l.exercise_name, weight_lifted, reps, ex.muscle_group FROM lift AS l
-JOIN exercise AS ex on l.exercise_name = ex.name
-WHERE ex.muscle_group = $1
+SELECT l.id, l.exercise_id, e.name AS exercise_name, weight_lifted, reps, emg.muscle_group, emg.is_primary, emg.contribution FROM lift AS l
+JOIN exercise AS e ON e.id = l.exercise_id
+JOIN exercise_muscle_group AS emg on e.name = emg.exercise_name
+WHERE emg.muscle_group = $1
 AND l.user_id = $2
-ORDER BY
-  CASE
-    WHEN $3 = 'weight' THEN weight_lifted
-    WHEN $4 = 'reps' THEN reps
-END DESC
-LIMIT $5
-OFFSET $6
+AND ($4::UUID IS NULL OR (
+  CASE WHEN $3::TEXT = 'reps' THEN l.reps::REAL ELSE l.weight_lifted END, l.id
+) < ($5::REAL, $4::UUID))
+ORDER BY CASE WHEN $3::TEXT = 'reps' THEN l.reps::REAL ELSE l.weight_lifted END DESC, l.id DESC
+LIMIT $6
+OFFSET $7
 `
 
 type ListPRsByMuscleGroupParams struct {
-	MuscleGroup string      `json:"muscle_group"`
-	UserID      uuid.UUID   `json:"user_id"`
-	Column3     interface{} `json:"column_3"`
-	Column4     interface{} `json:"column_4"`
-	Limit       int32       `json:"limit"`
-	Offset      int32       `json:"offset"`
+	MuscleGroup string        `json:"muscle_group"`
+	UserID      uuid.UUID     `json:"user_id"`
+	OrderBy     string        `json:"order_by"`
+	AfterID     uuid.NullUUID `json:"after_id"`
+	AfterValue  float32       `json:"after_value"`
+	Limit       int32         `json:"limit"`
+	Offset      int32         `json:"offset"`
 }
 
 type ListPRsByMuscleGroupRow struct {
 	ID           uuid.UUID `json:"id"`
+	ExerciseID   int32     `json:"exercise_id"`
 	ExerciseName string    `json:"exercise_name"`
 	WeightLifted float32   `json:"weight_lifted"`
 	Reps         int16     `json:"reps"`
 	MuscleGroup  string    `json:"muscle_group"`
+	IsPrimary    bool      `json:"is_primary"`
+	Contribution float32   `json:"contribution"`
 }
 
 func (q *Queries) ListPRsByMuscleGroup(ctx context.Context, arg ListPRsByMuscleGroupParams) ([]ListPRsByMuscleGroupRow, error) {
 	rows, err := q.db.QueryContext(ctx, listPRsByMuscleGroup,
 		arg.MuscleGroup,
 		arg.UserID,
-		arg.Column3,
-		arg.Column4,
+		arg.OrderBy,
+		arg.AfterID,
+		arg.AfterValue,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -356,10 +406,121 @@ func (q *Queries) ListPRsByMuscleGroup(ctx context.Context, arg ListPRsByMuscleG
 		var i ListPRsByMuscleGroupRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.ExerciseID,
 			&i.ExerciseName,
 			&i.WeightLifted,
 			&i.Reps,
 			&i.MuscleGroup,
+			&i.IsPrimary,
+			&i.Contribution,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkoutLifts = `-- name: ListWorkoutLifts :many
+SELECT id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq FROM lift
+WHERE workout_id = $1
+ORDER BY seq
+`
+
+func (q *Queries) ListWorkoutLifts(ctx context.Context, workoutID uuid.UUID) ([]Lift, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkoutLifts, workoutID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Lift{}
+	for rows.Next() {
+		var i Lift
+		if err := rows.Scan(
+			&i.ID,
+			&i.WeightLifted,
+			&i.Reps,
+			&i.UserID,
+			&i.WorkoutID,
+			&i.Notes,
+			&i.ExerciseID,
+			&i.Seq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignLifts = `-- name: ReassignLifts :execrows
+UPDATE lift SET exercise_id = $1
+WHERE exercise_id = $2
+`
+
+type ReassignLiftsParams struct {
+	Target int32 `json:"target"`
+	Source int32 `json:"source"`
+}
+
+func (q *Queries) ReassignLifts(ctx context.Context, arg ReassignLiftsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignLifts, arg.Target, arg.Source)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const swapWorkoutExercise = `-- name: SwapWorkoutExercise :many
+UPDATE lift SET exercise_id = $1
+WHERE workout_id = $2
+AND user_id = $3
+AND exercise_id = $4
+RETURNING id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq
+`
+
+type SwapWorkoutExerciseParams struct {
+	Target    int32     `json:"target"`
+	WorkoutID uuid.UUID `json:"workout_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Source    int32     `json:"source"`
+}
+
+func (q *Queries) SwapWorkoutExercise(ctx context.Context, arg SwapWorkoutExerciseParams) ([]Lift, error) {
+	rows, err := q.db.QueryContext(ctx, swapWorkoutExercise,
+		arg.Target,
+		arg.WorkoutID,
+		arg.UserID,
+		arg.Source,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Lift{}
+	for rows.Next() {
+		var i Lift
+		if err := rows.Scan(
+			&i.ID,
+			&i.WeightLifted,
+			&i.Reps,
+			&i.UserID,
+			&i.WorkoutID,
+			&i.Notes,
+			&i.ExerciseID,
+			&i.Seq,
 		); err != nil {
 			return nil, err
 		}
@@ -376,28 +537,37 @@ func (q *Queries) ListPRsByMuscleGroup(ctx context.Context, arg ListPRsByMuscleG
 
 const updateLift = `-- name: UpdateLift :one
 UPDATE lift SET
-weight_lifted = COALESCE(NULLIF($1, 0::REAL), weight_lifted),
-reps = COALESCE(NULLIF($2, 0::SMALLINT), reps)
-WHERE id = $3
-RETURNING id, exercise_name, weight_lifted, reps, user_id, workout_id
+weight_lifted = COALESCE($1, weight_lifted),
+reps = COALESCE($2, reps),
+notes = COALESCE($3, notes)
+WHERE id = $4
+RETURNING id, weight_lifted, reps, user_id, workout_id, notes, exercise_id, seq
 `
 
 type UpdateLiftParams struct {
-	Column1 interface{} `json:"column_1"`
-	Column2 interface{} `json:"column_2"`
-	ID      uuid.UUID   `json:"id"`
+	WeightLifted sql.NullFloat64 `json:"weight_lifted"`
+	Reps         sql.NullInt16   `json:"reps"`
+	Notes        sql.NullString  `json:"notes"`
+	ID           uuid.UUID       `json:"id"`
 }
 
 func (q *Queries) UpdateLift(ctx context.Context, arg UpdateLiftParams) (Lift, error) {
-	row := q.db.QueryRowContext(ctx, updateLift, arg.Column1, arg.Column2, arg.ID)
+	row := q.db.QueryRowContext(ctx, updateLift,
+		arg.WeightLifted,
+		arg.Reps,
+		arg.Notes,
+		arg.ID,
+	)
 	var i Lift
 	err := row.Scan(
 		&i.ID,
-		&i.ExerciseName,
 		&i.WeightLifted,
 		&i.Reps,
 		&i.UserID,
 		&i.WorkoutID,
+		&i.Notes,
+		&i.ExerciseID,
+		&i.Seq,
 	)
 	return i, err
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"strconv"
 	"testing"
 
@@ -15,7 +16,7 @@ func GenerateRandLift(t *testing.T) Lift {
 	workout := GenerateRandWorkout(t)
 
 	lift, err := testQueries.CreateLift(context.Background(), CreateLiftParams{
-		ExerciseName: exercise.Name,
+		ExerciseID:   exercise.ID,
 		WeightLifted: float32(util.RandomInt(100, 250)),
 		Reps:         int16(util.RandomInt(4, 12)),
 		UserID:       workout.UserID,
@@ -28,7 +29,7 @@ func GenerateRandLift(t *testing.T) Lift {
 	require.NotNil(t, lift.UserID)
 	require.NotNil(t, lift.Reps)
 	require.NotNil(t, lift.WeightLifted)
-	require.NotNil(t, lift.ExerciseName)
+	require.NotZero(t, lift.ExerciseID)
 	return lift
 }
 
@@ -40,11 +41,12 @@ func TestCreateLifts(t *testing.T) {
 	n := 5
 
 	allLifts := CreateLiftsParams{
-		Exercisenames: make([]string, n),
-		UserID:        make([]uuid.UUID, n),
-		WorkoutID:     make([]uuid.UUID, n),
-		Reps:          make([]int16, n),
-		Weights:       make([]float32, n),
+		ExerciseIds: make([]int32, n),
+		UserID:      make([]uuid.UUID, n),
+		WorkoutID:   make([]uuid.UUID, n),
+		Reps:        make([]int16, n),
+		Weights:     make([]float32, n),
+		Notes:       make([]string, n),
 	}
 
 	workout := GenerateRandWorkout(t)
@@ -56,7 +58,7 @@ func TestCreateLifts(t *testing.T) {
 		allLifts.WorkoutID[i] = workout.ID
 		allLifts.Reps[i] = int16(util.RandomInt(6, 12))
 		allLifts.Weights[i] = float32(util.RandomInt(100, 220))
-		allLifts.Exercisenames[i] = ex.Name
+		allLifts.ExerciseIds[i] = ex.ID
 	}
 
 	lifts, err := testQueries.CreateLifts(context.Background(), allLifts)
@@ -66,7 +68,7 @@ func TestCreateLifts(t *testing.T) {
 	for i, v := range lifts {
 		require.Equal(t, allLifts.UserID[i], workout.UserID)
 		require.Equal(t, allLifts.WorkoutID[i], workout.ID)
-		require.Equal(t, allLifts.Exercisenames[i], v.ExerciseName)
+		require.Equal(t, allLifts.ExerciseIds[i], v.ExerciseID)
 		require.Equal(t, allLifts.Weights[i], v.WeightLifted)
 		require.Equal(t, allLifts.Reps[i], v.Reps)
 	}
@@ -82,7 +84,7 @@ func TestGetLift(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, query)
 	require.Equal(t, lift.ID, query.ID)
-	require.Equal(t, lift.ExerciseName, query.ExerciseName)
+	require.Equal(t, lift.ExerciseID, query.ExerciseID)
 	require.Equal(t, lift.WeightLifted, query.WeightLifted)
 	require.Equal(t, lift.Reps, query.Reps)
 	require.Equal(t, lift.UserID, query.UserID)
@@ -99,7 +101,7 @@ func TestListLifts(t *testing.T) {
 			WorkoutID:    workout.ID,
 			Reps:         int16(5 + i),
 			WeightLifted: float32(150 + i),
-			ExerciseName: exercise.Name,
+			ExerciseID:   exercise.ID,
 		})
 		require.NoError(t, err)
 	}
@@ -112,6 +114,24 @@ func TestListLifts(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, query, n)
 
+	first, err := testQueries.ListLifts(context.Background(), ListLiftsParams{
+		UserID: workout.UserID,
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	last := first[len(first)-1]
+	rest, err := testQueries.ListLifts(context.Background(), ListLiftsParams{
+		UserID:    workout.UserID,
+		AfterID:   uuid.NullUUID{UUID: last.ID, Valid: true},
+		AfterName: last.ExerciseName,
+		Limit:     int32(n),
+	})
+	require.NoError(t, err)
+	require.Len(t, rest, n-2)
+	require.Equal(t, query[2:], rest)
+
 	for _, v := range query {
 		require.NotNil(t, v.UserID)
 		require.NotNil(t, v.ExerciseName)
@@ -134,7 +154,7 @@ func TestListPRs(t *testing.T) {
 		reps[i] = int16(12 - i)
 		weight[i] = float32(200 - i)
 		_, err := testQueries.CreateLift(context.Background(), CreateLiftParams{
-			ExerciseName: exercise.Name,
+			ExerciseID:   exercise.ID,
 			WorkoutID:    workout.ID,
 			UserID:       workout.UserID,
 			WeightLifted: float32(weight[i]),
@@ -145,7 +165,7 @@ func TestListPRs(t *testing.T) {
 
 	orderByWeight, err := testQueries.ListPRs(context.Background(), ListPRsParams{
 		UserID:  workout.UserID,
-		Column3: "weight",
+		OrderBy: "weight",
 		Limit:   int32(n),
 		Offset:  0,
 	})
@@ -154,13 +174,13 @@ func TestListPRs(t *testing.T) {
 
 	for i, v := range orderByWeight {
 		require.Equal(t, weight[i], v.WeightLifted)
-		require.Equal(t, exercise.Name, v.ExerciseName)
+		require.Equal(t, exercise.ID, v.ExerciseID)
 		require.Equal(t, workout.ID, v.WorkoutID)
 	}
 
 	orderByReps, err := testQueries.ListPRs(context.Background(), ListPRsParams{
 		UserID:  workout.UserID,
-		Column3: "weight",
+		OrderBy: "weight",
 		Limit:   int32(n),
 		Offset:  0,
 	})
@@ -188,7 +208,7 @@ func TestListPRsByExercise(t *testing.T) {
 		reps[i] = int16(12 - i)
 		weight[i] = float32(200 - i)
 		_, err := testQueries.CreateLift(context.Background(), CreateLiftParams{
-			ExerciseName: exercise.Name,
+			ExerciseID:   exercise.ID,
 			WorkoutID:    workout.ID,
 			UserID:       workout.UserID,
 			WeightLifted: float32(weight[i]),
@@ -198,27 +218,27 @@ func TestListPRsByExercise(t *testing.T) {
 	}
 
 	orderByWeight, err := testQueries.ListPRsByExercise(context.Background(), ListPRsByExerciseParams{
-		ExerciseName: exercise.Name,
-		UserID:       workout.UserID,
-		Column3:      "weight",
-		Limit:        int32(n),
-		Offset:       0,
+		ExerciseID: exercise.ID,
+		UserID:     workout.UserID,
+		OrderBy:    "weight",
+		Limit:      int32(n),
+		Offset:     0,
 	})
 	require.NoError(t, err)
 	require.Len(t, orderByWeight, n)
 
 	for i, v := range orderByWeight {
 		require.Equal(t, weight[i], v.WeightLifted)
-		require.Equal(t, exercise.Name, v.ExerciseName)
+		require.Equal(t, exercise.ID, v.ExerciseID)
 		require.Equal(t, workout.ID, v.WorkoutID)
 	}
 
 	orderByReps, err := testQueries.ListPRsByExercise(context.Background(), ListPRsByExerciseParams{
-		ExerciseName: exercise.Name,
-		UserID:       workout.UserID,
-		Column3:      "weight",
-		Limit:        int32(n),
-		Offset:       0,
+		ExerciseID: exercise.ID,
+		UserID:     workout.UserID,
+		OrderBy:    "weight",
+		Limit:      int32(n),
+		Offset:     0,
 	})
 	require.NoError(t, err)
 	require.Len(t, orderByReps, n)
@@ -245,7 +265,7 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 		reps[i] = int16(12 - i)
 		weight[i] = float32(200 - i)
 		_, err := testQueries.CreateLift(context.Background(), CreateLiftParams{
-			ExerciseName: exercise.Name,
+			ExerciseID:   exercise.ID,
 			WorkoutID:    workout.ID,
 			UserID:       workout.UserID,
 			WeightLifted: float32(weight[i]),
@@ -254,7 +274,7 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = testQueries.CreateLift(context.Background(), CreateLiftParams{
-			ExerciseName: exercise_2.Name,
+			ExerciseID:   exercise_2.ID,
 			WorkoutID:    workout.ID,
 			UserID:       workout.UserID,
 			WeightLifted: float32(weight[i]),
@@ -266,7 +286,7 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 	orderByWeightGroup1, err := testQueries.ListPRsByMuscleGroup(context.Background(), ListPRsByMuscleGroupParams{
 		MuscleGroup: exercise.MuscleGroup,
 		UserID:      workout.UserID,
-		Column3:     "weight",
+		OrderBy:     "weight",
 		Offset:      0,
 		Limit:       int32(n),
 	})
@@ -280,7 +300,7 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 	orderByWeightGroup2, err := testQueries.ListPRsByMuscleGroup(context.Background(), ListPRsByMuscleGroupParams{
 		MuscleGroup: exercise_2.MuscleGroup,
 		UserID:      workout.UserID,
-		Column3:     "weight",
+		OrderBy:     "weight",
 		Offset:      0,
 		Limit:       int32(n),
 	})
@@ -294,7 +314,7 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 	orderByRepsGroup1, err := testQueries.ListPRsByMuscleGroup(context.Background(), ListPRsByMuscleGroupParams{
 		MuscleGroup: exercise.MuscleGroup,
 		UserID:      workout.UserID,
-		Column3:     "reps",
+		OrderBy:     "reps",
 		Offset:      0,
 		Limit:       int32(n),
 	})
@@ -307,7 +327,7 @@ func TestListPRsByMuscleGroup(t *testing.T) {
 	orderByRepsGroup2, err := testQueries.ListPRsByMuscleGroup(context.Background(), ListPRsByMuscleGroupParams{
 		MuscleGroup: exercise_2.MuscleGroup,
 		UserID:      workout.UserID,
-		Column3:     "reps",
+		OrderBy:     "reps",
 		Offset:      0,
 		Limit:       int32(n),
 	})
@@ -327,9 +347,8 @@ func TestUpdateLift(t *testing.T) {
 	weightLift := GenerateRandLift(t)
 
 	patchWeightRes, err := testQueries.UpdateLift(context.Background(), UpdateLiftParams{
-		ID:      weightLift.ID,
-		Column1: patchedWeightStr,
-		Column2: 0,
+		ID:           weightLift.ID,
+		WeightLifted: sql.NullFloat64{Float64: patchWeightVal, Valid: true},
 	})
 	require.NoError(t, err)
 	require.Equal(t, float32(patchWeightVal), patchWeightRes.WeightLifted)
@@ -338,13 +357,31 @@ func TestUpdateLift(t *testing.T) {
 	repLift := GenerateRandLift(t)
 
 	patchedRepRes, err := testQueries.UpdateLift(context.Background(), UpdateLiftParams{
-		ID:      repLift.ID,
-		Column1: 0,
-		Column2: patchedRepsStr,
+		ID:   repLift.ID,
+		Reps: sql.NullInt16{Int16: int16(patchedRepsVal), Valid: true},
 	})
 	require.NoError(t, err)
 	require.Equal(t, int16(patchedRepsVal), patchedRepRes.Reps)
 	require.Equal(t, repLift.WeightLifted, patchedRepRes.WeightLifted)
+
+	notesLift := GenerateRandLift(t)
+	notes := util.RandomString(20)
+
+	patchedNotesRes, err := testQueries.UpdateLift(context.Background(), UpdateLiftParams{
+		ID:    notesLift.ID,
+		Notes: sql.NullString{String: notes, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, notes, patchedNotesRes.Notes)
+	require.Equal(t, notesLift.Reps, patchedNotesRes.Reps)
+	require.Equal(t, notesLift.WeightLifted, patchedNotesRes.WeightLifted)
+
+	clearedNotesRes, err := testQueries.UpdateLift(context.Background(), UpdateLiftParams{
+		ID:    notesLift.ID,
+		Notes: sql.NullString{String: "", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Empty(t, clearedNotesRes.Notes)
 }
 
 func TestDeleteLift(t *testing.T) {
@@ -359,3 +396,30 @@ func TestDeleteLift(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestSwapWorkoutExercise(t *testing.T) {
+	lift := GenerateRandLift(t)
+	target := GenerateRandomExercise(t)
+
+	swapped, err := testQueries.SwapWorkoutExercise(context.Background(), SwapWorkoutExerciseParams{
+		Target:    target.ID,
+		WorkoutID: lift.WorkoutID,
+		UserID:    uuid.New(),
+		Source:    lift.ExerciseID,
+	})
+	require.NoError(t, err)
+	require.Empty(t, swapped)
+
+	swapped, err = testQueries.SwapWorkoutExercise(context.Background(), SwapWorkoutExerciseParams{
+		Target:    target.ID,
+		WorkoutID: lift.WorkoutID,
+		UserID:    lift.UserID,
+		Source:    lift.ExerciseID,
+	})
+	require.NoError(t, err)
+	require.Len(t, swapped, 1)
+	require.Equal(t, lift.ID, swapped[0].ID)
+	require.Equal(t, target.ID, swapped[0].ExerciseID)
+	require.Equal(t, lift.WeightLifted, swapped[0].WeightLifted)
+	require.Equal(t, lift.Reps, swapped[0].Reps)
+}
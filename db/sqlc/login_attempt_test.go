@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordLoginFailure(t *testing.T) {
+	key := "account:" + util.RandomString(10)
+	now := time.Now().UTC()
+
+	_, err := testQueries.GetLoginAttempt(context.Background(), key)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	args := RecordLoginFailureParams{
+		Key:         key,
+		Now:         now,
+		ResetBefore: now.Add(-time.Hour),
+	}
+
+	attempt, err := testQueries.RecordLoginFailure(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), attempt.Failures)
+
+	// concurrent failures are all counted
+	n := 5
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testQueries.RecordLoginFailure(context.Background(), args)
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	got, err := testQueries.GetLoginAttempt(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, int32(6), got.Failures)
+
+	err = testQueries.LockLoginAttempt(context.Background(), LockLoginAttemptParams{
+		LockedUntil: now.Add(time.Minute),
+		Key:         key,
+	})
+	require.NoError(t, err)
+
+	// an earlier lockout never shortens a later one
+	err = testQueries.LockLoginAttempt(context.Background(), LockLoginAttemptParams{
+		LockedUntil: now.Add(time.Second),
+		Key:         key,
+	})
+	require.NoError(t, err)
+
+	got, err = testQueries.GetLoginAttempt(context.Background(), key)
+	require.NoError(t, err)
+	require.WithinDuration(t, now.Add(time.Minute), got.LockedUntil, time.Second)
+
+	// a failure after the reset window starts counting again
+	later := now.Add(2 * time.Hour)
+	attempt, err = testQueries.RecordLoginFailure(context.Background(), RecordLoginFailureParams{
+		Key:         key,
+		Now:         later,
+		ResetBefore: later.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), attempt.Failures)
+
+	err = testQueries.DeleteLoginAttempt(context.Background(), key)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetLoginAttempt(context.Background(), key)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandAccountMFA(t *testing.T, account Account) AccountMfa {
+	secret, err := util.NewTOTPSecret()
+	require.NoError(t, err)
+
+	mfa, err := testQueries.UpsertAccountMFA(context.Background(), UpsertAccountMFAParams{
+		UserID: account.ID,
+		Secret: secret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, mfa.UserID)
+	require.Equal(t, secret, mfa.Secret)
+	require.False(t, mfa.ConfirmedAt.Valid)
+
+	return mfa
+}
+
+func TestUpsertAccountMFA(t *testing.T) {
+	account := GenerateRandAccount(t)
+	first := GenerateRandAccountMFA(t, account)
+
+	err := testQueries.ConfirmAccountMFA(context.Background(), ConfirmAccountMFAParams{
+		ConfirmedAt:  sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		LastUsedStep: 10,
+		UserID:       account.ID,
+	})
+	require.NoError(t, err)
+
+	second := GenerateRandAccountMFA(t, account)
+	require.NotEqual(t, first.Secret, second.Secret)
+	require.Zero(t, second.LastUsedStep)
+
+	got, err := testQueries.GetAccountMFA(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.Secret, got.Secret)
+}
+
+func TestUpdateMFALastUsedStep(t *testing.T) {
+	account := GenerateRandAccount(t)
+	GenerateRandAccountMFA(t, account)
+
+	n, err := testQueries.UpdateMFALastUsedStep(context.Background(), UpdateMFALastUsedStepParams{Step: 5, UserID: account.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = testQueries.UpdateMFALastUsedStep(context.Background(), UpdateMFALastUsedStepParams{Step: 5, UserID: account.ID})
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	account := GenerateRandAccount(t)
+	hash := util.HashSecret(util.RandomString(10))
+
+	err := testQueries.CreateRecoveryCode(context.Background(), CreateRecoveryCodeParams{CodeHash: hash, UserID: account.ID})
+	require.NoError(t, err)
+
+	args := UseRecoveryCodeParams{Now: time.Now().UTC(), CodeHash: hash, UserID: GenerateRandAccount(t).ID}
+	n, err := testQueries.UseRecoveryCode(context.Background(), args)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	args.UserID = account.ID
+	n, err = testQueries.UseRecoveryCode(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = testQueries.UseRecoveryCode(context.Background(), args)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
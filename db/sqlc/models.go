@@ -5,46 +5,182 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID                uuid.UUID `json:"id"`
-	Name              string    `json:"name"`
-	Email             string    `json:"email"`
-	Password          string    `json:"password"`
-	PasswordChangedAt time.Time `json:"password_changed_at"`
-	Weight            float32   `json:"weight"`
-	BodyFat           float32   `json:"body_fat"`
-	StartDate         time.Time `json:"start_date"`
+	ID                uuid.UUID       `json:"id"`
+	Name              string          `json:"name"`
+	Email             string          `json:"email"`
+	Password          string          `json:"password"`
+	PasswordChangedAt time.Time       `json:"password_changed_at"`
+	Weight            float32         `json:"weight"`
+	BodyFat           float32         `json:"body_fat"`
+	StartDate         time.Time       `json:"start_date"`
+	EmailVerifiedAt   sql.NullTime    `json:"email_verified_at"`
+	DateOfBirth       sql.NullTime    `json:"date_of_birth"`
+	Height            sql.NullFloat64 `json:"height"`
+	Preferences       json.RawMessage `json:"preferences"`
+}
+
+type AccountIdentity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AccountMfa struct {
+	UserID       uuid.UUID    `json:"user_id"`
+	Secret       string       `json:"secret"`
+	ConfirmedAt  sql.NullTime `json:"confirmed_at"`
+	LastUsedStep int64        `json:"last_used_step"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"key_hash"`
+	Scopes     []string     `json:"scopes"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type CatalogExercise struct {
+	Name            string `json:"name"`
+	MuscleGroup     string `json:"muscle_group"`
+	Category        string `json:"category"`
+	Equipment       string `json:"equipment"`
+	MovementPattern string `json:"movement_pattern"`
+	Version         int32  `json:"version"`
+}
+
+type CatalogVersion struct {
+	Version   int32     `json:"version"`
+	AppliedAt time.Time `json:"applied_at"`
 }
 
 type Category struct {
 	ID   int16  `json:"id"`
 	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type EmailVerification struct {
+	TokenHash string       `json:"token_hash"`
+	UserID    uuid.UUID    `json:"user_id"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type EquipmentSetting struct {
+	UserID    uuid.UUID `json:"user_id"`
+	BarWeight float32   `json:"bar_weight"`
 }
 
 type Exercise struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	MuscleGroup string `json:"muscle_group"`
-	Category    string `json:"category"`
+	ID              int32  `json:"id"`
+	Name            string `json:"name"`
+	MuscleGroup     string `json:"muscle_group"`
+	Category        string `json:"category"`
+	Archived        bool   `json:"archived"`
+	Equipment       string `json:"equipment"`
+	MovementPattern string `json:"movement_pattern"`
+	Slug            string `json:"slug"`
+}
+
+type ExerciseAlias struct {
+	ID           int32  `json:"id"`
+	ExerciseName string `json:"exercise_name"`
+	Alias        string `json:"alias"`
+}
+
+type ExerciseMuscleGroup struct {
+	ExerciseName string  `json:"exercise_name"`
+	MuscleGroup  string  `json:"muscle_group"`
+	IsPrimary    bool    `json:"is_primary"`
+	Contribution float32 `json:"contribution"`
+}
+
+type GymProfile struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Equipment []string  `json:"equipment"`
 }
 
 type Lift struct {
 	ID           uuid.UUID `json:"id"`
-	ExerciseName string    `json:"exercise_name"`
 	WeightLifted float32   `json:"weight_lifted"`
 	Reps         int16     `json:"reps"`
 	UserID       uuid.UUID `json:"user_id"`
 	WorkoutID    uuid.UUID `json:"workout_id"`
+	Notes        string    `json:"notes"`
+	ExerciseID   int32     `json:"exercise_id"`
+	Seq          int64     `json:"seq"`
+}
+
+type LoginAttempt struct {
+	Key         string    `json:"key"`
+	Failures    int32     `json:"failures"`
+	LastFailure time.Time `json:"last_failure"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type MfaRecoveryCode struct {
+	CodeHash string       `json:"code_hash"`
+	UserID   uuid.UUID    `json:"user_id"`
+	UsedAt   sql.NullTime `json:"used_at"`
 }
 
 type MuscleGroup struct {
 	ID   int16  `json:"id"`
 	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type OidcLogin struct {
+	StateHash    string    `json:"state_hash"`
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PasswordReset struct {
+	TokenHash string       `json:"token_hash"`
+	UserID    uuid.UUID    `json:"user_id"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type PlateInventory struct {
+	UserID uuid.UUID `json:"user_id"`
+	Weight float32   `json:"weight"`
+	Pairs  int16     `json:"pairs"`
+}
+
+type RevokedToken struct {
+	TokenID   uuid.UUID `json:"token_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type TokenCutoff struct {
+	UserID        uuid.UUID `json:"user_id"`
+	RevokedBefore time.Time `json:"revoked_before"`
 }
 
 type Workout struct {
@@ -52,4 +188,7 @@ type Workout struct {
 	StartTime  time.Time `json:"start_time"`
 	FinishTime time.Time `json:"finish_time"`
 	UserID     uuid.UUID `json:"user_id"`
+	Notes      string    `json:"notes"`
+	Rating     int16     `json:"rating"`
+	Energy     int16     `json:"energy"`
 }
@@ -15,41 +15,65 @@ INSERT INTO muscle_group (
 ) VALUES (
   $1
 )
-RETURNING id, name
+RETURNING id, name, slug
 `
 
 func (q *Queries) CreateMuscleGroup(ctx context.Context, name string) (MuscleGroup, error) {
 	row := q.db.QueryRowContext(ctx, createMuscleGroup, name)
 	var i MuscleGroup
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
 	return i, err
 }
 
 const deleteGroup = `-- name: DeleteGroup :one
-DELETE FROM muscle_group WHERE name = $1 RETURNING id, name
+DELETE FROM muscle_group WHERE id = $1 RETURNING id, name, slug
 `
 
-func (q *Queries) DeleteGroup(ctx context.Context, name string) (MuscleGroup, error) {
-	row := q.db.QueryRowContext(ctx, deleteGroup, name)
+func (q *Queries) DeleteGroup(ctx context.Context, id int16) (MuscleGroup, error) {
+	row := q.db.QueryRowContext(ctx, deleteGroup, id)
 	var i MuscleGroup
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
 	return i, err
 }
 
 const getMuscleGroup = `-- name: GetMuscleGroup :one
-SELECT id, name FROM muscle_group
+SELECT id, name, slug FROM muscle_group
 WHERE name = $1
 `
 
 func (q *Queries) GetMuscleGroup(ctx context.Context, name string) (MuscleGroup, error) {
 	row := q.db.QueryRowContext(ctx, getMuscleGroup, name)
 	var i MuscleGroup
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
+	return i, err
+}
+
+const getMuscleGroupByID = `-- name: GetMuscleGroupByID :one
+SELECT id, name, slug FROM muscle_group
+WHERE id = $1
+`
+
+func (q *Queries) GetMuscleGroupByID(ctx context.Context, id int16) (MuscleGroup, error) {
+	row := q.db.QueryRowContext(ctx, getMuscleGroupByID, id)
+	var i MuscleGroup
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
+	return i, err
+}
+
+const getMuscleGroupBySlug = `-- name: GetMuscleGroupBySlug :one
+SELECT id, name, slug FROM muscle_group
+WHERE slug = $1
+`
+
+func (q *Queries) GetMuscleGroupBySlug(ctx context.Context, slug string) (MuscleGroup, error) {
+	row := q.db.QueryRowContext(ctx, getMuscleGroupBySlug, slug)
+	var i MuscleGroup
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
 	return i, err
 }
 
 const getMuscleGroups = `-- name: GetMuscleGroups :many
-SELECT id, name FROM muscle_group
+SELECT id, name, slug FROM muscle_group
 ORDER BY name
 `
 
@@ -62,7 +86,7 @@ func (q *Queries) GetMuscleGroups(ctx context.Context) ([]MuscleGroup, error) {
 	items := []MuscleGroup{}
 	for rows.Next() {
 		var i MuscleGroup
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.Slug); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -77,17 +101,17 @@ func (q *Queries) GetMuscleGroups(ctx context.Context) ([]MuscleGroup, error) {
 }
 
 const updateGroup = `-- name: UpdateGroup :one
-UPDATE muscle_group SET name = $1 WHERE name = $2 RETURNING id, name
+UPDATE muscle_group SET name = $1 WHERE id = $2 RETURNING id, name, slug
 `
 
 type UpdateGroupParams struct {
-	Name   string `json:"name"`
-	Name_2 string `json:"name_2"`
+	Name string `json:"name"`
+	ID   int16  `json:"id"`
 }
 
 func (q *Queries) UpdateGroup(ctx context.Context, arg UpdateGroupParams) (MuscleGroup, error) {
-	row := q.db.QueryRowContext(ctx, updateGroup, arg.Name, arg.Name_2)
+	row := q.db.QueryRowContext(ctx, updateGroup, arg.Name, arg.ID)
 	var i MuscleGroup
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.Slug)
 	return i, err
 }
@@ -47,7 +47,7 @@ func TestListMuscleGroups(t *testing.T) {
 	}
 
 	for i := 0; i < n; i++ {
-		_, _ = testQueries.DeleteGroup(context.Background(), muscleGroups[i].Name)
+		_, _ = testQueries.DeleteGroup(context.Background(), muscleGroups[i].ID)
 	}
 }
 
@@ -56,8 +56,8 @@ func TestUpdateMuscleGroup(t *testing.T) {
 	newName := util.RandomString(5)
 
 	patch, err := testQueries.UpdateGroup(context.Background(), UpdateGroupParams{
-		Name:   newName,
-		Name_2: muscleGroup.Name,
+		Name: newName,
+		ID:   muscleGroup.ID,
 	})
 	require.NoError(t, err)
 	require.Equal(t, newName, patch.Name)
@@ -66,7 +66,7 @@ func TestUpdateMuscleGroup(t *testing.T) {
 func TestDeleteMuscleGroup(t *testing.T) {
 	muscleGroup := GenerateRandMuscleGroup(t)
 
-	d, err := testQueries.DeleteGroup(context.Background(), muscleGroup.Name)
+	d, err := testQueries.DeleteGroup(context.Background(), muscleGroup.ID)
 	require.NoError(t, err)
 	require.NotEmpty(t, d)
 }
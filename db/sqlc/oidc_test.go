@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandOIDCLogin(t *testing.T, expiresAt time.Time) CreateOIDCLoginParams {
+	arg := CreateOIDCLoginParams{
+		StateHash:    util.HashSecret(util.RandomString(32)),
+		Provider:     "test",
+		CodeVerifier: util.RandomString(43),
+		Nonce:        util.RandomString(32),
+		ExpiresAt:    expiresAt,
+	}
+	require.NoError(t, testQueries.CreateOIDCLogin(context.Background(), arg))
+	return arg
+}
+
+func TestUseOIDCLogin(t *testing.T) {
+	now := time.Now().UTC()
+	arg := GenerateRandOIDCLogin(t, now.Add(10*time.Minute))
+
+	login, err := testQueries.UseOIDCLogin(context.Background(), UseOIDCLoginParams{StateHash: arg.StateHash, Now: now})
+	require.NoError(t, err)
+	require.Equal(t, arg.Provider, login.Provider)
+	require.Equal(t, arg.CodeVerifier, login.CodeVerifier)
+	require.Equal(t, arg.Nonce, login.Nonce)
+
+	// a state is only good once
+	_, err = testQueries.UseOIDCLogin(context.Background(), UseOIDCLoginParams{StateHash: arg.StateHash, Now: now})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	expired := GenerateRandOIDCLogin(t, now.Add(-time.Minute))
+	_, err = testQueries.UseOIDCLogin(context.Background(), UseOIDCLoginParams{StateHash: expired.StateHash, Now: now})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteExpiredOIDCLogins(t *testing.T) {
+	now := time.Now().UTC()
+	live := GenerateRandOIDCLogin(t, now.Add(10*time.Minute))
+	GenerateRandOIDCLogin(t, now.Add(-time.Minute))
+
+	n, err := testQueries.DeleteExpiredOIDCLogins(context.Background(), now)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, n, int64(1))
+
+	_, err = testQueries.UseOIDCLogin(context.Background(), UseOIDCLoginParams{StateHash: live.StateHash, Now: now})
+	require.NoError(t, err)
+}
+
+func TestAccountIdentity(t *testing.T) {
+	account := GenerateRandAccount(t)
+	arg := CreateAccountIdentityParams{
+		Provider: "test",
+		Subject:  util.RandomString(16),
+		UserID:   account.ID,
+		Email:    account.Email,
+	}
+
+	identity, err := testQueries.CreateAccountIdentity(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.UserID, identity.UserID)
+	require.NotZero(t, identity.CreatedAt)
+
+	got, err := testQueries.GetAccountIdentity(context.Background(), GetAccountIdentityParams{Provider: arg.Provider, Subject: arg.Subject})
+	require.NoError(t, err)
+	require.Equal(t, identity, got)
+
+	_, err = testQueries.GetAccountIdentity(context.Background(), GetAccountIdentityParams{Provider: "other", Subject: arg.Subject})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// a provider subject belongs to one account
+	_, err = testQueries.CreateAccountIdentity(context.Background(), arg)
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/stretchr/testify/require"
+)
+
+func GenerateRandPasswordReset(t *testing.T, account Account, expiresAt time.Time) PasswordReset {
+	args := CreatePasswordResetParams{
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		UserID:    account.ID,
+		ExpiresAt: expiresAt,
+	}
+
+	reset, err := testQueries.CreatePasswordReset(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, args.TokenHash, reset.TokenHash)
+	require.Equal(t, args.UserID, reset.UserID)
+	require.False(t, reset.UsedAt.Valid)
+
+	return reset
+}
+
+func TestCreatePasswordReset(t *testing.T) {
+	GenerateRandPasswordReset(t, GenerateRandAccount(t), time.Now().UTC().Add(time.Minute))
+}
+
+func TestUsePasswordReset(t *testing.T) {
+	account := GenerateRandAccount(t)
+	reset := GenerateRandPasswordReset(t, account, time.Now().UTC().Add(time.Minute))
+
+	args := UsePasswordResetParams{Now: time.Now().UTC(), TokenHash: reset.TokenHash}
+	userID, err := testQueries.UsePasswordReset(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, userID)
+
+	_, err = testQueries.UsePasswordReset(context.Background(), args)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	expired := GenerateRandPasswordReset(t, account, time.Now().UTC().Add(-time.Minute))
+	_, err = testQueries.UsePasswordReset(context.Background(), UsePasswordResetParams{Now: time.Now().UTC(), TokenHash: expired.TokenHash})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeletePasswordResets(t *testing.T) {
+	account := GenerateRandAccount(t)
+	reset := GenerateRandPasswordReset(t, account, time.Now().UTC().Add(time.Minute))
+
+	err := testQueries.DeletePasswordResets(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.UsePasswordReset(context.Background(), UsePasswordResetParams{Now: time.Now().UTC(), TokenHash: reset.TokenHash})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
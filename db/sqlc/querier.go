@@ -6,47 +6,126 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	ArchiveExercise(ctx context.Context, arg ArchiveExerciseParams) (Exercise, error)
+	ConfirmAccountMFA(ctx context.Context, arg ConfirmAccountMFAParams) error
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountIdentity(ctx context.Context, arg CreateAccountIdentityParams) (AccountIdentity, error)
+	CreateCatalogVersion(ctx context.Context, version int32) error
 	CreateCategory(ctx context.Context, name string) (Category, error)
+	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) (EmailVerification, error)
 	CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error)
+	CreateExerciseAlias(ctx context.Context, arg CreateExerciseAliasParams) (ExerciseAlias, error)
+	CreateGymProfile(ctx context.Context, arg CreateGymProfileParams) (GymProfile, error)
 	CreateLift(ctx context.Context, arg CreateLiftParams) (Lift, error)
 	CreateLifts(ctx context.Context, arg CreateLiftsParams) ([]Lift, error)
 	CreateMuscleGroup(ctx context.Context, name string) (MuscleGroup, error)
+	CreateOIDCLogin(ctx context.Context, arg CreateOIDCLoginParams) error
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreatePlates(ctx context.Context, arg CreatePlatesParams) ([]PlateInventory, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateSecondaryMuscleGroups(ctx context.Context, arg CreateSecondaryMuscleGroupsParams) ([]ExerciseMuscleGroup, error)
 	CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (Workout, error)
 	DeleteAccount(ctx context.Context, id uuid.UUID) (Account, error)
 	DeleteCategory(ctx context.Context, id int16) error
+	DeleteEmailVerifications(ctx context.Context, userID uuid.UUID) error
 	DeleteExercise(ctx context.Context, name string) error
-	DeleteGroup(ctx context.Context, name string) (MuscleGroup, error)
+	DeleteExerciseAlias(ctx context.Context, arg DeleteExerciseAliasParams) (int64, error)
+	DeleteExpiredOIDCLogins(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteExpiredRevokedTokens(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteGroup(ctx context.Context, id int16) (MuscleGroup, error)
+	DeleteGymProfile(ctx context.Context, arg DeleteGymProfileParams) (int64, error)
 	DeleteLift(ctx context.Context, id uuid.UUID) error
+	DeleteLoginAttempt(ctx context.Context, key string) error
+	DeletePasswordResets(ctx context.Context, userID uuid.UUID) error
+	DeletePlates(ctx context.Context, userID uuid.UUID) error
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+	DeleteSecondaryMuscleGroups(ctx context.Context, exerciseName string) error
 	DeleteWorkout(ctx context.Context, id uuid.UUID) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
 	GetAccountByEmail(ctx context.Context, email string) (GetAccountByEmailRow, error)
+	GetAccountIdentity(ctx context.Context, arg GetAccountIdentityParams) (AccountIdentity, error)
+	GetAccountMFA(ctx context.Context, userID uuid.UUID) (AccountMfa, error)
+	GetCatalogExercise(ctx context.Context, name string) (CatalogExercise, error)
+	GetCatalogVersion(ctx context.Context) (int32, error)
 	GetCategory(ctx context.Context, id int16) (Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
+	GetEmailVerifiedAt(ctx context.Context, id uuid.UUID) (sql.NullTime, error)
+	GetEquipmentSettings(ctx context.Context, userID uuid.UUID) (EquipmentSetting, error)
 	GetExercise(ctx context.Context, name string) (Exercise, error)
+	GetExerciseByID(ctx context.Context, id int32) (Exercise, error)
+	GetExerciseBySlug(ctx context.Context, slug string) (Exercise, error)
+	GetGymProfile(ctx context.Context, id uuid.UUID) (GymProfile, error)
+	GetLatestEmailVerification(ctx context.Context, userID uuid.UUID) (EmailVerification, error)
 	GetLift(ctx context.Context, arg GetLiftParams) (Lift, error)
+	GetLoginAttempt(ctx context.Context, key string) (LoginAttempt, error)
 	GetMuscleGroup(ctx context.Context, name string) (MuscleGroup, error)
+	GetMuscleGroupByID(ctx context.Context, id int16) (MuscleGroup, error)
+	GetMuscleGroupBySlug(ctx context.Context, slug string) (MuscleGroup, error)
 	GetMuscleGroups(ctx context.Context) ([]MuscleGroup, error)
+	GetPasswordChangedAt(ctx context.Context, id uuid.UUID) (time.Time, error)
 	GetWorkout(ctx context.Context, id uuid.UUID) ([]GetWorkoutRow, error)
+	IsTokenRevoked(ctx context.Context, arg IsTokenRevokedParams) (bool, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]ApiKey, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
 	ListByMuscleGroup(ctx context.Context, arg ListByMuscleGroupParams) ([]Exercise, error)
 	ListCategories(ctx context.Context) ([]Category, error)
+	ListExerciseAliases(ctx context.Context, exerciseName string) ([]ExerciseAlias, error)
+	ListExerciseMuscleGroups(ctx context.Context, exerciseName string) ([]ExerciseMuscleGroup, error)
+	ListExerciseNames(ctx context.Context) ([]ListExerciseNamesRow, error)
+	ListExerciseSubstitutes(ctx context.Context, arg ListExerciseSubstitutesParams) ([]ListExerciseSubstitutesRow, error)
 	ListExercises(ctx context.Context, arg ListExercisesParams) ([]Exercise, error)
-	ListLifts(ctx context.Context, arg ListLiftsParams) ([]Lift, error)
+	ListGymProfiles(ctx context.Context, userID uuid.UUID) ([]GymProfile, error)
+	ListLifts(ctx context.Context, arg ListLiftsParams) ([]ListLiftsRow, error)
+	ListMuscleGroupVolume(ctx context.Context, arg ListMuscleGroupVolumeParams) ([]ListMuscleGroupVolumeRow, error)
 	ListPRs(ctx context.Context, arg ListPRsParams) ([]Lift, error)
 	ListPRsByExercise(ctx context.Context, arg ListPRsByExerciseParams) ([]Lift, error)
 	ListPRsByMuscleGroup(ctx context.Context, arg ListPRsByMuscleGroupParams) ([]ListPRsByMuscleGroupRow, error)
+	ListPlates(ctx context.Context, userID uuid.UUID) ([]PlateInventory, error)
+	ListUserExercises(ctx context.Context, arg ListUserExercisesParams) ([]Exercise, error)
+	ListWorkoutLifts(ctx context.Context, workoutID uuid.UUID) ([]Lift, error)
 	ListWorkouts(ctx context.Context, arg ListWorkoutsParams) ([]Workout, error)
+	LockCatalog(ctx context.Context) error
+	LockLoginAttempt(ctx context.Context, arg LockLoginAttemptParams) error
+	ReassignAliases(ctx context.Context, arg ReassignAliasesParams) error
+	ReassignLifts(ctx context.Context, arg ReassignLiftsParams) (int64, error)
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginAttempt, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	RevokeAPIKeys(ctx context.Context, arg RevokeAPIKeysParams) (int64, error)
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	SearchExercises(ctx context.Context, arg SearchExercisesParams) ([]SearchExercisesRow, error)
+	SearchWorkouts(ctx context.Context, arg SearchWorkoutsParams) ([]Workout, error)
+	SeedCategories(ctx context.Context, names []string) error
+	SeedMuscleGroups(ctx context.Context, names []string) error
+	SwapWorkoutExercise(ctx context.Context, arg SwapWorkoutExerciseParams) ([]Lift, error)
+	UpdateAPIKeyLastUsed(ctx context.Context, arg UpdateAPIKeyLastUsedParams) error
+	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error
 	UpdateExercise(ctx context.Context, arg UpdateExerciseParams) (Exercise, error)
-	UpdateFinishTime(ctx context.Context, arg UpdateFinishTimeParams) (Workout, error)
 	UpdateGroup(ctx context.Context, arg UpdateGroupParams) (MuscleGroup, error)
+	UpdateGymProfile(ctx context.Context, arg UpdateGymProfileParams) (GymProfile, error)
 	UpdateLift(ctx context.Context, arg UpdateLiftParams) (Lift, error)
+	UpdateMFALastUsedStep(ctx context.Context, arg UpdateMFALastUsedStepParams) (int64, error)
+	UpdatePassword(ctx context.Context, arg UpdatePasswordParams) error
 	UpdateWeight(ctx context.Context, arg UpdateWeightParams) error
+	UpdateWorkout(ctx context.Context, arg UpdateWorkoutParams) (Workout, error)
+	UpsertAccountMFA(ctx context.Context, arg UpsertAccountMFAParams) (AccountMfa, error)
+	UpsertCatalogExercise(ctx context.Context, arg UpsertCatalogExerciseParams) error
+	UpsertEquipmentSettings(ctx context.Context, arg UpsertEquipmentSettingsParams) (EquipmentSetting, error)
+	UpsertTokenCutoff(ctx context.Context, arg UpsertTokenCutoffParams) error
+	UseEmailVerification(ctx context.Context, arg UseEmailVerificationParams) (uuid.UUID, error)
+	UseOIDCLogin(ctx context.Context, arg UseOIDCLoginParams) (OidcLogin, error)
+	UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (uuid.UUID, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	VerifyEmail(ctx context.Context, arg VerifyEmailParams) error
 }
 
 var _ Querier = (*Queries)(nil)
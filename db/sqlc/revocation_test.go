@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func checkRevoked(t *testing.T, tokenID, userID uuid.UUID, issuedAt time.Time) bool {
+	revoked, err := testQueries.IsTokenRevoked(context.Background(), IsTokenRevokedParams{
+		TokenID:       tokenID,
+		UserID:        userID,
+		RevokedBefore: issuedAt,
+	})
+	require.NoError(t, err)
+	return revoked
+}
+
+func TestRevokeToken(t *testing.T) {
+	account := GenerateRandAccount(t)
+	now := time.Now().UTC()
+	tokenID := uuid.New()
+
+	require.False(t, checkRevoked(t, tokenID, account.ID, now))
+
+	arg := RevokeTokenParams{TokenID: tokenID, UserID: account.ID, ExpiresAt: now.Add(time.Minute)}
+	require.NoError(t, testQueries.RevokeToken(context.Background(), arg))
+	// revoking twice is not an error
+	require.NoError(t, testQueries.RevokeToken(context.Background(), arg))
+
+	require.True(t, checkRevoked(t, tokenID, account.ID, now))
+	require.False(t, checkRevoked(t, uuid.New(), account.ID, now))
+}
+
+func TestUpsertTokenCutoff(t *testing.T) {
+	account := GenerateRandAccount(t)
+	now := time.Now().UTC()
+
+	err := testQueries.UpsertTokenCutoff(context.Background(), UpsertTokenCutoffParams{
+		UserID:        account.ID,
+		RevokedBefore: now,
+	})
+	require.NoError(t, err)
+
+	require.True(t, checkRevoked(t, uuid.New(), account.ID, now.Add(-time.Second)))
+	require.False(t, checkRevoked(t, uuid.New(), account.ID, now.Add(time.Second)))
+
+	// an older cutoff never moves the current one back
+	err = testQueries.UpsertTokenCutoff(context.Background(), UpsertTokenCutoffParams{
+		UserID:        account.ID,
+		RevokedBefore: now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, checkRevoked(t, uuid.New(), account.ID, now.Add(-time.Second)))
+}
+
+func TestDeleteExpiredRevokedTokens(t *testing.T) {
+	account := GenerateRandAccount(t)
+	now := time.Now().UTC()
+	expired, live := uuid.New(), uuid.New()
+
+	for tokenID, expiresAt := range map[uuid.UUID]time.Time{expired: now.Add(-time.Minute), live: now.Add(time.Minute)} {
+		err := testQueries.RevokeToken(context.Background(), RevokeTokenParams{
+			TokenID:   tokenID,
+			UserID:    account.ID,
+			ExpiresAt: expiresAt,
+		})
+		require.NoError(t, err)
+	}
+
+	deleted, err := testQueries.DeleteExpiredRevokedTokens(context.Background(), now)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	require.False(t, checkRevoked(t, expired, account.ID, now))
+	require.True(t, checkRevoked(t, live, account.ID, now))
+}
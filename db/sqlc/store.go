@@ -3,11 +3,35 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorkoutNotOwned       = errors.New("This workout does not belong to the authenticated user")
+	ErrMergeSameExercise     = errors.New("Cannot merge an exercise into itself")
+	ErrResetTokenInvalid     = errors.New("Reset token is invalid or has expired")
+	ErrVerifyTokenInvalid    = errors.New("Verification token is invalid or has expired")
+	ErrOIDCEmailUnverified   = errors.New("The identity provider has not verified this email address")
+	ErrOIDCAccountUnverified = errors.New("An account with this email exists but its email is not verified, sign in with its password and verify it first")
 )
 
 type Store interface {
 	Querier
+	RepeatWorkoutTx(ctx context.Context, arg RepeatWorkoutTxParams) (RepeatWorkoutTxResult, error)
+	UpdateEquipmentTx(ctx context.Context, arg UpdateEquipmentTxParams) (UpdateEquipmentTxResult, error)
+	ImportWorkoutsTx(ctx context.Context, arg ImportWorkoutsTxParams) (ImportWorkoutsTxResult, error)
+	MergeExercisesTx(ctx context.Context, arg MergeExercisesTxParams) (MergeExercisesTxResult, error)
+	SetExerciseMuscleGroupsTx(ctx context.Context, arg SetExerciseMuscleGroupsTxParams) ([]ExerciseMuscleGroup, error)
+	SeedCatalogTx(ctx context.Context, arg SeedCatalogTxParams) (SeedCatalogTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (uuid.UUID, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (uuid.UUID, error)
+	ConfirmMFATx(ctx context.Context, arg ConfirmMFATxParams) error
+	OIDCLoginTx(ctx context.Context, arg OIDCLoginTxParams) (Account, error)
+	UpdateAccountTx(ctx context.Context, arg UpdateAccountParams) (UpdateAccountTxResult, error)
 }
 
 type SQLStore struct {
@@ -40,6 +64,445 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
+type RepeatWorkoutTxParams struct {
+	WorkoutID uuid.UUID `json:"workout_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	StartTime time.Time `json:"start_time"`
+	Increment float32   `json:"increment"`
+}
+
+type RepeatWorkoutTxResult struct {
+	Workout Workout `json:"workout"`
+	Lifts   []Lift  `json:"lifts"`
+}
+
+// RepeatWorkoutTx clones the sets of a previous workout into a new workout.
+// When Increment is non zero, every exercise where each set hit the same
+// number of reps has Increment added to its weight.
+func (store *SQLStore) RepeatWorkoutTx(ctx context.Context, arg RepeatWorkoutTxParams) (RepeatWorkoutTxResult, error) {
+	var res RepeatWorkoutTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		source, err := q.ListWorkoutLifts(ctx, arg.WorkoutID)
+		if err != nil {
+			return err
+		}
+
+		if len(source) == 0 {
+			return sql.ErrNoRows
+		}
+
+		if source[0].UserID != arg.UserID {
+			return ErrWorkoutNotOwned
+		}
+
+		res.Workout, err = q.CreateWorkout(ctx, CreateWorkoutParams{
+			UserID:    arg.UserID,
+			StartTime: arg.StartTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		progressed := progressedExercises(source)
+		n := len(source)
+		lifts := CreateLiftsParams{
+			ExerciseIds: make([]int32, n),
+			Weights:     make([]float32, n),
+			Reps:        make([]int16, n),
+			UserID:      make([]uuid.UUID, n),
+			WorkoutID:   make([]uuid.UUID, n),
+			Notes:       make([]string, n),
+		}
+
+		for i, lift := range source {
+			weight := lift.WeightLifted
+			if progressed[lift.ExerciseID] {
+				weight += arg.Increment
+			}
+
+			lifts.ExerciseIds[i] = lift.ExerciseID
+			lifts.Weights[i] = weight
+			lifts.Reps[i] = lift.Reps
+			lifts.UserID[i] = arg.UserID
+			lifts.WorkoutID[i] = res.Workout.ID
+		}
+
+		res.Lifts, err = q.CreateLifts(ctx, lifts)
+		return err
+	})
+
+	return res, err
+}
+
+func progressedExercises(lifts []Lift) map[int32]bool {
+	targets := make(map[int32]int16)
+	progressed := make(map[int32]bool)
+
+	for _, lift := range lifts {
+		if lift.Reps > targets[lift.ExerciseID] {
+			targets[lift.ExerciseID] = lift.Reps
+		}
+	}
+
+	for _, lift := range lifts {
+		if lift.Reps < targets[lift.ExerciseID] {
+			progressed[lift.ExerciseID] = false
+			continue
+		}
+
+		if _, ok := progressed[lift.ExerciseID]; !ok {
+			progressed[lift.ExerciseID] = true
+		}
+	}
+
+	return progressed
+}
+
+type UpdateEquipmentTxParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	BarWeight float32   `json:"bar_weight"`
+	Weights   []float32 `json:"weights"`
+	Pairs     []int16   `json:"pairs"`
+}
+
+type UpdateEquipmentTxResult struct {
+	Settings EquipmentSetting `json:"settings"`
+	Plates   []PlateInventory `json:"plates"`
+}
+
+// UpdateEquipmentTx stores the bar weight and replaces the plate inventory of an account.
+func (store *SQLStore) UpdateEquipmentTx(ctx context.Context, arg UpdateEquipmentTxParams) (UpdateEquipmentTxResult, error) {
+	var res UpdateEquipmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		res.Settings, err = q.UpsertEquipmentSettings(ctx, UpsertEquipmentSettingsParams{
+			UserID:    arg.UserID,
+			BarWeight: arg.BarWeight,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeletePlates(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		userIDs := make([]uuid.UUID, len(arg.Weights))
+		for i := range userIDs {
+			userIDs[i] = arg.UserID
+		}
+
+		res.Plates, err = q.CreatePlates(ctx, CreatePlatesParams{
+			UserID:  userIDs,
+			Weights: arg.Weights,
+			Pairs:   arg.Pairs,
+		})
+		return err
+	})
+
+	return res, err
+}
+
+type ImportedWorkout struct {
+	StartTime   time.Time `json:"start_time"`
+	FinishTime  time.Time `json:"finish_time"`
+	Notes       string    `json:"notes"`
+	ExerciseIDs []int32   `json:"exercise_ids"`
+	Weights     []float32 `json:"weights"`
+	Reps        []int16   `json:"reps"`
+	SetNotes    []string  `json:"set_notes"`
+}
+
+type ImportWorkoutsTxParams struct {
+	UserID   uuid.UUID         `json:"user_id"`
+	Workouts []ImportedWorkout `json:"workouts"`
+}
+
+type ImportWorkoutsTxResult struct {
+	Workouts int `json:"workouts"`
+	Lifts    int `json:"lifts"`
+}
+
+// ImportWorkoutsTx creates every imported workout and its sets in a single
+// transaction so a failed import never leaves a partial history behind.
+func (store *SQLStore) ImportWorkoutsTx(ctx context.Context, arg ImportWorkoutsTxParams) (ImportWorkoutsTxResult, error) {
+	var res ImportWorkoutsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, imported := range arg.Workouts {
+			workout, err := q.CreateWorkout(ctx, CreateWorkoutParams{
+				UserID:    arg.UserID,
+				StartTime: imported.StartTime,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.UpdateWorkout(ctx, UpdateWorkoutParams{
+				ID:         workout.ID,
+				FinishTime: sql.NullTime{Time: imported.FinishTime, Valid: true},
+				Notes:      sql.NullString{String: imported.Notes, Valid: imported.Notes != ""},
+			})
+			if err != nil {
+				return err
+			}
+
+			n := len(imported.ExerciseIDs)
+			userIDs := make([]uuid.UUID, n)
+			workoutIDs := make([]uuid.UUID, n)
+			for i := 0; i < n; i++ {
+				userIDs[i] = arg.UserID
+				workoutIDs[i] = workout.ID
+			}
+
+			lifts, err := q.CreateLifts(ctx, CreateLiftsParams{
+				ExerciseIds: imported.ExerciseIDs,
+				Weights:     imported.Weights,
+				Reps:        imported.Reps,
+				UserID:      userIDs,
+				WorkoutID:   workoutIDs,
+				Notes:       imported.SetNotes,
+			})
+			if err != nil {
+				return err
+			}
+
+			res.Workouts++
+			res.Lifts += len(lifts)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+type MergeExercisesTxParams struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type MergeExercisesTxResult struct {
+	Exercise   Exercise `json:"exercise"`
+	LiftsMoved int64    `json:"lifts_moved"`
+}
+
+// MergeExercisesTx moves every lift and alias recorded against Source onto
+// Target and then removes Source. PRs are derived from lifts so they follow
+// automatically.
+func (store *SQLStore) MergeExercisesTx(ctx context.Context, arg MergeExercisesTxParams) (MergeExercisesTxResult, error) {
+	var res MergeExercisesTxResult
+
+	if arg.Source == arg.Target {
+		return res, ErrMergeSameExercise
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		source, err := q.GetExercise(ctx, arg.Source)
+		if err != nil {
+			return err
+		}
+
+		res.Exercise, err = q.GetExercise(ctx, arg.Target)
+		if err != nil {
+			return err
+		}
+
+		res.LiftsMoved, err = q.ReassignLifts(ctx, ReassignLiftsParams{
+			Target: res.Exercise.ID,
+			Source: source.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.ReassignAliases(ctx, ReassignAliasesParams{
+			Target: arg.Target,
+			Source: arg.Source,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteExercise(ctx, arg.Source)
+	})
+
+	return res, err
+}
+
+type SetExerciseMuscleGroupsTxParams struct {
+	ExerciseName  string    `json:"exercise_name"`
+	Primary       string    `json:"primary"`
+	MuscleGroups  []string  `json:"muscle_groups"`
+	Contributions []float32 `json:"contributions"`
+}
+
+// SetExerciseMuscleGroupsTx sets the primary muscle group of an exercise and
+// replaces its secondary groups. The primary row of exercise_muscle_group is
+// maintained by a trigger on exercise.muscle_group.
+func (store *SQLStore) SetExerciseMuscleGroupsTx(ctx context.Context, arg SetExerciseMuscleGroupsTxParams) ([]ExerciseMuscleGroup, error) {
+	var res []ExerciseMuscleGroup
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		_, err = q.UpdateExercise(ctx, UpdateExerciseParams{
+			Column1: "",
+			Column2: arg.Primary,
+			Column3: "",
+			Column4: "",
+			Column5: "",
+			Name:    arg.ExerciseName,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteSecondaryMuscleGroups(ctx, arg.ExerciseName)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateSecondaryMuscleGroups(ctx, CreateSecondaryMuscleGroupsParams{
+			ExerciseName:  arg.ExerciseName,
+			MuscleGroups:  arg.MuscleGroups,
+			Contributions: arg.Contributions,
+		})
+		if err != nil {
+			return err
+		}
+
+		res, err = q.ListExerciseMuscleGroups(ctx, arg.ExerciseName)
+		return err
+	})
+
+	return res, err
+}
+
+type SeedCatalogTxParams struct {
+	Version      int32             `json:"version"`
+	MuscleGroups []string          `json:"muscle_groups"`
+	Categories   []string          `json:"categories"`
+	Exercises    []CatalogExercise `json:"exercises"`
+}
+
+type SeedCatalogTxResult struct {
+	Version int32 `json:"version"`
+	Applied bool  `json:"applied"`
+	Created int   `json:"created"`
+	Updated int   `json:"updated"`
+	Skipped int   `json:"skipped"`
+}
+
+// SeedCatalogTx applies a catalog version newer than the last one applied.
+// Exercises are only updated while they still match what the catalog last
+// wrote, so rows a user has edited, renamed or deleted are left alone.
+func (store *SQLStore) SeedCatalogTx(ctx context.Context, arg SeedCatalogTxParams) (SeedCatalogTxResult, error) {
+	var res SeedCatalogTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// serialize concurrent seeders so only one of them applies a version
+		err := q.LockCatalog(ctx)
+		if err != nil {
+			return err
+		}
+
+		res.Version, err = q.GetCatalogVersion(ctx)
+		if err != nil {
+			return err
+		}
+
+		if res.Version >= arg.Version {
+			return nil
+		}
+
+		err = q.SeedMuscleGroups(ctx, arg.MuscleGroups)
+		if err != nil {
+			return err
+		}
+
+		err = q.SeedCategories(ctx, arg.Categories)
+		if err != nil {
+			return err
+		}
+
+		for _, ex := range arg.Exercises {
+			seeded, err := q.GetCatalogExercise(ctx, ex.Name)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			wasSeeded := err == nil
+
+			current, err := q.GetExercise(ctx, ex.Name)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			exists := err == nil
+
+			switch {
+			case !exists && !wasSeeded:
+				_, err = q.CreateExercise(ctx, CreateExerciseParams{
+					Name:            ex.Name,
+					MuscleGroup:     ex.MuscleGroup,
+					Category:        ex.Category,
+					Equipment:       ex.Equipment,
+					MovementPattern: ex.MovementPattern,
+				})
+				res.Created++
+			case exists && wasSeeded && catalogUntouched(current, seeded):
+				_, err = q.UpdateExercise(ctx, UpdateExerciseParams{
+					Column1: "",
+					Column2: ex.MuscleGroup,
+					Column3: ex.Category,
+					Column4: ex.Equipment,
+					Column5: ex.MovementPattern,
+					Name:    ex.Name,
+				})
+				res.Updated++
+			default:
+				res.Skipped++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			err = q.UpsertCatalogExercise(ctx, UpsertCatalogExerciseParams{
+				Name:            ex.Name,
+				MuscleGroup:     ex.MuscleGroup,
+				Category:        ex.Category,
+				Equipment:       ex.Equipment,
+				MovementPattern: ex.MovementPattern,
+				Version:         arg.Version,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		res.Version = arg.Version
+		res.Applied = true
+		return q.CreateCatalogVersion(ctx, arg.Version)
+	})
+
+	return res, err
+}
+
+func catalogUntouched(current Exercise, seeded CatalogExercise) bool {
+	return current.MuscleGroup == seeded.MuscleGroup &&
+		current.Category == seeded.Category &&
+		current.Equipment == seeded.Equipment &&
+		current.MovementPattern == seeded.MovementPattern
+}
+
 // type CreateCompleteWorkoutReq struct {
 // 	UserId       uuid.UUID `json:"user_id"`
 // 	StartTime    int64     `json:"start_time"`
@@ -98,3 +561,242 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 
 // 	return res, err
 // }
+
+type ResetPasswordTxParams struct {
+	TokenHash string    `json:"token_hash"`
+	Password  string    `json:"password"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// ResetPasswordTx consumes a reset token and sets the new password hash. Any
+// other outstanding tokens for the account are discarded and its API keys are
+// revoked, since a reset is how an account is recovered after a compromise.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (uuid.UUID, error) {
+	var userID uuid.UUID
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		userID, err = q.UsePasswordReset(ctx, UsePasswordResetParams{
+			Now:       arg.ResetAt,
+			TokenHash: arg.TokenHash,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrResetTokenInvalid
+			}
+			return err
+		}
+
+		err = q.UpdatePassword(ctx, UpdatePasswordParams{
+			Password:          arg.Password,
+			PasswordChangedAt: arg.ResetAt,
+			ID:                userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.RevokeAPIKeys(ctx, RevokeAPIKeysParams{
+			RevokedAt: sql.NullTime{Time: arg.ResetAt, Valid: true},
+			UserID:    userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeletePasswordResets(ctx, userID)
+	})
+
+	return userID, err
+}
+
+type VerifyEmailTxParams struct {
+	TokenHash  string    `json:"token_hash"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// VerifyEmailTx consumes a verification token and marks the account's email
+// as verified, discarding any other tokens that were sent.
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (uuid.UUID, error) {
+	var userID uuid.UUID
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		userID, err = q.UseEmailVerification(ctx, UseEmailVerificationParams{
+			Now:       arg.VerifiedAt,
+			TokenHash: arg.TokenHash,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrVerifyTokenInvalid
+			}
+			return err
+		}
+
+		err = q.VerifyEmail(ctx, VerifyEmailParams{
+			EmailVerifiedAt: sql.NullTime{Time: arg.VerifiedAt, Valid: true},
+			ID:              userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteEmailVerifications(ctx, userID)
+	})
+
+	return userID, err
+}
+
+type ConfirmMFATxParams struct {
+	UserID      uuid.UUID `json:"user_id"`
+	ConfirmedAt time.Time `json:"confirmed_at"`
+	Step        int64     `json:"step"`
+	CodeHashes  []string  `json:"code_hashes"`
+}
+
+// ConfirmMFATx turns on two factor auth for the account and replaces its
+// recovery codes. Step is the code used to confirm so it cannot be replayed.
+func (store *SQLStore) ConfirmMFATx(ctx context.Context, arg ConfirmMFATxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.ConfirmAccountMFA(ctx, ConfirmAccountMFAParams{
+			ConfirmedAt:  sql.NullTime{Time: arg.ConfirmedAt, Valid: true},
+			LastUsedStep: arg.Step,
+			UserID:       arg.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteRecoveryCodes(ctx, arg.UserID); err != nil {
+			return err
+		}
+
+		for _, hash := range arg.CodeHashes {
+			err := q.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{CodeHash: hash, UserID: arg.UserID})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type OIDCLoginTxParams struct {
+	Provider      string    `json:"provider"`
+	Subject       string    `json:"subject"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	Name          string    `json:"name"`
+	Password      string    `json:"password"`
+	Now           time.Time `json:"now"`
+}
+
+// OIDCLoginTx returns the account signing in with a provider identity. An
+// identity seen for the first time is linked to the account with the same
+// email, or a new account is created with Password, which nobody knows. Both
+// need the provider to have verified the email. An existing account is only
+// linked once its own email is verified, otherwise whoever registered it
+// without owning the address would keep access to it.
+func (store *SQLStore) OIDCLoginTx(ctx context.Context, arg OIDCLoginTxParams) (Account, error) {
+	var account Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		identity, err := q.GetAccountIdentity(ctx, GetAccountIdentityParams{
+			Provider: arg.Provider,
+			Subject:  arg.Subject,
+		})
+		if err == nil {
+			account, err = q.GetAccount(ctx, identity.UserID)
+			return err
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		if !arg.EmailVerified || arg.Email == "" {
+			return ErrOIDCEmailUnverified
+		}
+
+		var userID uuid.UUID
+		existing, err := q.GetAccountByEmail(ctx, arg.Email)
+		switch {
+		case err == nil:
+			verifiedAt, err := q.GetEmailVerifiedAt(ctx, existing.ID)
+			if err != nil {
+				return err
+			}
+			if !verifiedAt.Valid {
+				return ErrOIDCAccountUnverified
+			}
+			userID = existing.ID
+		case err == sql.ErrNoRows:
+			created, err := q.CreateAccount(ctx, CreateAccountParams{
+				Name:     arg.Name,
+				Email:    arg.Email,
+				Password: arg.Password,
+			})
+			if err != nil {
+				return err
+			}
+			userID = created.ID
+
+			err = q.VerifyEmail(ctx, VerifyEmailParams{
+				EmailVerifiedAt: sql.NullTime{Time: arg.Now, Valid: true},
+				ID:              userID,
+			})
+			if err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		_, err = q.CreateAccountIdentity(ctx, CreateAccountIdentityParams{
+			Provider: arg.Provider,
+			Subject:  arg.Subject,
+			UserID:   userID,
+			Email:    arg.Email,
+		})
+		if err != nil {
+			return err
+		}
+
+		account, err = q.GetAccount(ctx, userID)
+		return err
+	})
+
+	return account, err
+}
+
+type UpdateAccountTxResult struct {
+	Account      Account `json:"account"`
+	EmailChanged bool    `json:"email_changed"`
+}
+
+// UpdateAccountTx applies a partial profile update. A new email is no longer
+// verified and verification tokens sent to the old one stop working.
+func (store *SQLStore) UpdateAccountTx(ctx context.Context, arg UpdateAccountParams) (UpdateAccountTxResult, error) {
+	var res UpdateAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetAccount(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		res.Account, err = q.UpdateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		res.EmailChanged = res.Account.Email != before.Email
+		if !res.EmailChanged {
+			return nil
+		}
+		return q.DeleteEmailVerifications(ctx, arg.ID)
+	})
+
+	return res, err
+}
@@ -0,0 +1,443 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeatWorkoutTx(t *testing.T) {
+	store := NewStore(testDB)
+	workout := GenerateRandWorkout(t)
+	hit := GenerateRandomExercise(t)
+	missed := GenerateRandomExercise(t)
+
+	sets := []struct {
+		exercise int32
+		reps     int16
+	}{
+		{hit.ID, 5},
+		{missed.ID, 8},
+		{hit.ID, 5},
+		{missed.ID, 6},
+	}
+
+	for _, set := range sets {
+		_, err := testQueries.CreateLift(context.Background(), CreateLiftParams{
+			ExerciseID:   set.exercise,
+			WeightLifted: 100,
+			Reps:         set.reps,
+			UserID:       workout.UserID,
+			WorkoutID:    workout.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	res, err := store.RepeatWorkoutTx(context.Background(), RepeatWorkoutTxParams{
+		WorkoutID: workout.ID,
+		UserID:    workout.UserID,
+		StartTime: util.FormatMSEpoch(time.Now().UnixMilli()),
+		Increment: 5,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, workout.ID, res.Workout.ID)
+	require.Equal(t, workout.UserID, res.Workout.UserID)
+	require.Len(t, res.Lifts, len(sets))
+
+	// sets are cloned in the order they were recorded
+	for i, lift := range res.Lifts {
+		require.Equal(t, res.Workout.ID, lift.WorkoutID)
+		require.Equal(t, sets[i].exercise, lift.ExerciseID)
+		require.Equal(t, sets[i].reps, lift.Reps)
+		if lift.ExerciseID == hit.ID {
+			require.Equal(t, float32(105), lift.WeightLifted)
+			continue
+		}
+		require.Equal(t, float32(100), lift.WeightLifted)
+	}
+
+	_, err = store.RepeatWorkoutTx(context.Background(), RepeatWorkoutTxParams{
+		WorkoutID: workout.ID,
+		UserID:    uuid.New(),
+		StartTime: util.FormatMSEpoch(time.Now().UnixMilli()),
+	})
+	require.ErrorIs(t, err, ErrWorkoutNotOwned)
+}
+
+func TestUpdateEquipmentTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := GenerateRandAccount(t)
+
+	for _, weights := range [][]float32{{45, 25}, {20, 10, 5}} {
+		pairs := make([]int16, len(weights))
+		for i := range pairs {
+			pairs[i] = 2
+		}
+
+		res, err := store.UpdateEquipmentTx(context.Background(), UpdateEquipmentTxParams{
+			UserID:    account.ID,
+			BarWeight: 20,
+			Weights:   weights,
+			Pairs:     pairs,
+		})
+		require.NoError(t, err)
+		require.Equal(t, float32(20), res.Settings.BarWeight)
+		require.Len(t, res.Plates, len(weights))
+
+		plates, err := testQueries.ListPlates(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Len(t, plates, len(weights))
+	}
+}
+
+func TestImportWorkoutsTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := GenerateRandAccount(t)
+	exercise := GenerateRandomExercise(t)
+	start := util.FormatMSEpoch(time.Now().UnixMilli())
+
+	res, err := store.ImportWorkoutsTx(context.Background(), ImportWorkoutsTxParams{
+		UserID: account.ID,
+		Workouts: []ImportedWorkout{
+			{
+				StartTime:   start,
+				FinishTime:  start.Add(time.Hour),
+				Notes:       "imported",
+				ExerciseIDs: []int32{exercise.ID, exercise.ID},
+				Weights:     []float32{135, 145},
+				Reps:        []int16{5, 5},
+				SetNotes:    []string{"", "top set"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Workouts)
+	require.Equal(t, 2, res.Lifts)
+
+	_, err = store.ImportWorkoutsTx(context.Background(), ImportWorkoutsTxParams{
+		UserID: account.ID,
+		Workouts: []ImportedWorkout{
+			{
+				StartTime:   start,
+				FinishTime:  start.Add(time.Hour),
+				ExerciseIDs: []int32{-1},
+				Weights:     []float32{135},
+				Reps:        []int16{5},
+				SetNotes:    []string{""},
+			},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestMergeExercisesTx(t *testing.T) {
+	store := NewStore(testDB)
+	lift := GenerateRandLift(t)
+	source, err := testQueries.GetExerciseByID(context.Background(), lift.ExerciseID)
+	require.NoError(t, err)
+	target := GenerateRandomExercise(t)
+
+	res, err := store.MergeExercisesTx(context.Background(), MergeExercisesTxParams{
+		Source: source.Name,
+		Target: target.Name,
+	})
+	require.NoError(t, err)
+	require.Equal(t, target.Name, res.Exercise.Name)
+	require.Equal(t, int64(1), res.LiftsMoved)
+
+	merged, err := testQueries.GetLift(context.Background(), GetLiftParams{
+		UserID: lift.UserID,
+		ID:     lift.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, target.ID, merged.ExerciseID)
+
+	_, err = testQueries.GetExercise(context.Background(), source.Name)
+	require.Error(t, err)
+
+	_, err = store.MergeExercisesTx(context.Background(), MergeExercisesTxParams{
+		Source: target.Name,
+		Target: target.Name,
+	})
+	require.ErrorIs(t, err, ErrMergeSameExercise)
+}
+
+func TestSeedCatalogTx(t *testing.T) {
+	store := NewStore(testDB)
+	muscleGroup := util.RandomString(8)
+	category := util.RandomString(8)
+	untouched := CatalogExercise{Name: util.RandomString(8), MuscleGroup: muscleGroup, Category: category, Equipment: "barbell"}
+	edited := CatalogExercise{Name: util.RandomString(8), MuscleGroup: muscleGroup, Category: category, Equipment: "barbell"}
+
+	current, err := testQueries.GetCatalogVersion(context.Background())
+	require.NoError(t, err)
+
+	args := SeedCatalogTxParams{
+		Version:      current + 1,
+		MuscleGroups: []string{muscleGroup},
+		Categories:   []string{category},
+		Exercises:    []CatalogExercise{untouched, edited},
+	}
+	res, err := store.SeedCatalogTx(context.Background(), args)
+	require.NoError(t, err)
+	require.True(t, res.Applied)
+	require.Equal(t, 2, res.Created)
+
+	// the same version again is a no-op
+	res, err = store.SeedCatalogTx(context.Background(), args)
+	require.NoError(t, err)
+	require.False(t, res.Applied)
+
+	_, err = testQueries.UpdateExercise(context.Background(), UpdateExerciseParams{
+		Column4: "dumbbell",
+		Name:    edited.Name,
+	})
+	require.NoError(t, err)
+
+	untouched.Equipment = "cable"
+	edited.Equipment = "cable"
+	args.Version++
+	args.Exercises = []CatalogExercise{untouched, edited}
+	res, err = store.SeedCatalogTx(context.Background(), args)
+	require.NoError(t, err)
+	require.True(t, res.Applied)
+	require.Equal(t, 1, res.Updated)
+	require.Equal(t, 1, res.Skipped)
+
+	exercise, err := testQueries.GetExercise(context.Background(), untouched.Name)
+	require.NoError(t, err)
+	require.Equal(t, "cable", exercise.Equipment)
+
+	exercise, err = testQueries.GetExercise(context.Background(), edited.Name)
+	require.NoError(t, err)
+	require.Equal(t, "dumbbell", exercise.Equipment)
+}
+
+func TestSeedCatalogTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	current, err := testQueries.GetCatalogVersion(context.Background())
+	require.NoError(t, err)
+
+	args := SeedCatalogTxParams{
+		Version:      current + 1,
+		MuscleGroups: []string{util.RandomString(8)},
+		Categories:   []string{util.RandomString(8)},
+	}
+	args.Exercises = []CatalogExercise{{Name: util.RandomString(8), MuscleGroup: args.MuscleGroups[0], Category: args.Categories[0], Equipment: "barbell"}}
+
+	n := 5
+	errs := make(chan error, n)
+	results := make(chan SeedCatalogTxResult, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			res, err := store.SeedCatalogTx(context.Background(), args)
+			errs <- err
+			results <- res
+		}()
+	}
+
+	applied := 0
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+		if res := <-results; res.Applied {
+			applied++
+		}
+	}
+	require.Equal(t, 1, applied)
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := GenerateRandAccount(t)
+	reset := GenerateRandPasswordReset(t, account, time.Now().UTC().Add(time.Minute))
+	other := GenerateRandPasswordReset(t, account, time.Now().UTC().Add(time.Minute))
+	key := GenerateRandAPIKey(t, account)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(10))
+	require.NoError(t, err)
+
+	args := ResetPasswordTxParams{
+		TokenHash: reset.TokenHash,
+		Password:  hashedPassword,
+		ResetAt:   time.Now().UTC(),
+	}
+
+	userID, err := store.ResetPasswordTx(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, userID)
+
+	updated, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, updated.Password)
+
+	_, err = testQueries.GetAPIKeyByHash(context.Background(), key.KeyHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.ResetPasswordTx(context.Background(), args)
+	require.ErrorIs(t, err, ErrResetTokenInvalid)
+
+	args.TokenHash = other.TokenHash
+	_, err = store.ResetPasswordTx(context.Background(), args)
+	require.ErrorIs(t, err, ErrResetTokenInvalid)
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := GenerateRandAccount(t)
+	require.False(t, account.EmailVerifiedAt.Valid)
+
+	verification := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(time.Hour))
+	other := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(time.Hour))
+
+	args := VerifyEmailTxParams{TokenHash: verification.TokenHash, VerifiedAt: time.Now().UTC()}
+	userID, err := store.VerifyEmailTx(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, userID)
+
+	verifiedAt, err := testQueries.GetEmailVerifiedAt(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, verifiedAt.Valid)
+	require.WithinDuration(t, args.VerifiedAt, verifiedAt.Time, time.Second)
+
+	_, err = store.VerifyEmailTx(context.Background(), args)
+	require.ErrorIs(t, err, ErrVerifyTokenInvalid)
+
+	args.TokenHash = other.TokenHash
+	_, err = store.VerifyEmailTx(context.Background(), args)
+	require.ErrorIs(t, err, ErrVerifyTokenInvalid)
+}
+
+func TestConfirmMFATx(t *testing.T) {
+	store := NewStore(testDB)
+	account := GenerateRandAccount(t)
+	GenerateRandAccountMFA(t, account)
+
+	old := util.HashSecret(util.RandomString(10))
+	err := testQueries.CreateRecoveryCode(context.Background(), CreateRecoveryCodeParams{CodeHash: old, UserID: account.ID})
+	require.NoError(t, err)
+
+	hashes := []string{util.HashSecret(util.RandomString(10)), util.HashSecret(util.RandomString(10))}
+	err = store.ConfirmMFATx(context.Background(), ConfirmMFATxParams{
+		UserID:      account.ID,
+		ConfirmedAt: time.Now().UTC(),
+		Step:        42,
+		CodeHashes:  hashes,
+	})
+	require.NoError(t, err)
+
+	mfa, err := testQueries.GetAccountMFA(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.True(t, mfa.ConfirmedAt.Valid)
+	require.Equal(t, int64(42), mfa.LastUsedStep)
+
+	n, err := testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Now: time.Now().UTC(), CodeHash: old, UserID: account.ID})
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	n, err = testQueries.UseRecoveryCode(context.Background(), UseRecoveryCodeParams{Now: time.Now().UTC(), CodeHash: hashes[0], UserID: account.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
+
+func TestOIDCLoginTx(t *testing.T) {
+	store := NewStore(testDB)
+	existing := GenerateRandAccount(t)
+
+	args := OIDCLoginTxParams{
+		Provider:      "test",
+		Subject:       util.RandomString(16),
+		Email:         existing.Email,
+		EmailVerified: true,
+		Name:          util.RandomString(8),
+		Password:      util.RandomString(32),
+		Now:           time.Now().UTC(),
+	}
+
+	// an account that never verified its email is not linked
+	_, err := store.OIDCLoginTx(context.Background(), args)
+	require.ErrorIs(t, err, ErrOIDCAccountUnverified)
+
+	_, err = testQueries.GetAccountIdentity(context.Background(), GetAccountIdentityParams{
+		Provider: args.Provider,
+		Subject:  args.Subject,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	verifiedAt, err := testQueries.GetEmailVerifiedAt(context.Background(), existing.ID)
+	require.NoError(t, err)
+	require.False(t, verifiedAt.Valid)
+
+	err = testQueries.VerifyEmail(context.Background(), VerifyEmailParams{
+		EmailVerifiedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:              existing.ID,
+	})
+	require.NoError(t, err)
+
+	// once it has, a verified email links the identity to the account
+	account, err := store.OIDCLoginTx(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, existing.ID, account.ID)
+	require.Equal(t, existing.Password, account.Password)
+	require.True(t, account.EmailVerifiedAt.Valid)
+
+	// later sign ins go through the identity even if the email changed
+	args.Email = util.RandomEmail()
+	args.EmailVerified = false
+	account, err = store.OIDCLoginTx(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, existing.ID, account.ID)
+
+	// a new identity without a verified email is refused
+	args.Subject = util.RandomString(16)
+	_, err = store.OIDCLoginTx(context.Background(), args)
+	require.ErrorIs(t, err, ErrOIDCEmailUnverified)
+
+	// and with one an account is created
+	args.EmailVerified = true
+	account, err = store.OIDCLoginTx(context.Background(), args)
+	require.NoError(t, err)
+	require.NotEqual(t, existing.ID, account.ID)
+	require.Equal(t, args.Email, account.Email)
+	require.Equal(t, args.Name, account.Name)
+	require.True(t, account.EmailVerifiedAt.Valid)
+}
+
+func TestUpdateAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	account := GenerateRandAccount(t)
+	verification := GenerateRandEmailVerification(t, account, time.Now().UTC().Add(time.Hour))
+
+	args := UpdateAccountParams{
+		Weight:      sql.NullFloat64{Float64: 190, Valid: true},
+		Preferences: json.RawMessage("{}"),
+		ID:          account.ID,
+	}
+	res, err := store.UpdateAccountTx(context.Background(), args)
+	require.NoError(t, err)
+	require.False(t, res.EmailChanged)
+	require.Equal(t, float32(190), res.Account.Weight)
+
+	args = UpdateAccountParams{
+		Email:       sql.NullString{String: util.RandomEmail(), Valid: true},
+		Preferences: json.RawMessage("{}"),
+		ID:          account.ID,
+	}
+	res, err = store.UpdateAccountTx(context.Background(), args)
+	require.NoError(t, err)
+	require.True(t, res.EmailChanged)
+	require.Equal(t, args.Email.String, res.Account.Email)
+
+	// the token sent to the old address no longer verifies the account
+	_, err = store.VerifyEmailTx(context.Background(), VerifyEmailTxParams{TokenHash: verification.TokenHash, VerifiedAt: time.Now().UTC()})
+	require.ErrorIs(t, err, ErrVerifyTokenInvalid)
+
+	args.ID = uuid.New()
+	_, err = store.UpdateAccountTx(context.Background(), args)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,7 +16,7 @@ import (
 const createWorkout = `-- name: CreateWorkout :one
 INSERT INTO workout (user_id, start_time) 
 VALUES ($1, $2)
-RETURNING id, start_time, finish_time, user_id
+RETURNING id, start_time, finish_time, user_id, notes, rating, energy
 `
 
 type CreateWorkoutParams struct {
@@ -31,6 +32,9 @@ func (q *Queries) CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (W
 		&i.StartTime,
 		&i.FinishTime,
 		&i.UserID,
+		&i.Notes,
+		&i.Rating,
+		&i.Energy,
 	)
 	return i, err
 }
@@ -46,20 +50,27 @@ func (q *Queries) DeleteWorkout(ctx context.Context, id uuid.UUID) error {
 }
 
 const getWorkout = `-- name: GetWorkout :many
-SELECT w.id, exercise_name, weight_lifted, reps, start_time, finish_time, l.user_id
+SELECT w.id, l.exercise_id, e.name AS exercise_name, weight_lifted, reps, start_time, finish_time, l.user_id,
+w.notes, w.rating, w.energy, l.notes AS lift_notes
 FROM workout AS w
 JOIN lift AS l ON l.workout_id = w.id
+JOIN exercise AS e ON e.id = l.exercise_id
 WHERE w.id = $1
 `
 
 type GetWorkoutRow struct {
 	ID           uuid.UUID `json:"id"`
+	ExerciseID   int32     `json:"exercise_id"`
 	ExerciseName string    `json:"exercise_name"`
 	WeightLifted float32   `json:"weight_lifted"`
 	Reps         int16     `json:"reps"`
 	StartTime    time.Time `json:"start_time"`
 	FinishTime   time.Time `json:"finish_time"`
 	UserID       uuid.UUID `json:"user_id"`
+	Notes        string    `json:"notes"`
+	Rating       int16     `json:"rating"`
+	Energy       int16     `json:"energy"`
+	LiftNotes    string    `json:"lift_notes"`
 }
 
 func (q *Queries) GetWorkout(ctx context.Context, id uuid.UUID) ([]GetWorkoutRow, error) {
@@ -73,12 +84,17 @@ func (q *Queries) GetWorkout(ctx context.Context, id uuid.UUID) ([]GetWorkoutRow
 		var i GetWorkoutRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.ExerciseID,
 			&i.ExerciseName,
 			&i.WeightLifted,
 			&i.Reps,
 			&i.StartTime,
 			&i.FinishTime,
 			&i.UserID,
+			&i.Notes,
+			&i.Rating,
+			&i.Energy,
+			&i.LiftNotes,
 		); err != nil {
 			return nil, err
 		}
@@ -94,21 +110,91 @@ func (q *Queries) GetWorkout(ctx context.Context, id uuid.UUID) ([]GetWorkoutRow
 }
 
 const listWorkouts = `-- name: ListWorkouts :many
-SELECT id, start_time, finish_time, user_id FROM workout
+SELECT id, start_time, finish_time, user_id, notes, rating, energy FROM workout
 WHERE user_id = $1
-ORDER BY start_time DESC
-LIMIT $2
-OFFSET $3
+AND ($2::UUID IS NULL OR (start_time, id) < ($3::TIMESTAMP, $2::UUID))
+ORDER BY start_time DESC, id DESC
+LIMIT $4
+OFFSET $5
 `
 
 type ListWorkoutsParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	UserID         uuid.UUID     `json:"user_id"`
+	AfterID        uuid.NullUUID `json:"after_id"`
+	AfterStartTime time.Time     `json:"after_start_time"`
+	Limit          int32         `json:"limit"`
+	Offset         int32         `json:"offset"`
 }
 
 func (q *Queries) ListWorkouts(ctx context.Context, arg ListWorkoutsParams) ([]Workout, error) {
-	rows, err := q.db.QueryContext(ctx, listWorkouts, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listWorkouts,
+		arg.UserID,
+		arg.AfterID,
+		arg.AfterStartTime,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workout{}
+	for rows.Next() {
+		var i Workout
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartTime,
+			&i.FinishTime,
+			&i.UserID,
+			&i.Notes,
+			&i.Rating,
+			&i.Energy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchWorkouts = `-- name: SearchWorkouts :many
+SELECT DISTINCT w.id, w.start_time, w.finish_time, w.user_id, w.notes, w.rating, w.energy FROM workout AS w
+LEFT JOIN lift AS l ON l.workout_id = w.id
+WHERE w.user_id = $1
+AND (
+  to_tsvector('english', w.notes) @@ plainto_tsquery('english', $2)
+  OR to_tsvector('english', l.notes) @@ plainto_tsquery('english', $2)
+)
+AND ($3::UUID IS NULL OR (w.start_time, w.id) < ($4::TIMESTAMP, $3::UUID))
+ORDER BY w.start_time DESC, w.id DESC
+LIMIT $5
+OFFSET $6
+`
+
+type SearchWorkoutsParams struct {
+	UserID         uuid.UUID     `json:"user_id"`
+	Query          string        `json:"query"`
+	AfterID        uuid.NullUUID `json:"after_id"`
+	AfterStartTime time.Time     `json:"after_start_time"`
+	Limit          int32         `json:"limit"`
+	Offset         int32         `json:"offset"`
+}
+
+func (q *Queries) SearchWorkouts(ctx context.Context, arg SearchWorkoutsParams) ([]Workout, error) {
+	rows, err := q.db.QueryContext(ctx, searchWorkouts,
+		arg.UserID,
+		arg.Query,
+		arg.AfterID,
+		arg.AfterStartTime,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +207,9 @@ func (q *Queries) ListWorkouts(ctx context.Context, arg ListWorkoutsParams) ([]W
 			&i.StartTime,
 			&i.FinishTime,
 			&i.UserID,
+			&i.Notes,
+			&i.Rating,
+			&i.Energy,
 		); err != nil {
 			return nil, err
 		}
@@ -135,26 +224,41 @@ func (q *Queries) ListWorkouts(ctx context.Context, arg ListWorkoutsParams) ([]W
 	return items, nil
 }
 
-const updateFinishTime = `-- name: UpdateFinishTime :one
+const updateWorkout = `-- name: UpdateWorkout :one
 UPDATE workout SET
-finish_time = $1
-WHERE id = $2
-RETURNING id, start_time, finish_time, user_id
+finish_time = COALESCE($1, finish_time),
+notes = COALESCE($2, notes),
+rating = COALESCE($3, rating),
+energy = COALESCE($4, energy)
+WHERE id = $5
+RETURNING id, start_time, finish_time, user_id, notes, rating, energy
 `
 
-type UpdateFinishTimeParams struct {
-	FinishTime time.Time `json:"finish_time"`
-	ID         uuid.UUID `json:"id"`
+type UpdateWorkoutParams struct {
+	FinishTime sql.NullTime   `json:"finish_time"`
+	Notes      sql.NullString `json:"notes"`
+	Rating     sql.NullInt16  `json:"rating"`
+	Energy     sql.NullInt16  `json:"energy"`
+	ID         uuid.UUID      `json:"id"`
 }
 
-func (q *Queries) UpdateFinishTime(ctx context.Context, arg UpdateFinishTimeParams) (Workout, error) {
-	row := q.db.QueryRowContext(ctx, updateFinishTime, arg.FinishTime, arg.ID)
+func (q *Queries) UpdateWorkout(ctx context.Context, arg UpdateWorkoutParams) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, updateWorkout,
+		arg.FinishTime,
+		arg.Notes,
+		arg.Rating,
+		arg.Energy,
+		arg.ID,
+	)
 	var i Workout
 	err := row.Scan(
 		&i.ID,
 		&i.StartTime,
 		&i.FinishTime,
 		&i.UserID,
+		&i.Notes,
+		&i.Rating,
+		&i.Energy,
 	)
 	return i, err
 }
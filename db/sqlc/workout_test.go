@@ -2,10 +2,12 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,9 +54,9 @@ func TestUpdateWorkoutEndTime(t *testing.T) {
 	date1 := time.Date(end.Year(), end.Month(), end.Day(), end.Hour(), end.Minute(), end.Second(), end.Nanosecond(), time.UTC)
 	require.Greater(t, end.UnixMilli(), workout.StartTime.UnixMilli())
 
-	patched, err := testQueries.UpdateFinishTime(context.Background(), UpdateFinishTimeParams{
+	patched, err := testQueries.UpdateWorkout(context.Background(), UpdateWorkoutParams{
 		ID:         workout.ID,
-		FinishTime: end,
+		FinishTime: sql.NullTime{Time: end, Valid: true},
 	})
 	date2 := time.Date(patched.FinishTime.Year(), patched.FinishTime.Month(), patched.FinishTime.Day(), patched.FinishTime.Hour(), patched.FinishTime.Minute(), patched.FinishTime.Second(), patched.FinishTime.Nanosecond(), time.UTC)
 	require.NoError(t, err)
@@ -62,6 +64,71 @@ func TestUpdateWorkoutEndTime(t *testing.T) {
 	require.Equal(t, true, date1.Equal(date2))
 }
 
+func TestUpdateWorkoutNotes(t *testing.T) {
+	workout := GenerateRandWorkout(t)
+	notes := util.RandomString(20)
+
+	patched, err := testQueries.UpdateWorkout(context.Background(), UpdateWorkoutParams{
+		ID:     workout.ID,
+		Notes:  sql.NullString{String: notes, Valid: true},
+		Rating: sql.NullInt16{Int16: 5, Valid: true},
+		Energy: sql.NullInt16{Int16: 2, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, notes, patched.Notes)
+	require.Equal(t, int16(5), patched.Rating)
+	require.Equal(t, int16(2), patched.Energy)
+	require.WithinDuration(t, workout.FinishTime, patched.FinishTime, time.Second)
+
+	_, err = testQueries.UpdateWorkout(context.Background(), UpdateWorkoutParams{
+		ID:     workout.ID,
+		Rating: sql.NullInt16{Int16: 6, Valid: true},
+	})
+	require.Error(t, err)
+}
+
+func TestSearchWorkouts(t *testing.T) {
+	workout := GenerateRandWorkout(t)
+	exercise := GenerateRandomExercise(t)
+
+	_, err := testQueries.UpdateWorkout(context.Background(), UpdateWorkoutParams{
+		ID:    workout.ID,
+		Notes: sql.NullString{String: "felt great today", Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateLift(context.Background(), CreateLiftParams{
+		ExerciseID:   exercise.ID,
+		WeightLifted: float32(util.RandomInt(100, 250)),
+		Reps:         int16(util.RandomInt(4, 12)),
+		UserID:       workout.UserID,
+		WorkoutID:    workout.ID,
+		Notes:        "left shoulder tight",
+	})
+	require.NoError(t, err)
+
+	for _, query := range []string{"great", "shoulder"} {
+		workouts, err := testQueries.SearchWorkouts(context.Background(), SearchWorkoutsParams{
+			UserID: workout.UserID,
+			Query:  query,
+			Limit:  5,
+			Offset: 0,
+		})
+		require.NoError(t, err)
+		require.Len(t, workouts, 1)
+		require.Equal(t, workout.ID, workouts[0].ID)
+	}
+
+	workouts, err := testQueries.SearchWorkouts(context.Background(), SearchWorkoutsParams{
+		UserID: workout.UserID,
+		Query:  "knee",
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, workouts)
+}
+
 func TestListWorkouts(t *testing.T) {
 	account := GenerateRandAccount(t)
 	_24Hours := int64(60 * 60 * 24 * 1000)
@@ -81,6 +148,16 @@ func TestListWorkouts(t *testing.T) {
 	})
 	require.NoError(t, err)
 	require.Len(t, query, n)
+
+	last := query[1]
+	rest, err := testQueries.ListWorkouts(context.Background(), ListWorkoutsParams{
+		UserID:         account.ID,
+		AfterID:        uuid.NullUUID{UUID: last.ID, Valid: true},
+		AfterStartTime: last.StartTime,
+		Limit:          int32(n),
+	})
+	require.NoError(t, err)
+	require.Equal(t, query[2:], rest)
 }
 
 func TestDeleteWorkout(t *testing.T) {
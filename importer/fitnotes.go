@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+)
+
+const fitNotesDateLayout = "2006-01-02"
+
+// FitNotes only records the day of a workout, so every set logged on the same
+// date belongs to a single workout.
+func fitNotesParser(cols columns) (func(columns, []string) (string, Workout, Set, error), error) {
+	err := cols.require("date", "exercise", "reps")
+	if err != nil {
+		return nil, err
+	}
+
+	weightColumn, multiplier := "weight (lbs)", 1.0
+	if !cols.has(weightColumn) {
+		weightColumn, multiplier = "weight (kgs)", kgToLbs
+	}
+
+	err = cols.require(weightColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(cols columns, record []string) (string, Workout, Set, error) {
+		var workout Workout
+		var set Set
+
+		date := cols.get(record, "date")
+		day, err := time.Parse(fitNotesDateLayout, date)
+		if err != nil {
+			return "", workout, set, fmt.Errorf("Invalid date %q", date)
+		}
+
+		workout.StartTime = day
+		workout.FinishTime = day
+
+		set.Exercise = cols.get(record, "exercise")
+		set.Notes = cols.get(record, "comment")
+		set.Weight, err = parseWeight(cols.get(record, weightColumn), multiplier)
+		if err != nil {
+			return "", workout, set, err
+		}
+
+		set.Reps, err = parseReps(cols.get(record, "reps"))
+		if err != nil {
+			return "", workout, set, err
+		}
+
+		return date, workout, set, nil
+	}, nil
+}
@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"fmt"
+	"time"
+)
+
+const hevyDateLayout = "2 Jan 2006, 15:04"
+
+func hevyParser(cols columns) (func(columns, []string) (string, Workout, Set, error), error) {
+	err := cols.require("title", "start_time", "end_time", "exercise_title", "reps")
+	if err != nil {
+		return nil, err
+	}
+
+	weightColumn, multiplier := "weight_lbs", 1.0
+	if !cols.has(weightColumn) {
+		weightColumn, multiplier = "weight_kg", kgToLbs
+	}
+
+	err = cols.require(weightColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(cols columns, record []string) (string, Workout, Set, error) {
+		var workout Workout
+		var set Set
+
+		start, err := time.Parse(hevyDateLayout, cols.get(record, "start_time"))
+		if err != nil {
+			return "", workout, set, fmt.Errorf("Invalid start_time %q", cols.get(record, "start_time"))
+		}
+
+		finish, err := time.Parse(hevyDateLayout, cols.get(record, "end_time"))
+		if err != nil {
+			return "", workout, set, fmt.Errorf("Invalid end_time %q", cols.get(record, "end_time"))
+		}
+
+		workout.StartTime = start
+		workout.FinishTime = finish
+		workout.Notes = cols.get(record, "description")
+
+		set.Exercise = cols.get(record, "exercise_title")
+		set.Notes = cols.get(record, "exercise_notes")
+		set.Weight, err = parseWeight(cols.get(record, weightColumn), multiplier)
+		if err != nil {
+			return "", workout, set, err
+		}
+
+		set.Reps, err = parseReps(cols.get(record, "reps"))
+		if err != nil {
+			return "", workout, set, err
+		}
+
+		return cols.get(record, "start_time") + cols.get(record, "title"), workout, set, nil
+	}, nil
+}
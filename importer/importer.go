@@ -0,0 +1,235 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Source string
+
+const (
+	SourceStrong   Source = "strong"
+	SourceHevy     Source = "hevy"
+	SourceFitNotes Source = "fitnotes"
+)
+
+const kgToLbs = 2.20462
+
+var ErrUnsupportedSource = errors.New("Unsupported import source: must be one of strong, hevy or fitnotes")
+
+type Set struct {
+	Exercise string  `json:"exercise"`
+	Weight   float32 `json:"weight"`
+	Reps     int16   `json:"reps"`
+	Notes    string  `json:"notes"`
+}
+
+type Workout struct {
+	StartTime  time.Time `json:"start_time"`
+	FinishTime time.Time `json:"finish_time"`
+	Notes      string    `json:"notes"`
+	Sets       []Set     `json:"sets"`
+}
+
+// Parse reads a CSV export from one of the supported apps. Rows are grouped
+// into workouts in the order they first appear in the file and sets without
+// reps (cardio, timed holds) are skipped.
+func Parse(source Source, r io.Reader) ([]Workout, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read csv header: %w", err)
+	}
+	cols := newColumns(header)
+
+	var parse func(columns, []string) (string, Workout, Set, error)
+	switch source {
+	case SourceStrong:
+		parse, err = strongParser(cols)
+	case SourceHevy:
+		parse, err = hevyParser(cols)
+	case SourceFitNotes:
+		parse, err = fitNotesParser(cols)
+	default:
+		return nil, ErrUnsupportedSource
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var workouts []Workout
+	index := make(map[string]int)
+	line := 1
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read csv line %d: %w", line, err)
+		}
+
+		key, workout, set, err := parse(cols, record)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid csv line %d: %w", line, err)
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(workouts)
+			index[key] = i
+			workouts = append(workouts, workout)
+		}
+
+		if set.Reps > 0 && set.Exercise != "" {
+			workouts[i].Sets = append(workouts[i].Sets, set)
+		}
+	}
+
+	imported := workouts[:0]
+	for _, workout := range workouts {
+		if len(workout.Sets) > 0 {
+			imported = append(imported, workout)
+		}
+	}
+
+	return imported, nil
+}
+
+type columns map[string]int
+
+func newColumns(header []string) columns {
+	cols := make(columns, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		cols[name] = i
+	}
+	return cols
+}
+
+func (cols columns) require(names ...string) error {
+	for _, name := range names {
+		if _, ok := cols[name]; !ok {
+			return fmt.Errorf("Missing csv column %q", name)
+		}
+	}
+	return nil
+}
+
+func (cols columns) has(name string) bool {
+	_, ok := cols[name]
+	return ok
+}
+
+func (cols columns) get(record []string, name string) string {
+	i, ok := cols[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func parseWeight(value string, multiplier float64) (float32, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	weight, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid weight %q", value)
+	}
+	return float32(weight * multiplier), nil
+}
+
+func parseReps(value string) (int16, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	reps, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid reps %q", value)
+	}
+	return int16(reps), nil
+}
+
+type MappingReport struct {
+	Mapped  map[string]string `json:"mapped"`
+	Unknown []string          `json:"unknown"`
+}
+
+var equipmentSuffix = regexp.MustCompile(`\s*\(.*\)\s*$`)
+
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// MapExercises resolves every exercise name in the import onto the catalog.
+// Overrides supplied by the caller win, followed by a case insensitive match
+// and finally a match with the equipment suffix, e.g. "(Barbell)", removed.
+func MapExercises(workouts []Workout, catalog []string, overrides map[string]string) MappingReport {
+	known := make(map[string]string, len(catalog))
+	for _, name := range catalog {
+		known[normalizeName(name)] = name
+	}
+
+	report := MappingReport{
+		Mapped:  make(map[string]string),
+		Unknown: []string{},
+	}
+
+	for _, workout := range workouts {
+		for _, set := range workout.Sets {
+			if _, ok := report.Mapped[set.Exercise]; ok {
+				continue
+			}
+
+			if name, ok := resolve(set.Exercise, known, overrides); ok {
+				report.Mapped[set.Exercise] = name
+				continue
+			}
+
+			if !contains(report.Unknown, set.Exercise) {
+				report.Unknown = append(report.Unknown, set.Exercise)
+			}
+		}
+	}
+
+	sort.Strings(report.Unknown)
+	return report
+}
+
+func resolve(name string, known map[string]string, overrides map[string]string) (string, bool) {
+	if override, ok := overrides[name]; ok {
+		canonical, ok := known[normalizeName(override)]
+		return canonical, ok
+	}
+
+	if canonical, ok := known[normalizeName(name)]; ok {
+		return canonical, true
+	}
+
+	canonical, ok := known[normalizeName(equipmentSuffix.ReplaceAllString(name, ""))]
+	return canonical, ok
+}
+
+func contains(names []string, name string) bool {
+	for _, v := range names {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStrong(t *testing.T) {
+	data := `Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE
+2022-11-01 18:30:00,"Push",1h 5m,"Bench Press (Barbell)",1,185,8,0,0,"paused",,
+2022-11-01 18:30:00,"Push",1h 5m,"Bench Press (Barbell)",2,185,7,0,0,,,
+2022-11-01 18:30:00,"Push",1h 5m,"Treadmill",1,0,0,1.5,600,,,
+2022-11-03 07:00:00,"Pull",45m,"Deadlift (Barbell)",1,315,5,0,0,,"felt strong",
+`
+
+	workouts, err := Parse(SourceStrong, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, workouts, 2)
+
+	push := workouts[0]
+	require.Equal(t, time.Date(2022, 11, 1, 18, 30, 0, 0, time.UTC), push.StartTime)
+	require.Equal(t, push.StartTime.Add(65*time.Minute), push.FinishTime)
+	require.Len(t, push.Sets, 2)
+	require.Equal(t, Set{Exercise: "Bench Press (Barbell)", Weight: 185, Reps: 8, Notes: "paused"}, push.Sets[0])
+
+	pull := workouts[1]
+	require.Equal(t, "felt strong", pull.Notes)
+	require.Equal(t, pull.StartTime.Add(45*time.Minute), pull.FinishTime)
+	require.Len(t, pull.Sets, 1)
+}
+
+func TestParseHevy(t *testing.T) {
+	data := `"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_kg","reps","distance_km","duration_seconds","rpe"
+"Legs","5 Nov 2022, 09:00","5 Nov 2022, 10:15","","Squat (Barbell)",,"",0,"normal",100,5,,,
+"Legs","5 Nov 2022, 09:00","5 Nov 2022, 10:15","","Squat (Barbell)",,"",1,"normal",100,5,,,
+`
+
+	workouts, err := Parse(SourceHevy, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, workouts, 1)
+	require.Equal(t, 75*time.Minute, workouts[0].FinishTime.Sub(workouts[0].StartTime))
+	require.Len(t, workouts[0].Sets, 2)
+	require.InDelta(t, 220.462, workouts[0].Sets[0].Weight, 0.01)
+}
+
+func TestParseFitNotes(t *testing.T) {
+	data := `Date,Exercise,Category,Weight (lbs),Reps,Distance,Distance Unit,Time,Comment
+2022-12-01,Overhead Press,Shoulders,95.0,8,,,,
+2022-12-01,Lateral Raise,Shoulders,20.0,12,,,,slow
+2022-12-02,Barbell Row,Back,135.0,10,,,,
+`
+
+	workouts, err := Parse(SourceFitNotes, strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, workouts, 2)
+	require.Len(t, workouts[0].Sets, 2)
+	require.Equal(t, "slow", workouts[0].Sets[1].Notes)
+	require.Equal(t, float32(135), workouts[1].Sets[0].Weight)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(Source("myfitnesspal"), strings.NewReader("Date\n"))
+	require.ErrorIs(t, err, ErrUnsupportedSource)
+
+	_, err = Parse(SourceStrong, strings.NewReader("Date,Exercise Name\n"))
+	require.Error(t, err)
+
+	_, err = Parse(SourceFitNotes, strings.NewReader("Date,Exercise,Weight (lbs),Reps\nyesterday,Squat,100,5\n"))
+	require.Error(t, err)
+}
+
+func TestMapExercises(t *testing.T) {
+	workouts := []Workout{
+		{
+			Sets: []Set{
+				{Exercise: "Bench Press (Barbell)", Reps: 5},
+				{Exercise: "deadlift", Reps: 5},
+				{Exercise: "Skullcrusher", Reps: 10},
+				{Exercise: "Cable Fly", Reps: 10},
+				{Exercise: "Cable Fly", Reps: 10},
+			},
+		},
+	}
+	catalog := []string{"Bench Press", "Deadlift", "Lying Tricep Extension"}
+	overrides := map[string]string{"Skullcrusher": "lying tricep extension"}
+
+	report := MapExercises(workouts, catalog, overrides)
+	require.Equal(t, map[string]string{
+		"Bench Press (Barbell)": "Bench Press",
+		"deadlift":              "Deadlift",
+		"Skullcrusher":          "Lying Tricep Extension",
+	}, report.Mapped)
+	require.Equal(t, []string{"Cable Fly"}, report.Unknown)
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const strongDateLayout = "2006-01-02 15:04:05"
+
+var strongDuration = regexp.MustCompile(`(?:(\d+)h)?\s*(?:(\d+)m)?`)
+
+func strongParser(cols columns) (func(columns, []string) (string, Workout, Set, error), error) {
+	err := cols.require("date", "workout name", "exercise name", "weight", "reps")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(cols columns, record []string) (string, Workout, Set, error) {
+		var workout Workout
+		var set Set
+
+		date := cols.get(record, "date")
+		start, err := time.Parse(strongDateLayout, date)
+		if err != nil {
+			return "", workout, set, fmt.Errorf("Invalid date %q", date)
+		}
+
+		workout.StartTime = start
+		workout.FinishTime = start.Add(parseStrongDuration(cols.get(record, "duration")))
+		workout.Notes = cols.get(record, "workout notes")
+
+		set.Exercise = cols.get(record, "exercise name")
+		set.Notes = cols.get(record, "notes")
+		set.Weight, err = parseWeight(cols.get(record, "weight"), 1)
+		if err != nil {
+			return "", workout, set, err
+		}
+
+		set.Reps, err = parseReps(cols.get(record, "reps"))
+		if err != nil {
+			return "", workout, set, err
+		}
+
+		return date + cols.get(record, "workout name"), workout, set, nil
+	}, nil
+}
+
+func parseStrongDuration(value string) time.Duration {
+	match := strongDuration.FindStringSubmatch(value)
+	if match == nil {
+		return 0
+	}
+
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+}
@@ -0,0 +1,70 @@
+package limiter
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter tracks failed attempts per key. Once a key has used up its free
+// attempts every further failure locks it for twice as long as the last.
+type Limiter interface {
+	// Check reports how long key is still locked for, zero when an attempt
+	// is allowed.
+	Check(ctx context.Context, key string) (time.Duration, error)
+	// Fail records a failed attempt and reports the lockout it caused.
+	Fail(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy describes when a key gets locked. Failures are forgotten once
+// MaxDelay passes without another one.
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+type state struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+func (st state) wait(now time.Time) time.Duration {
+	if now.Before(st.LockedUntil) {
+		return st.LockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// fail returns st with one more failure at now applied.
+func (policy Policy) fail(st state, now time.Time) state {
+	if now.Sub(st.LastFailure) >= policy.MaxDelay {
+		st.Failures = 0
+	}
+
+	st.Failures++
+	st.LastFailure = now
+
+	if delay := policy.lockout(st.Failures); delay > 0 {
+		st.LockedUntil = now.Add(delay)
+	}
+	return st
+}
+
+// lockout returns how long a key is locked for after its nth failure in a
+// row, zero while it still has free attempts.
+func (policy Policy) lockout(failures int) time.Duration {
+	over := failures - policy.MaxAttempts
+	if over < 0 {
+		return 0
+	}
+
+	delay := policy.MaxDelay
+	if over < 32 {
+		if d := policy.BaseDelay << over; d > 0 && d < policy.MaxDelay {
+			delay = d
+		}
+	}
+	return delay
+}
@@ -0,0 +1,131 @@
+package limiter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = Policy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: 10 * time.Minute}
+
+func TestPolicyFail(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	expected := []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute, 10 * time.Minute}
+
+	var st state
+	for i, wait := range expected {
+		st = testPolicy.fail(st, now)
+		require.Equal(t, i+1, st.Failures)
+		require.Equal(t, wait, st.wait(now), "failure %d", i+1)
+	}
+
+	st = testPolicy.fail(st, now.Add(testPolicy.MaxDelay))
+	require.Equal(t, 1, st.Failures)
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter := NewMemoryLimiter(testPolicy)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < testPolicy.MaxAttempts-1; i++ {
+		wait, err := limiter.Fail(ctx, "account:a")
+		require.NoError(t, err)
+		require.Zero(t, wait)
+	}
+
+	wait, err := limiter.Fail(ctx, "account:a")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, wait)
+
+	wait, err = limiter.Check(ctx, "account:a")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, wait)
+
+	wait, err = limiter.Check(ctx, "account:b")
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	now = now.Add(30 * time.Second)
+	wait, err = limiter.Check(ctx, "account:a")
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Second, wait)
+
+	require.NoError(t, limiter.Reset(ctx, "account:a"))
+	wait, err = limiter.Check(ctx, "account:a")
+	require.NoError(t, err)
+	require.Zero(t, wait)
+}
+
+func TestMemoryLimiterPrune(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter := NewMemoryLimiter(testPolicy)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < pruneAt; i++ {
+		limiter.state[fmt.Sprintf("account:%d", i)] = state{Failures: 1, LastFailure: now.Add(-testPolicy.MaxDelay)}
+	}
+
+	_, err := limiter.Fail(ctx, "account:a")
+	require.NoError(t, err)
+	require.Len(t, limiter.state, 1)
+}
+
+func TestPostgresLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	limiter := NewPostgresLimiter(store, testPolicy)
+	limiter.now = func() time.Time { return now }
+
+	store.EXPECT().GetLoginAttempt(gomock.Any(), "ip:1.2.3.4").Times(1).Return(db.LoginAttempt{}, sql.ErrNoRows)
+	wait, err := limiter.Check(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	record := db.RecordLoginFailureParams{
+		Key:         "ip:1.2.3.4",
+		Now:         now,
+		ResetBefore: now.Add(-testPolicy.MaxDelay),
+	}
+
+	// the failure count comes from the database, a free attempt isn't locked
+	store.EXPECT().RecordLoginFailure(gomock.Any(), record).Times(1).Return(db.LoginAttempt{Key: "ip:1.2.3.4", Failures: 2}, nil)
+	store.EXPECT().LockLoginAttempt(gomock.Any(), gomock.Any()).Times(0)
+
+	wait, err = limiter.Fail(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	require.Zero(t, wait)
+
+	store.EXPECT().RecordLoginFailure(gomock.Any(), record).Times(1).Return(db.LoginAttempt{Key: "ip:1.2.3.4", Failures: 3}, nil)
+	store.EXPECT().LockLoginAttempt(gomock.Any(), db.LockLoginAttemptParams{
+		LockedUntil: now.Add(time.Minute),
+		Key:         "ip:1.2.3.4",
+	}).Times(1).Return(nil)
+
+	wait, err = limiter.Fail(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, wait)
+
+	store.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).Times(1).Return(db.LoginAttempt{}, sql.ErrConnDone)
+	_, err = limiter.Check(ctx, "ip:1.2.3.4")
+	require.ErrorIs(t, err, sql.ErrConnDone)
+
+	store.EXPECT().DeleteLoginAttempt(gomock.Any(), "ip:1.2.3.4").Times(1).Return(nil)
+	require.NoError(t, limiter.Reset(ctx, "ip:1.2.3.4"))
+}
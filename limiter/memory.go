@@ -0,0 +1,66 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pruneAt is how many keys MemoryLimiter holds before dropping the ones
+// whose failures have already been forgotten.
+const pruneAt = 10000
+
+// MemoryLimiter keeps attempts in process. Counts are lost on restart and not
+// shared between instances.
+type MemoryLimiter struct {
+	policy Policy
+	now    func() time.Time
+
+	mu    sync.Mutex
+	state map[string]state
+}
+
+func NewMemoryLimiter(policy Policy) *MemoryLimiter {
+	return &MemoryLimiter{
+		policy: policy,
+		now:    time.Now,
+		state:  map[string]state{},
+	}
+}
+
+func (limiter *MemoryLimiter) Check(ctx context.Context, key string) (time.Duration, error) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	return limiter.state[key].wait(limiter.now()), nil
+}
+
+func (limiter *MemoryLimiter) Fail(ctx context.Context, key string) (time.Duration, error) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	if len(limiter.state) >= pruneAt {
+		limiter.prune(now)
+	}
+
+	st := limiter.policy.fail(limiter.state[key], now)
+	limiter.state[key] = st
+	return st.wait(now), nil
+}
+
+func (limiter *MemoryLimiter) Reset(ctx context.Context, key string) error {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	delete(limiter.state, key)
+	return nil
+}
+
+func (limiter *MemoryLimiter) prune(now time.Time) {
+	for key, st := range limiter.state {
+		if st.wait(now) == 0 && now.Sub(st.LastFailure) >= limiter.policy.MaxDelay {
+			delete(limiter.state, key)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package limiter
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+)
+
+// PostgresLimiter keeps attempts in the login_attempt table so lockouts hold
+// across restarts and every instance behind a load balancer. Failures are
+// counted by the database in one statement so concurrent ones all count.
+type PostgresLimiter struct {
+	store  db.Querier
+	policy Policy
+	now    func() time.Time
+}
+
+func NewPostgresLimiter(store db.Querier, policy Policy) *PostgresLimiter {
+	return &PostgresLimiter{
+		store:  store,
+		policy: policy,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (limiter *PostgresLimiter) load(ctx context.Context, key string) (state, error) {
+	attempt, err := limiter.store.GetLoginAttempt(ctx, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return state{}, nil
+		}
+		return state{}, err
+	}
+
+	return state{
+		Failures:    int(attempt.Failures),
+		LastFailure: attempt.LastFailure,
+		LockedUntil: attempt.LockedUntil,
+	}, nil
+}
+
+func (limiter *PostgresLimiter) Check(ctx context.Context, key string) (time.Duration, error) {
+	st, err := limiter.load(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return st.wait(limiter.now()), nil
+}
+
+func (limiter *PostgresLimiter) Fail(ctx context.Context, key string) (time.Duration, error) {
+	now := limiter.now()
+	attempt, err := limiter.store.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+		Key:         key,
+		Now:         now,
+		ResetBefore: now.Add(-limiter.policy.MaxDelay),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	delay := limiter.policy.lockout(int(attempt.Failures))
+	if delay == 0 {
+		return 0, nil
+	}
+
+	// never shortens a lockout a concurrent failure already set
+	err = limiter.store.LockLoginAttempt(ctx, db.LockLoginAttemptParams{
+		LockedUntil: now.Add(delay),
+		Key:         key,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return delay, nil
+}
+
+func (limiter *PostgresLimiter) Reset(ctx context.Context, key string) error {
+	return limiter.store.DeleteLoginAttempt(ctx, key)
+}
@@ -0,0 +1,27 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer writes each message to its own .eml file for local development.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("Failed to create mail directory: %w", err)
+	}
+	return &FileMailer{dir, from}, nil
+}
+
+func (mailer *FileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), msg.To)
+	return os.WriteFile(filepath.Join(mailer.dir, filepath.Base(name)), msg.rfc822(mailer.from), 0o644)
+}
@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryMailer(t *testing.T) {
+	mailer := NewMemoryMailer()
+	msg := Message{To: "lifter@example.com", Subject: "Reset", Body: "token"}
+
+	require.NoError(t, mailer.Send(context.Background(), msg))
+	require.Equal(t, []Message{msg}, mailer.Sent())
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewFileMailer(filepath.Join(dir, "mail"), "no-reply@example.com")
+	require.NoError(t, err)
+
+	msg := Message{To: "lifter@example.com", Subject: "Reset", Body: "line one\nline two"}
+	require.NoError(t, mailer.Send(context.Background(), msg))
+
+	files, err := filepath.Glob(filepath.Join(dir, "mail", "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), "From: no-reply@example.com\r\n")
+	require.Contains(t, string(data), "To: lifter@example.com\r\n")
+	require.Contains(t, string(data), "Subject: Reset\r\n")
+	require.Contains(t, string(data), "\r\n\r\nline one\r\nline two")
+}
@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"log"
 
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/api"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/catalog"
 	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
 	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
 	_ "github.com/lib/pq"
 )
 
 func main() {
+	seedOnly := flag.Bool("seed", false, "seed the exercise catalog and exit")
+	flag.Parse()
+
 	config, err := util.LoadConfig(".")
 	if err != nil {
 		log.Fatal("Failed to load config file", err)
@@ -22,6 +28,19 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
+	if config.SeedCatalog || *seedOnly {
+		res, err := catalog.Seed(context.Background(), store)
+		if err != nil {
+			log.Fatal("Failed to seed catalog:", err)
+		}
+		log.Printf("catalog version %d (applied: %t, created: %d, updated: %d, skipped: %d)",
+			res.Version, res.Applied, res.Created, res.Updated, res.Skipped)
+	}
+
+	if *seedOnly {
+		return
+	}
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("Failed to create server:", err)
@@ -0,0 +1,231 @@
+// Package oidctest runs a stand-in OpenID Connect provider for tests.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/golang-jwt/jwt"
+)
+
+const keyID = "oidctest"
+
+var b64 = base64.RawURLEncoding
+
+// Identity is the user that signs in at the provider.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type grant struct {
+	identity    Identity
+	clientID    string
+	redirectURI string
+	nonce       string
+	challenge   string
+}
+
+// Server is an identity provider with discovery, token and key set
+// endpoints. Tests play the user's browser through Authorize.
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+	// Claims, when set, may change an id token's claims before it is signed.
+	Claims func(claims jwt.MapClaims)
+
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	server := &Server{
+		ClientID:     "lift-tracker",
+		ClientSecret: util.RandomString(32),
+		key:          key,
+		grants:       map[string]grant{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", server.discovery)
+	mux.HandleFunc("/token", server.token)
+	mux.HandleFunc("/jwks", server.jwks)
+	server.Server = httptest.NewServer(mux)
+	return server
+}
+
+// Provider is the configuration a client needs to use this server.
+func (server *Server) Provider(name, redirectURL string) util.OIDCProvider {
+	return util.OIDCProvider{
+		Name:         name,
+		Issuer:       server.URL,
+		ClientID:     server.ClientID,
+		ClientSecret: server.ClientSecret,
+		RedirectURL:  redirectURL,
+	}
+}
+
+func (server *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 server.URL,
+		"authorization_endpoint": server.URL + "/authorize",
+		"token_endpoint":         server.URL + "/token",
+		"jwks_uri":               server.URL + "/jwks",
+	})
+}
+
+func (server *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := server.key.PublicKey
+	writeJSON(w, http.StatusOK, token.JWKS{Keys: []token.JWK{{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: "RS256",
+		KeyID:     keyID,
+		N:         b64.EncodeToString(pub.N.Bytes()),
+		E:         b64.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+// Authorize signs identity in through authURL, as the user's browser would,
+// and returns the callback url the provider redirects back to.
+func (server *Server) Authorize(authURL string, identity Identity) (string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		return "", fmt.Errorf("oidctest: not an authorization code request with PKCE: %s", authURL)
+	}
+	if q.Get("client_id") != server.ClientID {
+		return "", fmt.Errorf("oidctest: unknown client %q", q.Get("client_id"))
+	}
+
+	code := util.RandomString(32)
+	server.mu.Lock()
+	server.grants[code] = grant{
+		identity:    identity,
+		clientID:    q.Get("client_id"),
+		redirectURI: q.Get("redirect_uri"),
+		nonce:       q.Get("nonce"),
+		challenge:   q.Get("code_challenge"),
+	}
+	server.mu.Unlock()
+
+	callback, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		return "", err
+	}
+	cq := callback.Query()
+	cq.Set("code", code)
+	cq.Set("state", q.Get("state"))
+	callback.RawQuery = cq.Encode()
+	return callback.String(), nil
+}
+
+func (server *Server) token(w http.ResponseWriter, r *http.Request) {
+	fail := func(code, description string) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+	}
+
+	if r.Method != http.MethodPost {
+		fail("invalid_request", "token requests must be POST")
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if id, _ = url.QueryUnescape(id); !ok || id != server.ClientID {
+		fail("invalid_client", "unknown client")
+		return
+	}
+	if secret, _ = url.QueryUnescape(secret); secret != server.ClientSecret {
+		fail("invalid_client", "wrong client secret")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		fail("unsupported_grant_type", "")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	server.mu.Lock()
+	g, ok := server.grants[code]
+	delete(server.grants, code)
+	server.mu.Unlock()
+
+	if !ok {
+		fail("invalid_grant", "unknown or used code")
+		return
+	}
+	if r.PostForm.Get("redirect_uri") != g.redirectURI {
+		fail("invalid_grant", "redirect_uri does not match")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if b64.EncodeToString(sum[:]) != g.challenge {
+		fail("invalid_grant", "code_verifier does not match")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            server.URL,
+		"aud":            g.clientID,
+		"sub":            g.identity.Subject,
+		"email":          g.identity.Email,
+		"email_verified": g.identity.EmailVerified,
+		"name":           g.identity.Name,
+		"nonce":          g.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	}
+	if server.Claims != nil {
+		server.Claims(claims)
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(server.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": util.RandomString(32),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
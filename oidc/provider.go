@@ -0,0 +1,340 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/util"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// leeway absorbs clock drift between us and the provider when checking
+	// the id token's timestamps.
+	leeway = time.Minute
+	// keyRefetchInterval stops tokens with made up key ids from making us
+	// fetch the key set on every request.
+	keyRefetchInterval = time.Minute
+)
+
+var (
+	defaultScopes = []string{"openid", "email", "profile"}
+	b64           = base64.RawURLEncoding
+
+	ErrInvalidIDToken = errors.New("oidc: id token is invalid")
+)
+
+// Claims is what we take from a verified id token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider runs the authorization code flow with PKCE against one OpenID
+// Connect provider. Discovery and signing keys are fetched on first use and
+// the keys are fetched again when a token names one we haven't seen.
+type Provider struct {
+	config util.OIDCProvider
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+func NewProvider(config util.OIDCProvider, client *http.Client) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = defaultScopes
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{config: config, client: client, now: time.Now}
+}
+
+func (provider *Provider) Name() string {
+	return provider.config.Name
+}
+
+// NewVerifier returns a PKCE code verifier, Challenge derives what is sent
+// in the authorization request from it.
+func NewVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return b64.EncodeToString(b), nil
+}
+
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return b64.EncodeToString(sum[:])
+}
+
+func (provider *Provider) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := provider.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: GET %s returned %s", endpoint, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func (provider *Provider) loadDiscovery(ctx context.Context) (*discovery, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if provider.discovery != nil {
+		return provider.discovery, nil
+	}
+
+	var doc discovery
+	endpoint := strings.TrimSuffix(provider.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := provider.getJSON(ctx, endpoint, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Issuer != provider.config.Issuer {
+		return nil, fmt.Errorf("oidc: discovery issuer %q does not match %q", doc.Issuer, provider.config.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc: discovery document for %s is incomplete", provider.config.Issuer)
+	}
+
+	provider.discovery = &doc
+	return provider.discovery, nil
+}
+
+// AuthCodeURL is where the user is sent to sign in. state and nonce tie the
+// callback and id token to this attempt.
+func (provider *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	doc, err := provider.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {provider.config.ClientID},
+		"redirect_uri":          {provider.config.RedirectURL},
+		"scope":                 {strings.Join(provider.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return doc.AuthorizationEndpoint + sep + query.Encode(), nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange trades the authorization code for tokens and returns the claims
+// of the verified id token.
+func (provider *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (Claims, error) {
+	doc, err := provider.loadDiscovery(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {provider.config.RedirectURL},
+		"code_verifier": {verifier},
+		"client_id":     {provider.config.ClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Claims{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if provider.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(provider.config.ClientID), url.QueryEscape(provider.config.ClientSecret))
+	}
+
+	res, err := provider.client.Do(req)
+	if err != nil {
+		return Claims{}, err
+	}
+	defer res.Body.Close()
+
+	var tokens tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&tokens); err != nil {
+		return Claims{}, fmt.Errorf("oidc: token response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK || tokens.Error != "" {
+		return Claims{}, fmt.Errorf("oidc: token exchange failed: %s %s", tokens.Error, tokens.ErrorDescription)
+	}
+
+	if tokens.IDToken == "" {
+		return Claims{}, fmt.Errorf("oidc: token response has no id_token")
+	}
+	return provider.verify(ctx, doc, tokens.IDToken, nonce)
+}
+
+func (provider *Provider) verify(ctx context.Context, doc *discovery, idToken, nonce string) (Claims, error) {
+	parser := jwt.Parser{
+		ValidMethods:         []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()},
+		SkipClaimsValidation: true,
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return provider.key(ctx, doc, kid)
+	})
+	if err != nil {
+		return Claims{}, ErrInvalidIDToken
+	}
+
+	now := provider.now()
+	str := func(name string) string {
+		s, _ := claims[name].(string)
+		return s
+	}
+	unix := func(name string) (time.Time, bool) {
+		n, ok := claims[name].(float64)
+		return time.Unix(int64(n), 0), ok
+	}
+
+	if str("iss") != provider.config.Issuer || !audienceContains(claims["aud"], provider.config.ClientID) {
+		return Claims{}, ErrInvalidIDToken
+	}
+
+	exp, ok := unix("exp")
+	if !ok || now.After(exp.Add(leeway)) {
+		return Claims{}, ErrInvalidIDToken
+	}
+	if iat, ok := unix("iat"); ok && iat.After(now.Add(leeway)) {
+		return Claims{}, ErrInvalidIDToken
+	}
+
+	if str("nonce") != nonce || str("sub") == "" {
+		return Claims{}, ErrInvalidIDToken
+	}
+
+	verified, _ := claims["email_verified"].(bool)
+	return Claims{
+		Subject:       str("sub"),
+		Email:         str("email"),
+		EmailVerified: verified,
+		Name:          str("name"),
+	}, nil
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// key returns the provider's public key named kid, fetching the key set
+// again once if it isn't known yet so rotations are picked up.
+func (provider *Provider) key(ctx context.Context, doc *discovery, kid string) (interface{}, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if key, ok := provider.keys[kid]; ok {
+		return key, nil
+	}
+
+	if provider.now().Sub(provider.fetchedAt) < keyRefetchInterval {
+		return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+	}
+
+	var set token.JWKS
+	if err := provider.getJSON(ctx, doc.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range set.Keys {
+		if key, err := publicKey(jwk); err == nil {
+			keys[jwk.KeyID] = key
+		}
+	}
+	provider.keys = keys
+	provider.fetchedAt = provider.now()
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("oidc: unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func publicKey(jwk token.JWK) (interface{}, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := b64.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		x, err := b64.DecodeString(jwk.X)
+		if err != nil || jwk.Curve != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("oidc: unsupported OKP key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("oidc: unsupported key type %q", jwk.KeyType)
+}
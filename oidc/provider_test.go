@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/oidc/oidctest"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:8080/user/oidc/test/callback"
+
+var identity = oidctest.Identity{
+	Subject:       "subject-1",
+	Email:         "lifter@example.com",
+	EmailVerified: true,
+	Name:          "Lifter",
+}
+
+// signIn runs the flow up to the callback and returns its code.
+func signIn(t *testing.T, server *oidctest.Server, provider *Provider, verifier, nonce string) string {
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", nonce, verifier)
+	require.NoError(t, err)
+
+	callback, err := server.Authorize(authURL, identity)
+	require.NoError(t, err)
+
+	u, err := url.Parse(callback)
+	require.NoError(t, err)
+	require.Equal(t, "state-1", u.Query().Get("state"))
+	return u.Query().Get("code")
+}
+
+func TestExchange(t *testing.T) {
+	server := oidctest.NewServer()
+	defer server.Close()
+
+	provider := NewProvider(server.Provider("test", redirectURL), server.Client())
+	require.Equal(t, "test", provider.Name())
+
+	verifier, err := NewVerifier()
+	require.NoError(t, err)
+
+	code := signIn(t, server, provider, verifier, "nonce-1")
+	claims, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.NoError(t, err)
+	require.Equal(t, Claims{
+		Subject:       identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: true,
+		Name:          identity.Name,
+	}, claims)
+
+	// codes are single use
+	_, err = provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.Error(t, err)
+}
+
+func TestExchangeRejects(t *testing.T) {
+	testCases := []struct {
+		name     string
+		claims   func(claims jwt.MapClaims)
+		verifier func(verifier string) string
+		nonce    string
+	}{
+		{
+			name:  "WrongNonce",
+			nonce: "other-nonce",
+		},
+		{
+			name:     "WrongVerifier",
+			verifier: func(string) string { return "not-the-verifier" },
+		},
+		{
+			name:   "WrongAudience",
+			claims: func(claims jwt.MapClaims) { claims["aud"] = "someone-else" },
+		},
+		{
+			name:   "WrongIssuer",
+			claims: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+		},
+		{
+			name:   "Expired",
+			claims: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		},
+		{
+			name:   "IssuedInFuture",
+			claims: func(claims jwt.MapClaims) { claims["iat"] = time.Now().Add(time.Hour).Unix() },
+		},
+		{
+			name:   "NoSubject",
+			claims: func(claims jwt.MapClaims) { delete(claims, "sub") },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := oidctest.NewServer()
+			defer server.Close()
+			server.Claims = tc.claims
+
+			provider := NewProvider(server.Provider("test", redirectURL), server.Client())
+			verifier, err := NewVerifier()
+			require.NoError(t, err)
+
+			code := signIn(t, server, provider, verifier, "nonce-1")
+
+			nonce := "nonce-1"
+			if tc.nonce != "" {
+				nonce = tc.nonce
+			}
+			if tc.verifier != nil {
+				verifier = tc.verifier(verifier)
+			}
+
+			_, err = provider.Exchange(context.Background(), code, verifier, nonce)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestExchangeAcceptsAudienceList(t *testing.T) {
+	server := oidctest.NewServer()
+	defer server.Close()
+	server.Claims = func(claims jwt.MapClaims) {
+		claims["aud"] = []string{"another-client", server.ClientID}
+	}
+
+	provider := NewProvider(server.Provider("test", redirectURL), server.Client())
+	verifier, err := NewVerifier()
+	require.NoError(t, err)
+
+	code := signIn(t, server, provider, verifier, "nonce-1")
+	_, err = provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.NoError(t, err)
+}
+
+func TestUnknownKeyRefetch(t *testing.T) {
+	server := oidctest.NewServer()
+	defer server.Close()
+
+	provider := NewProvider(server.Provider("test", redirectURL), server.Client())
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	verifier, err := NewVerifier()
+	require.NoError(t, err)
+
+	// a stale key set without the provider's key was fetched moments ago
+	_, err = provider.loadDiscovery(context.Background())
+	require.NoError(t, err)
+	provider.keys = map[string]interface{}{}
+	provider.fetchedAt = now
+
+	code := signIn(t, server, provider, verifier, "nonce-1")
+	_, err = provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.ErrorIs(t, err, ErrInvalidIDToken)
+
+	// after the refetch interval the rotated key is picked up
+	now = now.Add(keyRefetchInterval)
+	code = signIn(t, server, provider, verifier, "nonce-1")
+	_, err = provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.NoError(t, err)
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	server := oidctest.NewServer()
+	defer server.Close()
+
+	config := server.Provider("test", redirectURL)
+	config.Issuer = server.URL + "/"
+
+	provider := NewProvider(config, server.Client())
+	_, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	require.Error(t, err)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	server := oidctest.NewServer()
+	defer server.Close()
+
+	provider := NewProvider(server.Provider("test", redirectURL), server.Client())
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, "openid email profile", q.Get("scope"))
+	require.Equal(t, redirectURL, q.Get("redirect_uri"))
+	require.Equal(t, Challenge("verifier"), q.Get("code_challenge"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+}
@@ -0,0 +1,132 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/google/uuid"
+)
+
+type cacheEntry struct {
+	revoked bool
+	until   time.Time
+}
+
+type cutoff struct {
+	at    time.Time
+	until time.Time
+}
+
+// Cache sits in front of a shared store so authenticated requests don't all
+// hit the database. A revoked token is remembered until it expires, a token
+// that was not revoked is only trusted for ttl, which is how long a
+// revocation made by another instance can take to be noticed here.
+// Revocations made through the cache apply locally right away.
+type Cache struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]cacheEntry
+	// cutoffs made on this instance, kept for ttl so entries cached before
+	// the cutoff can't outlive it
+	cutoffs map[uuid.UUID]cutoff
+}
+
+func NewCache(store Store, ttl time.Duration) *Cache {
+	return &Cache{
+		store:   store,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[uuid.UUID]cacheEntry{},
+		cutoffs: map[uuid.UUID]cutoff{},
+	}
+}
+
+func (cache *Cache) Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	if err := cache.store.Revoke(ctx, tokenID, userID, expiresAt); err != nil {
+		return err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.set(tokenID, cacheEntry{revoked: true, until: expiresAt})
+	return nil
+}
+
+func (cache *Cache) RevokeAll(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	if err := cache.store.RevokeAll(ctx, userID, at); err != nil {
+		return err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.cutoffs[userID] = cutoff{at: at, until: cache.now().Add(cache.ttl)}
+	return nil
+}
+
+func (cache *Cache) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	now := cache.now()
+
+	cache.mu.Lock()
+	if c, ok := cache.cutoffs[payload.UserID]; ok && now.Before(c.until) && payload.IssuedAt.Before(c.at) {
+		cache.mu.Unlock()
+		return true, nil
+	}
+	entry, ok := cache.entries[payload.ID]
+	cache.mu.Unlock()
+
+	if ok && now.Before(entry.until) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := cache.store.IsRevoked(ctx, payload)
+	if err != nil {
+		return false, err
+	}
+
+	entry = cacheEntry{revoked: revoked, until: now.Add(cache.ttl)}
+	if revoked {
+		entry.until = payload.ExpiredAt
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.set(payload.ID, entry)
+	return revoked, nil
+}
+
+func (cache *Cache) Cleanup(ctx context.Context) error {
+	cache.mu.Lock()
+	cache.prune()
+	cache.mu.Unlock()
+
+	return cache.store.Cleanup(ctx)
+}
+
+func (cache *Cache) set(tokenID uuid.UUID, entry cacheEntry) {
+	if len(cache.entries) >= pruneAt {
+		cache.prune()
+	}
+	cache.entries[tokenID] = entry
+}
+
+func (cache *Cache) prune() {
+	now := cache.now()
+	for id, entry := range cache.entries {
+		if !now.Before(entry.until) {
+			delete(cache.entries, id)
+		}
+	}
+	for userID, c := range cache.cutoffs {
+		if !now.Before(c.until) {
+			delete(cache.cutoffs, userID)
+		}
+	}
+}
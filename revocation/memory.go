@@ -0,0 +1,76 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/google/uuid"
+)
+
+// MemoryStore keeps revocations in process. They are lost on restart and not
+// shared between instances.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	tokens  map[uuid.UUID]time.Time
+	cutoffs map[uuid.UUID]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		tokens:  map[uuid.UUID]time.Time{},
+		cutoffs: map[uuid.UUID]time.Time{},
+	}
+}
+
+func (store *MemoryStore) Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if len(store.tokens) >= pruneAt {
+		store.prune()
+	}
+	store.tokens[tokenID] = expiresAt
+	return nil
+}
+
+func (store *MemoryStore) RevokeAll(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if at.After(store.cutoffs[userID]) {
+		store.cutoffs[userID] = at
+	}
+	return nil
+}
+
+func (store *MemoryStore) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if _, ok := store.tokens[payload.ID]; ok {
+		return true, nil
+	}
+	return payload.IssuedAt.Before(store.cutoffs[payload.UserID]), nil
+}
+
+func (store *MemoryStore) Cleanup(ctx context.Context) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.prune()
+	return nil
+}
+
+func (store *MemoryStore) prune() {
+	now := store.now()
+	for id, expiresAt := range store.tokens {
+		if !now.Before(expiresAt) {
+			delete(store.tokens, id)
+		}
+	}
+}
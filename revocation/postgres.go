@@ -0,0 +1,52 @@
+package revocation
+
+import (
+	"context"
+	"time"
+
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/google/uuid"
+)
+
+// PostgresStore keeps revocations in the revoked_token and token_cutoff
+// tables so they hold across restarts and every instance.
+type PostgresStore struct {
+	store db.Querier
+	now   func() time.Time
+}
+
+func NewPostgresStore(store db.Querier) *PostgresStore {
+	return &PostgresStore{
+		store: store,
+		now:   func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (store *PostgresStore) Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	return store.store.RevokeToken(ctx, db.RevokeTokenParams{
+		TokenID:   tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt.UTC(),
+	})
+}
+
+func (store *PostgresStore) RevokeAll(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return store.store.UpsertTokenCutoff(ctx, db.UpsertTokenCutoffParams{
+		UserID:        userID,
+		RevokedBefore: at.UTC(),
+	})
+}
+
+func (store *PostgresStore) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	return store.store.IsTokenRevoked(ctx, db.IsTokenRevokedParams{
+		TokenID:       payload.ID,
+		UserID:        payload.UserID,
+		RevokedBefore: payload.IssuedAt.UTC(),
+	})
+}
+
+func (store *PostgresStore) Cleanup(ctx context.Context) error {
+	_, err := store.store.DeleteExpiredRevokedTokens(ctx, store.now())
+	return err
+}
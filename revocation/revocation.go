@@ -0,0 +1,43 @@
+package revocation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/google/uuid"
+)
+
+// pruneAt is how many entries the in-process stores hold before dropping
+// the ones that can no longer matter.
+const pruneAt = 10000
+
+// Store remembers access tokens that were invalidated before they expired.
+type Store interface {
+	// Revoke rejects one token. The entry is only kept until expiresAt, the
+	// token is rejected as expired after that anyway.
+	Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error
+	// RevokeAll rejects every token issued to userID before at.
+	RevokeAll(ctx context.Context, userID uuid.UUID, at time.Time) error
+	IsRevoked(ctx context.Context, payload *token.Payload) (bool, error)
+	// Cleanup drops revocations of tokens that have since expired.
+	Cleanup(ctx context.Context) error
+}
+
+// RunCleanup calls store.Cleanup every interval until ctx is done.
+func RunCleanup(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := store.Cleanup(ctx); err != nil {
+				log.Printf("revoked token cleanup failed: %v", err)
+			}
+		}
+	}
+}
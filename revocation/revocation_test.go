@@ -0,0 +1,218 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/mock"
+	db "github.com/AntoninoAdornetto/isMogged-lift-tracker-service/db/sqlc"
+	"github.com/AntoninoAdornetto/isMogged-lift-tracker-service/token"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayload(userID uuid.UUID, issuedAt time.Time) *token.Payload {
+	return &token.Payload{
+		ID:        uuid.New(),
+		UserID:    userID,
+		IssuedAt:  issuedAt,
+		ExpiredAt: issuedAt.Add(15 * time.Minute),
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	first := newPayload(userID, now.Add(-time.Minute))
+	second := newPayload(userID, now.Add(-time.Minute))
+
+	revoked, err := store.IsRevoked(ctx, first)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	require.NoError(t, store.Revoke(ctx, first.ID, userID, first.ExpiredAt))
+
+	revoked, err = store.IsRevoked(ctx, first)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = store.IsRevoked(ctx, second)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// log out everywhere catches tokens issued before it, not after
+	require.NoError(t, store.RevokeAll(ctx, userID, now))
+	revoked, err = store.IsRevoked(ctx, second)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	later := newPayload(userID, now.Add(time.Second))
+	revoked, err = store.IsRevoked(ctx, later)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// an older cutoff doesn't move the existing one back
+	require.NoError(t, store.RevokeAll(ctx, userID, now.Add(-time.Hour)))
+	revoked, err = store.IsRevoked(ctx, second)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	other := newPayload(uuid.New(), now.Add(-time.Minute))
+	revoked, err = store.IsRevoked(ctx, other)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	now = first.ExpiredAt
+	require.NoError(t, store.Cleanup(ctx))
+	require.Empty(t, store.tokens)
+}
+
+func TestPostgresStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	querier := mockdb.NewMockStore(ctrl)
+	store := NewPostgresStore(querier)
+	store.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	payload := newPayload(userID, now.In(time.FixedZone("EST", -5*3600)))
+
+	querier.EXPECT().RevokeToken(gomock.Any(), gomock.Eq(db.RevokeTokenParams{
+		TokenID:   payload.ID,
+		UserID:    userID,
+		ExpiresAt: payload.ExpiredAt.UTC(),
+	})).Times(1).Return(nil)
+	require.NoError(t, store.Revoke(ctx, payload.ID, userID, payload.ExpiredAt))
+
+	querier.EXPECT().UpsertTokenCutoff(gomock.Any(), gomock.Eq(db.UpsertTokenCutoffParams{
+		UserID:        userID,
+		RevokedBefore: now,
+	})).Times(1).Return(nil)
+	require.NoError(t, store.RevokeAll(ctx, userID, now))
+
+	querier.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Eq(db.IsTokenRevokedParams{
+		TokenID:       payload.ID,
+		UserID:        userID,
+		RevokedBefore: now,
+	})).Times(1).Return(true, nil)
+	revoked, err := store.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	querier.EXPECT().DeleteExpiredRevokedTokens(gomock.Any(), gomock.Eq(now)).Times(1).Return(int64(3), nil)
+	require.NoError(t, store.Cleanup(ctx))
+
+	querier.EXPECT().IsTokenRevoked(gomock.Any(), gomock.Any()).Times(1).Return(false, sql.ErrConnDone)
+	_, err = store.IsRevoked(ctx, payload)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+// countingStore records how often the cache falls through to it.
+type countingStore struct {
+	*MemoryStore
+	lookups int
+}
+
+func (store *countingStore) IsRevoked(ctx context.Context, payload *token.Payload) (bool, error) {
+	store.lookups++
+	return store.MemoryStore.IsRevoked(ctx, payload)
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 30 * time.Second
+
+	shared := &countingStore{MemoryStore: NewMemoryStore()}
+	cache := NewCache(shared, ttl)
+	cache.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	payload := newPayload(userID, now.Add(-time.Minute))
+
+	for i := 0; i < 3; i++ {
+		revoked, err := cache.IsRevoked(ctx, payload)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	}
+	require.Equal(t, 1, shared.lookups)
+
+	// a revocation made by another instance shows up once the entry expires
+	require.NoError(t, shared.Revoke(ctx, payload.ID, userID, payload.ExpiredAt))
+	revoked, err := cache.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	now = now.Add(ttl)
+	revoked, err = cache.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	require.Equal(t, 2, shared.lookups)
+
+	// revoked tokens stay cached until they expire
+	now = now.Add(5 * time.Minute)
+	revoked, err = cache.IsRevoked(ctx, payload)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	require.Equal(t, 2, shared.lookups)
+}
+
+func TestCacheLocalRevocations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	ttl := 30 * time.Second
+
+	shared := &countingStore{MemoryStore: NewMemoryStore()}
+	cache := NewCache(shared, ttl)
+	cache.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	first := newPayload(userID, now.Add(-time.Minute))
+	second := newPayload(userID, now.Add(-time.Minute))
+
+	for _, payload := range []*token.Payload{first, second} {
+		revoked, err := cache.IsRevoked(ctx, payload)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	}
+
+	require.NoError(t, cache.Revoke(ctx, first.ID, userID, first.ExpiredAt))
+	revoked, err := cache.IsRevoked(ctx, first)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	require.NoError(t, cache.RevokeAll(ctx, userID, now))
+	revoked, err = cache.IsRevoked(ctx, second)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	require.Equal(t, 2, shared.lookups)
+
+	// both reached the shared store as well
+	revoked, err = shared.MemoryStore.IsRevoked(ctx, first)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	revoked, err = shared.MemoryStore.IsRevoked(ctx, second)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// the local cutoff only has to outlive entries cached before it
+	now = now.Add(ttl)
+	require.NoError(t, cache.Cleanup(ctx))
+	require.Empty(t, cache.cutoffs)
+
+	revoked, err = cache.IsRevoked(ctx, second)
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
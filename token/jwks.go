@@ -0,0 +1,326 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+const minRSAKeyBits = 2048
+
+// Registered claim values for services verifying tokens against the JWKS.
+// Challenge tokens have their own audience so a check pinned to
+// AccessAudience rejects them.
+const (
+	JWTIssuer         = "ismogged"
+	AccessAudience    = "ismogged:access"
+	ChallengeAudience = "ismogged:mfa_challenge"
+)
+
+// SigningKey is one private key of an asymmetric JWT key ring. Every key is
+// published in the JWKS as soon as it is loaded but only signs once
+// ActiveFrom has passed, so verifiers can fetch a new key ahead of the
+// first token that carries it.
+type SigningKey struct {
+	ID         string
+	PrivateKey crypto.Signer
+	ActiveFrom time.Time
+}
+
+// ParseSigningKeys reads keys formatted as "kid:path.pem@activeFrom,...".
+// The activation time is RFC 3339 and optional, keys without one are
+// active immediately. PEM files hold a PKCS #8 RSA or Ed25519 key, or a
+// PKCS #1 RSA key.
+func ParseSigningKeys(spec string) ([]SigningKey, error) {
+	var keys []SigningKey
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, rest, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || rest == "" {
+			return nil, fmt.Errorf("Invalid signing key %q: expected kid:path", entry)
+		}
+
+		path, activeFrom, scheduled := strings.Cut(rest, "@")
+		key := SigningKey{ID: id}
+		if scheduled {
+			t, err := time.Parse(time.RFC3339, activeFrom)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid activation time for %s: %w", id, err)
+			}
+			key.ActiveFrom = t
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read signing key %s: %w", id, err)
+		}
+
+		key.PrivateKey, err = parsePrivateKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid signing key %s: %w", id, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("At least one signing key is required")
+	}
+	return keys, nil
+}
+
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+		return signer, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+func signingMethod(key crypto.Signer) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", key)
+}
+
+// asymmetricClaims carries the registered claims standard JWT libraries
+// check next to the payload this service reads, which keeps sub second
+// issue times for the password change check.
+type asymmetricClaims struct {
+	Payload
+	jwt.StandardClaims
+}
+
+func (claims *asymmetricClaims) Valid() error {
+	if err := claims.StandardClaims.Valid(); err != nil {
+		return err
+	}
+	if !claims.VerifyIssuer(JWTIssuer, true) || claims.Subject != claims.UserID.String() {
+		return InvalidTokenError
+	}
+	return claims.Payload.Valid()
+}
+
+type asymmetricKey struct {
+	SigningKey
+	method jwt.SigningMethod
+}
+
+// AsymmetricJWTMaker signs JWTs with RS256 or EdDSA depending on the key
+// type. The kid header names the key so rotated keys keep verifying until
+// they are removed from the ring.
+type AsymmetricJWTMaker struct {
+	keys []asymmetricKey
+	now  func() time.Time
+}
+
+func NewAsymmetricJWTMaker(keys []SigningKey) (Maker, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("At least one signing key is required")
+	}
+
+	maker := &AsymmetricJWTMaker{now: time.Now}
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("Duplicate key id: %s", key.ID)
+		}
+		seen[key.ID] = true
+
+		method, err := signingMethod(key.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid signing key %s: %w", key.ID, err)
+		}
+		maker.keys = append(maker.keys, asymmetricKey{key, method})
+	}
+
+	if _, err := maker.currentKey(); err != nil {
+		return nil, err
+	}
+	return maker, nil
+}
+
+// currentKey returns the most recently activated key. Keys activated at
+// the same time sign in the order they were configured.
+func (maker *AsymmetricJWTMaker) currentKey() (*asymmetricKey, error) {
+	now := maker.now()
+
+	var current *asymmetricKey
+	for i := range maker.keys {
+		key := &maker.keys[i]
+		if key.ActiveFrom.After(now) {
+			continue
+		}
+		if current == nil || key.ActiveFrom.After(current.ActiveFrom) {
+			current = key
+		}
+	}
+
+	if current == nil {
+		return nil, fmt.Errorf("No signing key is active yet")
+	}
+	return current, nil
+}
+
+func (maker *AsymmetricJWTMaker) CreateToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	return maker.sign(payload, AccessAudience)
+}
+
+func (maker *AsymmetricJWTMaker) CreateChallengeToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	payload.Challenge = true
+
+	return maker.sign(payload, ChallengeAudience)
+}
+
+func (maker *AsymmetricJWTMaker) sign(payload *Payload, audience string) (string, error) {
+	key, err := maker.currentKey()
+	if err != nil {
+		return "", err
+	}
+
+	claims := &asymmetricClaims{
+		Payload: *payload,
+		StandardClaims: jwt.StandardClaims{
+			Id:        payload.ID.String(),
+			Subject:   payload.UserID.String(),
+			Issuer:    JWTIssuer,
+			Audience:  audience,
+			IssuedAt:  payload.IssuedAt.Unix(),
+			NotBefore: payload.IssuedAt.Unix(),
+			ExpiresAt: payload.ExpiredAt.Unix(),
+		},
+	}
+
+	jwtToken := jwt.NewWithClaims(key.method, claims)
+	jwtToken.Header["kid"] = key.ID
+	return jwtToken.SignedString(key.PrivateKey)
+}
+
+func (maker *AsymmetricJWTMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range maker.keys {
+			// the algorithm is pinned per key so a token can't pick one
+			if key.ID == kid && token.Method.Alg() == key.method.Alg() {
+				return key.PrivateKey.Public(), nil
+			}
+		}
+		return nil, InvalidTokenError
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &asymmetricClaims{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && (verr.Errors&jwt.ValidationErrorExpired != 0 || errors.Is(verr.Inner, ExpiredTokenErr)) {
+			return nil, ExpiredTokenErr
+		}
+		return nil, InvalidTokenError
+	}
+
+	claims, ok := jwtToken.Claims.(*asymmetricClaims)
+	if !ok {
+		return nil, InvalidTokenError
+	}
+
+	// the audience decides what a token is for, not the challenge field
+	switch claims.Audience {
+	case AccessAudience:
+		claims.Challenge = false
+	case ChallengeAudience:
+		claims.Challenge = true
+	default:
+		return nil, InvalidTokenError
+	}
+
+	return &claims.Payload, nil
+}
+
+// JWK is the public half of a signing key as described by RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySetProvider is implemented by makers whose tokens other services can
+// verify with published public keys.
+type KeySetProvider interface {
+	JWKS() JWKS
+}
+
+func (maker *AsymmetricJWTMaker) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range maker.keys {
+		jwk := JWK{Use: "sig", Algorithm: key.method.Alg(), KeyID: key.ID}
+
+		switch pub := key.PrivateKey.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = b64.EncodeToString(pub.N.Bytes())
+			jwk.E = b64.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = b64.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
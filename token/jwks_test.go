@@ -0,0 +1,304 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func writeKeyPEM(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestAsymmetricJWTMaker(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  crypto.Signer
+		alg  string
+	}{
+		{"RS256", newRSAKey(t), "RS256"},
+		{"EdDSA", newEd25519Key(t), "EdDSA"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewAsymmetricJWTMaker([]SigningKey{{ID: "k1", PrivateKey: tc.key}})
+			require.NoError(t, err)
+
+			userID := uuid.New()
+			token, err := maker.CreateToken(userID, time.Minute)
+			require.NoError(t, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, parsed.Method.Alg())
+			require.Equal(t, "k1", parsed.Header["kid"])
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, userID, payload.UserID)
+			require.WithinDuration(t, time.Now().Add(time.Minute), payload.ExpiredAt, time.Second)
+			require.False(t, payload.Challenge)
+
+			token, err = maker.CreateChallengeToken(userID, time.Minute)
+			require.NoError(t, err)
+			payload, err = maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.True(t, payload.Challenge)
+
+			token, err = maker.CreateToken(userID, -time.Minute)
+			require.NoError(t, err)
+			payload, err = maker.VerifyToken(token)
+			require.EqualError(t, err, ExpiredTokenErr.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+// TestAsymmetricJWTRegisteredClaims checks tokens the way another service
+// would, with only the public key and standard claim checks.
+func TestAsymmetricJWTRegisteredClaims(t *testing.T) {
+	key := newEd25519Key(t)
+	maker, err := NewAsymmetricJWTMaker([]SigningKey{{ID: "k1", PrivateKey: key}})
+	require.NoError(t, err)
+
+	parse := func(token string) (jwt.MapClaims, error) {
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+			return key.Public(), nil
+		})
+		return claims, err
+	}
+
+	userID := uuid.New()
+	token, err := maker.CreateToken(userID, time.Minute)
+	require.NoError(t, err)
+
+	claims, err := parse(token)
+	require.NoError(t, err)
+	require.Equal(t, userID.String(), claims["sub"])
+	require.Equal(t, JWTIssuer, claims["iss"])
+	require.NotEmpty(t, claims["jti"])
+	require.NotZero(t, claims["iat"])
+	require.InDelta(t, time.Now().Add(time.Minute).Unix(), claims["exp"], 1)
+	require.True(t, claims.VerifyAudience(AccessAudience, true))
+
+	challenge, err := maker.CreateChallengeToken(userID, time.Minute)
+	require.NoError(t, err)
+
+	claims, err = parse(challenge)
+	require.NoError(t, err)
+	require.False(t, claims.VerifyAudience(AccessAudience, true))
+	require.True(t, claims.VerifyAudience(ChallengeAudience, true))
+
+	expired, err := maker.CreateToken(userID, -time.Minute)
+	require.NoError(t, err)
+
+	_, err = parse(expired)
+	var verr *jwt.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.NotZero(t, verr.Errors&jwt.ValidationErrorExpired)
+}
+
+func TestAsymmetricJWTInvalidTokens(t *testing.T) {
+	key := newEd25519Key(t)
+	maker, err := NewAsymmetricJWTMaker([]SigningKey{{ID: "k1", PrivateKey: key}})
+	require.NoError(t, err)
+
+	other, err := NewAsymmetricJWTMaker([]SigningKey{{ID: "k1", PrivateKey: newEd25519Key(t)}})
+	require.NoError(t, err)
+
+	payload, err := NewPayload(uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	hmacSecret := []byte(key.Public().(ed25519.PublicKey))
+	hs256 := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	hs256.Header["kid"] = "k1"
+	hs256Token, err := hs256.SignedString(hmacSecret)
+	require.NoError(t, err)
+
+	none := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	none.Header["kid"] = "k1"
+	noneToken, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	unknownKid := jwt.NewWithClaims(jwt.SigningMethodEdDSA, payload)
+	unknownKid.Header["kid"] = "k2"
+	unknownKidToken, err := unknownKid.SignedString(key)
+	require.NoError(t, err)
+
+	otherToken, err := other.CreateToken(uuid.New(), time.Minute)
+	require.NoError(t, err)
+
+	// a correctly signed payload without the registered claims is refused
+	bare := jwt.NewWithClaims(jwt.SigningMethodEdDSA, payload)
+	bare.Header["kid"] = "k1"
+	bareToken, err := bare.SignedString(key)
+	require.NoError(t, err)
+
+	for _, token := range []string{hs256Token, noneToken, unknownKidToken, otherToken, bareToken, "garbage"} {
+		payload, err := maker.VerifyToken(token)
+		require.EqualError(t, err, InvalidTokenError.Error())
+		require.Nil(t, payload)
+	}
+}
+
+func TestAsymmetricJWTRotation(t *testing.T) {
+	now := time.Now()
+	oldKey := SigningKey{ID: "old", PrivateKey: newRSAKey(t)}
+	newKey := SigningKey{ID: "new", PrivateKey: newEd25519Key(t), ActiveFrom: now.Add(time.Hour)}
+
+	m, err := NewAsymmetricJWTMaker([]SigningKey{oldKey, newKey})
+	require.NoError(t, err)
+	maker := m.(*AsymmetricJWTMaker)
+
+	kid := func(token string) interface{} {
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+		require.NoError(t, err)
+		return parsed.Header["kid"]
+	}
+
+	oldToken, err := maker.CreateToken(uuid.New(), 2*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "old", kid(oldToken))
+
+	// both keys are published before the scheduled one starts signing
+	require.Len(t, maker.JWKS().Keys, 2)
+
+	maker.now = func() time.Time { return now.Add(2 * time.Hour) }
+	newToken, err := maker.CreateToken(uuid.New(), time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "new", kid(newToken))
+
+	_, err = maker.VerifyToken(oldToken)
+	require.NoError(t, err)
+	_, err = maker.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	// once the old key is dropped from the ring its tokens stop verifying
+	rotated, err := NewAsymmetricJWTMaker([]SigningKey{{ID: "new", PrivateKey: newKey.PrivateKey}})
+	require.NoError(t, err)
+	_, err = rotated.VerifyToken(oldToken)
+	require.EqualError(t, err, InvalidTokenError.Error())
+	_, err = rotated.VerifyToken(newToken)
+	require.NoError(t, err)
+}
+
+func TestNewAsymmetricJWTMakerErrors(t *testing.T) {
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	key := newEd25519Key(t)
+
+	testCases := []struct {
+		name string
+		keys []SigningKey
+	}{
+		{"NoKeys", nil},
+		{"DuplicateID", []SigningKey{{ID: "k1", PrivateKey: key}, {ID: "k1", PrivateKey: newEd25519Key(t)}}},
+		{"WeakRSA", []SigningKey{{ID: "k1", PrivateKey: smallRSA}}},
+		{"NoneActive", []SigningKey{{ID: "k1", PrivateKey: key, ActiveFrom: time.Now().Add(time.Hour)}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewAsymmetricJWTMaker(tc.keys)
+			require.Error(t, err)
+			require.Nil(t, maker)
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	edKey := newEd25519Key(t)
+
+	maker, err := NewAsymmetricJWTMaker([]SigningKey{
+		{ID: "rsa", PrivateKey: rsaKey},
+		{ID: "ed", PrivateKey: edKey},
+	})
+	require.NoError(t, err)
+
+	set := maker.(KeySetProvider).JWKS()
+	require.Len(t, set.Keys, 2)
+
+	rsaJWK := set.Keys[0]
+	require.Equal(t, JWK{KeyType: "RSA", Use: "sig", Algorithm: "RS256", KeyID: "rsa", N: rsaJWK.N, E: "AQAB"}, rsaJWK)
+	n, err := b64.DecodeString(rsaJWK.N)
+	require.NoError(t, err)
+	require.Zero(t, new(big.Int).SetBytes(n).Cmp(rsaKey.N))
+
+	edJWK := set.Keys[1]
+	require.Equal(t, "OKP", edJWK.KeyType)
+	require.Equal(t, "Ed25519", edJWK.Curve)
+	require.Equal(t, "EdDSA", edJWK.Algorithm)
+	require.Equal(t, b64.EncodeToString(edKey.Public().(ed25519.PublicKey)), edJWK.X)
+}
+
+func TestParseSigningKeys(t *testing.T) {
+	rsaPath := writeKeyPEM(t, newRSAKey(t))
+	edPath := writeKeyPEM(t, newEd25519Key(t))
+
+	pkcs1Path := filepath.Join(t.TempDir(), "rsa.pem")
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(newRSAKey(t))})
+	require.NoError(t, os.WriteFile(pkcs1Path, pkcs1, 0600))
+
+	keys, err := ParseSigningKeys(fmt.Sprintf("a:%s, b:%s@2030-01-02T03:04:05Z,c:%s", rsaPath, edPath, pkcs1Path))
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	require.Equal(t, "a", keys[0].ID)
+	require.IsType(t, &rsa.PrivateKey{}, keys[0].PrivateKey)
+	require.True(t, keys[0].ActiveFrom.IsZero())
+
+	require.Equal(t, "b", keys[1].ID)
+	require.IsType(t, ed25519.PrivateKey{}, keys[1].PrivateKey)
+	require.Equal(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), keys[1].ActiveFrom)
+
+	require.IsType(t, &rsa.PrivateKey{}, keys[2].PrivateKey)
+
+	garbagePath := filepath.Join(t.TempDir(), "garbage.pem")
+	require.NoError(t, os.WriteFile(garbagePath, []byte("not a key"), 0600))
+
+	for _, spec := range []string{
+		"",
+		"a",
+		":" + rsaPath,
+		"a:" + rsaPath + "@tomorrow",
+		"a:/does/not/exist.pem",
+		"a:" + garbagePath,
+	} {
+		_, err := ParseSigningKeys(spec)
+		require.Error(t, err, spec)
+	}
+}
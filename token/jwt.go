@@ -29,6 +29,20 @@ func (maker *JWTCreator) CreateToken(userID uuid.UUID, duration time.Duration) (
 		return "", err
 	}
 
+	return maker.sign(payload)
+}
+
+func (maker *JWTCreator) CreateChallengeToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	payload.Challenge = true
+
+	return maker.sign(payload)
+}
+
+func (maker *JWTCreator) sign(payload *Payload) (string, error) {
 	jwt := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
 	return jwt.SignedString([]byte(maker.secretKey))
 }
@@ -33,6 +33,21 @@ func TestJWTCreator(t *testing.T) {
 	require.Equal(t, userID, payload.UserID)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+	require.False(t, payload.Challenge)
+}
+
+func TestJWTChallengeToken(t *testing.T) {
+	maker, err := NewJWTCreator(util.RandomString(32))
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	token, err := maker.CreateChallengeToken(userID, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, userID, payload.UserID)
+	require.True(t, payload.Challenge)
 }
 
 func TestExpiredJWTToken(t *testing.T) {
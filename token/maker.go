@@ -8,5 +8,8 @@ import (
 
 type Maker interface {
 	CreateToken(userID uuid.UUID, duration time.Duration) (string, error)
+	// CreateChallengeToken mints a token that only proves the password step
+	// of a two factor login passed. It is not accepted as an access token.
+	CreateChallengeToken(userID uuid.UUID, duration time.Duration) (string, error)
 	VerifyToken(token string) (*Payload, error)
 }
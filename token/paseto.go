@@ -0,0 +1,352 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+)
+
+const (
+	pasetoLocalHeader  = "v4.local."
+	pasetoPublicHeader = "v4.public."
+	pasetoKeySize      = 32
+)
+
+var b64 = base64.RawURLEncoding
+
+// Key is one entry of a key ring. ID is written to the token footer so
+// tokens minted before a rotation can still be verified.
+type Key struct {
+	ID       string
+	Material []byte
+}
+
+// ParseKeys reads keys formatted as "kid:hexkey,kid:hexkey". The first key
+// mints new tokens, the rest are only used to verify.
+func ParseKeys(spec string) ([]Key, error) {
+	var keys []Key
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, material, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("Invalid key %q: expected kid:hexkey", entry)
+		}
+
+		b, err := hex.DecodeString(material)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid key %q: %w", id, err)
+		}
+		keys = append(keys, Key{ID: id, Material: b})
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("At least one key is required")
+	}
+	return keys, nil
+}
+
+type keyRing struct {
+	current string
+	keys    map[string][]byte
+}
+
+func newKeyRing(keys []Key) (keyRing, error) {
+	if len(keys) == 0 {
+		return keyRing{}, fmt.Errorf("At least one key is required")
+	}
+
+	ring := keyRing{current: keys[0].ID, keys: map[string][]byte{}}
+	for _, key := range keys {
+		if len(key.Material) != pasetoKeySize {
+			return keyRing{}, fmt.Errorf("Invalid key length for %s: must be %d bytes", key.ID, pasetoKeySize)
+		}
+		if _, ok := ring.keys[key.ID]; ok {
+			return keyRing{}, fmt.Errorf("Duplicate key id: %s", key.ID)
+		}
+		ring.keys[key.ID] = key.Material
+	}
+	return ring, nil
+}
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// splitToken returns the decoded body and raw footer of a token with the
+// expected header along with the key the footer names.
+func (ring keyRing) splitToken(token, header string) ([]byte, []byte, []byte, error) {
+	if !strings.HasPrefix(token, header) {
+		return nil, nil, nil, InvalidTokenError
+	}
+
+	parts := strings.Split(token[len(header):], ".")
+	if len(parts) != 2 {
+		return nil, nil, nil, InvalidTokenError
+	}
+
+	body, err := b64.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, nil, InvalidTokenError
+	}
+
+	footer, err := b64.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, nil, InvalidTokenError
+	}
+
+	var f pasetoFooter
+	if err := json.Unmarshal(footer, &f); err != nil {
+		return nil, nil, nil, InvalidTokenError
+	}
+
+	key, ok := ring.keys[f.KeyID]
+	if !ok {
+		return nil, nil, nil, InvalidTokenError
+	}
+	return body, footer, key, nil
+}
+
+func (ring keyRing) footer() []byte {
+	footer, _ := json.Marshal(pasetoFooter{KeyID: ring.current})
+	return footer
+}
+
+// pae is the pre-authentication encoding every PASETO MAC and signature is
+// computed over.
+func pae(pieces ...[]byte) []byte {
+	le64 := func(n int) []byte {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(n)&^(1<<63))
+		return b[:]
+	}
+
+	out := le64(len(pieces))
+	for _, piece := range pieces {
+		out = append(out, le64(len(piece))...)
+		out = append(out, piece...)
+	}
+	return out
+}
+
+func verifyPayload(message []byte) (*Payload, error) {
+	var payload Payload
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return nil, InvalidTokenError
+	}
+
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// PasetoLocalMaker issues v4.local tokens, encrypted and authenticated with
+// a shared key.
+type PasetoLocalMaker struct {
+	ring keyRing
+}
+
+func NewPasetoLocalMaker(keys []Key) (Maker, error) {
+	ring, err := newKeyRing(keys)
+	if err != nil {
+		return nil, err
+	}
+	return &PasetoLocalMaker{ring}, nil
+}
+
+func (maker *PasetoLocalMaker) CreateToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	return maker.encrypt(payload)
+}
+
+func (maker *PasetoLocalMaker) CreateChallengeToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	payload.Challenge = true
+
+	return maker.encrypt(payload)
+}
+
+func localKeys(key, nonce []byte) ([]byte, []byte, []byte, error) {
+	encHash, err := blake2b.New(56, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	encHash.Write([]byte("paseto-encryption-key"))
+	encHash.Write(nonce)
+	tmp := encHash.Sum(nil)
+
+	authHash, err := blake2b.New(32, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	authHash.Write([]byte("paseto-auth-key-for-aead"))
+	authHash.Write(nonce)
+
+	return tmp[:32], tmp[32:], authHash.Sum(nil), nil
+}
+
+func localTag(authKey []byte, pieces ...[]byte) ([]byte, error) {
+	mac, err := blake2b.New(32, authKey)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(pae(pieces...))
+	return mac.Sum(nil), nil
+}
+
+func (maker *PasetoLocalMaker) encrypt(payload *Payload) (string, error) {
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return encryptLocal(maker.ring.keys[maker.ring.current], nonce, message, maker.ring.footer())
+}
+
+func encryptLocal(key, nonce, message, footer []byte) (string, error) {
+	encKey, counterNonce, authKey, err := localKeys(key, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	cipher, err := chacha20.NewUnauthenticatedCipher(encKey, counterNonce)
+	if err != nil {
+		return "", err
+	}
+	ciphertext := make([]byte, len(message))
+	cipher.XORKeyStream(ciphertext, message)
+
+	tag, err := localTag(authKey, []byte(pasetoLocalHeader), nonce, ciphertext, footer, nil)
+	if err != nil {
+		return "", err
+	}
+
+	body := append(append(append([]byte{}, nonce...), ciphertext...), tag...)
+	token := pasetoLocalHeader + b64.EncodeToString(body)
+	if len(footer) > 0 {
+		token += "." + b64.EncodeToString(footer)
+	}
+	return token, nil
+}
+
+func (maker *PasetoLocalMaker) VerifyToken(token string) (*Payload, error) {
+	body, footer, key, err := maker.ring.splitToken(token, pasetoLocalHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) < 64 {
+		return nil, InvalidTokenError
+	}
+	nonce, ciphertext, tag := body[:32], body[32:len(body)-32], body[len(body)-32:]
+
+	encKey, counterNonce, authKey, err := localKeys(key, nonce)
+	if err != nil {
+		return nil, InvalidTokenError
+	}
+
+	expected, err := localTag(authKey, []byte(pasetoLocalHeader), nonce, ciphertext, footer, nil)
+	if err != nil || subtle.ConstantTimeCompare(expected, tag) != 1 {
+		return nil, InvalidTokenError
+	}
+
+	cipher, err := chacha20.NewUnauthenticatedCipher(encKey, counterNonce)
+	if err != nil {
+		return nil, InvalidTokenError
+	}
+	message := make([]byte, len(ciphertext))
+	cipher.XORKeyStream(message, ciphertext)
+
+	return verifyPayload(message)
+}
+
+// PasetoPublicMaker issues v4.public tokens signed with Ed25519. Key
+// material is the 32 byte private key seed.
+type PasetoPublicMaker struct {
+	ring keyRing
+}
+
+func NewPasetoPublicMaker(keys []Key) (Maker, error) {
+	ring, err := newKeyRing(keys)
+	if err != nil {
+		return nil, err
+	}
+	return &PasetoPublicMaker{ring}, nil
+}
+
+func (maker *PasetoPublicMaker) CreateToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	return maker.sign(payload)
+}
+
+func (maker *PasetoPublicMaker) CreateChallengeToken(userID uuid.UUID, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, duration)
+	if err != nil {
+		return "", err
+	}
+	payload.Challenge = true
+
+	return maker.sign(payload)
+}
+
+func (maker *PasetoPublicMaker) sign(payload *Payload) (string, error) {
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	footer := maker.ring.footer()
+	privateKey := ed25519.NewKeyFromSeed(maker.ring.keys[maker.ring.current])
+	sig := ed25519.Sign(privateKey, pae([]byte(pasetoPublicHeader), message, footer, nil))
+
+	body := append(message, sig...)
+	return pasetoPublicHeader + b64.EncodeToString(body) + "." + b64.EncodeToString(footer), nil
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	body, footer, key, err := maker.ring.splitToken(token, pasetoPublicHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) < ed25519.SignatureSize {
+		return nil, InvalidTokenError
+	}
+	message, sig := body[:len(body)-ed25519.SignatureSize], body[len(body)-ed25519.SignatureSize:]
+
+	publicKey := ed25519.NewKeyFromSeed(key).Public().(ed25519.PublicKey)
+	if !ed25519.Verify(publicKey, pae([]byte(pasetoPublicHeader), message, footer, nil), sig) {
+		return nil, InvalidTokenError
+	}
+
+	return verifyPayload(message)
+}
@@ -0,0 +1,198 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomKey(t *testing.T, id string) Key {
+	material := make([]byte, pasetoKeySize)
+	_, err := rand.Read(material)
+	require.NoError(t, err)
+	return Key{ID: id, Material: material}
+}
+
+var pasetoMakers = map[string]func(keys []Key) (Maker, error){
+	"local":  NewPasetoLocalMaker,
+	"public": NewPasetoPublicMaker,
+}
+
+// TestPasetoLocalVector checks the key derivation and encryption against
+// test vector 4-E-1 from the PASETO spec.
+func TestPasetoLocalVector(t *testing.T) {
+	key, err := hex.DecodeString("707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f")
+	require.NoError(t, err)
+
+	message := []byte(`{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`)
+	token, err := encryptLocal(key, make([]byte, 32), message, nil)
+	require.NoError(t, err)
+	require.Equal(t, "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvSwscFlAl1pk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XJ5hOb_4v9RmDkneN0S92dx0OW4pgy7omxgf3S8c3LlQg", token)
+}
+
+func TestPasetoMaker(t *testing.T) {
+	for name, newMaker := range pasetoMakers {
+		t.Run(name, func(t *testing.T) {
+			maker, err := newMaker([]Key{randomKey(t, "k1")})
+			require.NoError(t, err)
+
+			userID := uuid.New()
+			issuedAt := time.Now()
+
+			token, err := maker.CreateToken(userID, time.Minute)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(token, "v4."+name+"."))
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotZero(t, payload.ID)
+			require.Equal(t, userID, payload.UserID)
+			require.False(t, payload.Challenge)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, issuedAt.Add(time.Minute), payload.ExpiredAt, time.Second)
+
+			challenge, err := maker.CreateChallengeToken(userID, time.Minute)
+			require.NoError(t, err)
+			payload, err = maker.VerifyToken(challenge)
+			require.NoError(t, err)
+			require.True(t, payload.Challenge)
+		})
+	}
+}
+
+func TestExpiredPasetoToken(t *testing.T) {
+	for name, newMaker := range pasetoMakers {
+		t.Run(name, func(t *testing.T) {
+			maker, err := newMaker([]Key{randomKey(t, "k1")})
+			require.NoError(t, err)
+
+			token, err := maker.CreateToken(uuid.New(), -time.Minute)
+			require.NoError(t, err)
+
+			payload, err := maker.VerifyToken(token)
+			require.EqualError(t, err, ExpiredTokenErr.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestInvalidPasetoToken(t *testing.T) {
+	for name, newMaker := range pasetoMakers {
+		t.Run(name, func(t *testing.T) {
+			key := randomKey(t, "k1")
+			maker, err := newMaker([]Key{key})
+			require.NoError(t, err)
+
+			token, err := maker.CreateToken(uuid.New(), time.Minute)
+			require.NoError(t, err)
+
+			body := strings.Split(token, ".")[2]
+			flipped := []byte(body)
+			flipped[len(flipped)/2] ^= 'A' ^ 'B'
+			tampered := strings.Replace(token, body, string(flipped), 1)
+
+			otherKey := randomKey(t, "k1")
+			other, err := newMaker([]Key{otherKey})
+			require.NoError(t, err)
+
+			jwtMaker, err := NewJWTCreator(hex.EncodeToString(key.Material))
+			require.NoError(t, err)
+			jwt, err := jwtMaker.CreateToken(uuid.New(), time.Minute)
+			require.NoError(t, err)
+
+			cases := map[string]string{
+				"Tampered":  tampered,
+				"NoFooter":  strings.Join(strings.Split(token, ".")[:3], "."),
+				"BadFooter": token + "x",
+				"JWT":       jwt,
+				"Empty":     "",
+			}
+			for caseName, bad := range cases {
+				payload, err := maker.VerifyToken(bad)
+				require.EqualError(t, err, InvalidTokenError.Error(), caseName)
+				require.Nil(t, payload)
+			}
+
+			payload, err := other.VerifyToken(token)
+			require.EqualError(t, err, InvalidTokenError.Error())
+			require.Nil(t, payload)
+		})
+	}
+}
+
+func TestPasetoPurposeMismatch(t *testing.T) {
+	keys := []Key{randomKey(t, "k1")}
+
+	local, err := NewPasetoLocalMaker(keys)
+	require.NoError(t, err)
+	public, err := NewPasetoPublicMaker(keys)
+	require.NoError(t, err)
+
+	token, err := local.CreateToken(uuid.New(), time.Minute)
+	require.NoError(t, err)
+	_, err = public.VerifyToken(token)
+	require.EqualError(t, err, InvalidTokenError.Error())
+
+	token, err = public.CreateToken(uuid.New(), time.Minute)
+	require.NoError(t, err)
+	_, err = local.VerifyToken(token)
+	require.EqualError(t, err, InvalidTokenError.Error())
+}
+
+func TestPasetoKeyRotation(t *testing.T) {
+	for name, newMaker := range pasetoMakers {
+		t.Run(name, func(t *testing.T) {
+			oldKey := randomKey(t, "2023-01")
+			newKey := randomKey(t, "2023-02")
+
+			before, err := newMaker([]Key{oldKey})
+			require.NoError(t, err)
+			oldToken, err := before.CreateToken(uuid.New(), time.Minute)
+			require.NoError(t, err)
+
+			rotated, err := newMaker([]Key{newKey, oldKey})
+			require.NoError(t, err)
+
+			_, err = rotated.VerifyToken(oldToken)
+			require.NoError(t, err)
+
+			newToken, err := rotated.CreateToken(uuid.New(), time.Minute)
+			require.NoError(t, err)
+			require.Contains(t, newToken, "."+b64.EncodeToString([]byte(`{"kid":"2023-02"}`)))
+
+			_, err = before.VerifyToken(newToken)
+			require.EqualError(t, err, InvalidTokenError.Error())
+
+			retired, err := newMaker([]Key{newKey})
+			require.NoError(t, err)
+			_, err = retired.VerifyToken(oldToken)
+			require.EqualError(t, err, InvalidTokenError.Error())
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	k1, k2 := randomKey(t, "k1"), randomKey(t, "k2")
+	spec := fmt.Sprintf("k1:%s, k2:%s", hex.EncodeToString(k1.Material), hex.EncodeToString(k2.Material))
+
+	keys, err := ParseKeys(spec)
+	require.NoError(t, err)
+	require.Equal(t, []Key{k1, k2}, keys)
+
+	for _, bad := range []string{"", "nokid", ":abcd", "k1:zz"} {
+		_, err := ParseKeys(bad)
+		require.Error(t, err, bad)
+	}
+
+	_, err = NewPasetoLocalMaker([]Key{{ID: "short", Material: []byte("short")}})
+	require.Error(t, err)
+
+	_, err = NewPasetoLocalMaker([]Key{k1, k1})
+	require.Error(t, err)
+}
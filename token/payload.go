@@ -12,6 +12,7 @@ type Payload struct {
 	UserID    uuid.UUID `json:"user_id"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
+	Challenge bool      `json:"challenge,omitempty"`
 }
 
 var (
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/spf13/viper"
@@ -11,7 +13,79 @@ type Config struct {
 	DBSource       string        `mapstructure:"DB_SOURCE"`
 	ServerAddress  string        `mapstructure:"SERVER_ADDRESS"`
 	SecretKey      string        `mapstructure:"SECRET_KEY"`
+	TokenType      string        `mapstructure:"TOKEN_TYPE"`
+	PasetoKeys     string        `mapstructure:"PASETO_KEYS"`
+	JWTSigningKeys string        `mapstructure:"JWT_SIGNING_KEYS"`
 	AccessDuration time.Duration `mapstructure:"ACCESS_DURATION"`
+
+	RevocationStore    string        `mapstructure:"REVOCATION_STORE"`
+	RevocationCacheTTL time.Duration `mapstructure:"REVOCATION_CACHE_TTL"`
+	SeedCatalog        bool          `mapstructure:"SEED_CATALOG"`
+
+	MailDriver   string `mapstructure:"MAIL_DRIVER"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+	MailDir      string `mapstructure:"MAIL_DIR"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+
+	PasswordResetURL      string        `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetDuration time.Duration `mapstructure:"PASSWORD_RESET_DURATION"`
+
+	EmailVerifyURL        string        `mapstructure:"EMAIL_VERIFY_URL"`
+	EmailVerifyDuration   time.Duration `mapstructure:"EMAIL_VERIFY_DURATION"`
+	EmailVerifyResendWait time.Duration `mapstructure:"EMAIL_VERIFY_RESEND_WAIT"`
+	RequireVerifiedEmail  bool          `mapstructure:"REQUIRE_VERIFIED_EMAIL"`
+
+	LoginLimiter       string        `mapstructure:"LOGIN_LIMITER"`
+	LoginMaxAttempts   int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginIPMaxAttempts int           `mapstructure:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginLockout       time.Duration `mapstructure:"LOGIN_LOCKOUT"`
+	LoginMaxLockout    time.Duration `mapstructure:"LOGIN_MAX_LOCKOUT"`
+
+	MFAIssuer            string        `mapstructure:"MFA_ISSUER"`
+	MFAChallengeDuration time.Duration `mapstructure:"MFA_CHALLENGE_DURATION"`
+
+	// OIDCProviders is a JSON list of OIDCProvider
+	OIDCProviders     string        `mapstructure:"OIDC_PROVIDERS"`
+	OIDCLoginDuration time.Duration `mapstructure:"OIDC_LOGIN_DURATION"`
+}
+
+// OIDCProvider is an OpenID Connect identity provider users can sign in
+// with. Name is the provider's path segment in the login urls.
+type OIDCProvider struct {
+	Name         string   `json:"name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	RedirectURL  string   `json:"redirect_url"`
+	Scopes       []string `json:"scopes"`
+}
+
+// ParseOIDCProviders reads the OIDC_PROVIDERS setting. An empty setting
+// means no providers are configured.
+func ParseOIDCProviders(spec string) ([]OIDCProvider, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	var providers []OIDCProvider
+	if err := json.Unmarshal([]byte(spec), &providers); err != nil {
+		return nil, fmt.Errorf("Invalid OIDC_PROVIDERS: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for _, provider := range providers {
+		if provider.Name == "" || provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			return nil, fmt.Errorf("OIDC provider %q needs a name, issuer, client_id and redirect_url", provider.Name)
+		}
+		if seen[provider.Name] {
+			return nil, fmt.Errorf("Duplicate OIDC provider: %s", provider.Name)
+		}
+		seen[provider.Name] = true
+	}
+	return providers, nil
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig("..")
+	require.NoError(t, err)
+	require.NotEmpty(t, config.DBSource)
+}
+
+func TestParseOIDCProviders(t *testing.T) {
+	providers, err := ParseOIDCProviders("")
+	require.NoError(t, err)
+	require.Empty(t, providers)
+
+	providers, err = ParseOIDCProviders(`[{"name":"acme","issuer":"https://id.acme.test","client_id":"lift","client_secret":"s3cret","redirect_url":"https://lift.test/user/oidc/acme/callback","scopes":["openid","email"]}]`)
+	require.NoError(t, err)
+	require.Equal(t, []OIDCProvider{{
+		Name:         "acme",
+		Issuer:       "https://id.acme.test",
+		ClientID:     "lift",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://lift.test/user/oidc/acme/callback",
+		Scopes:       []string{"openid", "email"},
+	}}, providers)
+
+	for _, spec := range []string{
+		`not json`,
+		`[{"name":"acme"}]`,
+		`[{"name":"a","issuer":"i","client_id":"c","redirect_url":"r"},{"name":"a","issuer":"i","client_id":"c","redirect_url":"r"}]`,
+	} {
+		_, err := ParseOIDCProviders(spec)
+		require.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,129 @@
+package util
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+const DefaultBarWeight float32 = 45
+
+var DefaultPlates = map[float32]int16{
+	45:  4,
+	35:  1,
+	25:  1,
+	10:  2,
+	5:   1,
+	2.5: 1,
+}
+
+var ErrTargetBelowBar = errors.New("Target weight must be greater than or equal to the bar weight")
+
+type Plate struct {
+	Weight float32 `json:"weight"`
+	Count  int16   `json:"count"`
+}
+
+type PlateLoad struct {
+	Target    float32 `json:"target"`
+	BarWeight float32 `json:"bar_weight"`
+	Achieved  float32 `json:"achieved"`
+	PerSide   []Plate `json:"per_side"`
+	Remainder float32 `json:"remainder"`
+}
+
+// CalculatePlates loads the heaviest available plates first. The inventory maps
+// a plate weight to the number of pairs that are available. When the target
+// can't be reached exactly, Achieved holds the closest lighter load.
+func CalculatePlates(target float32, barWeight float32, inventory map[float32]int16) (PlateLoad, error) {
+	if target < barWeight {
+		return PlateLoad{}, ErrTargetBelowBar
+	}
+
+	load := PlateLoad{
+		Target:    target,
+		BarWeight: barWeight,
+		PerSide:   []Plate{},
+	}
+
+	remaining := (target - barWeight) / 2
+	for _, weight := range sortedPlates(inventory) {
+		count := int16(remaining / weight)
+		if count > inventory[weight] {
+			count = inventory[weight]
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		load.PerSide = append(load.PerSide, Plate{Weight: weight, Count: count})
+		remaining -= weight * float32(count)
+	}
+
+	load.Remainder = remaining * 2
+	load.Achieved = target - load.Remainder
+	return load, nil
+}
+
+type WarmupSet struct {
+	Weight float32 `json:"weight"`
+	Reps   int16   `json:"reps"`
+}
+
+var warmupRamp = []struct {
+	percent float32
+	reps    int16
+}{
+	{0.4, 5},
+	{0.6, 3},
+	{0.8, 2},
+}
+
+// WarmupSets builds a ramp from the empty bar up to the working weight. Each
+// step is rounded down to a weight that can be loaded with pairs of the
+// smallest plate in the inventory.
+func WarmupSets(working float32, barWeight float32, inventory map[float32]int16) []WarmupSet {
+	sets := []WarmupSet{}
+	if working <= barWeight {
+		return sets
+	}
+
+	sets = append(sets, WarmupSet{Weight: barWeight, Reps: 10})
+
+	step := smallestPlate(inventory) * 2
+	for _, ramp := range warmupRamp {
+		weight := working * ramp.percent
+		if step > 0 {
+			weight = barWeight + float32(math.Floor(float64((weight-barWeight)/step)))*step
+		}
+
+		if weight <= sets[len(sets)-1].Weight || weight >= working {
+			continue
+		}
+
+		sets = append(sets, WarmupSet{Weight: weight, Reps: ramp.reps})
+	}
+
+	return sets
+}
+
+func sortedPlates(inventory map[float32]int16) []float32 {
+	weights := make([]float32, 0, len(inventory))
+	for weight, pairs := range inventory {
+		if weight > 0 && pairs > 0 {
+			weights = append(weights, weight)
+		}
+	}
+
+	sort.Slice(weights, func(i, j int) bool { return weights[i] > weights[j] })
+	return weights
+}
+
+func smallestPlate(inventory map[float32]int16) float32 {
+	weights := sortedPlates(inventory)
+	if len(weights) == 0 {
+		return 0
+	}
+	return weights[len(weights)-1]
+}
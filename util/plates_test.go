@@ -0,0 +1,46 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculatePlates(t *testing.T) {
+	load, err := CalculatePlates(225, DefaultBarWeight, DefaultPlates)
+	require.NoError(t, err)
+	require.Equal(t, float32(225), load.Achieved)
+	require.Zero(t, load.Remainder)
+	require.Equal(t, []Plate{{Weight: 45, Count: 2}}, load.PerSide)
+
+	load, err = CalculatePlates(300, DefaultBarWeight, DefaultPlates)
+	require.NoError(t, err)
+	require.Equal(t, float32(300), load.Achieved)
+	require.Equal(t, []Plate{{Weight: 45, Count: 2}, {Weight: 35, Count: 1}, {Weight: 2.5, Count: 1}}, load.PerSide)
+
+	load, err = CalculatePlates(140, 45, map[float32]int16{45: 1})
+	require.NoError(t, err)
+	require.Equal(t, float32(135), load.Achieved)
+	require.Equal(t, float32(5), load.Remainder)
+
+	_, err = CalculatePlates(30, DefaultBarWeight, DefaultPlates)
+	require.ErrorIs(t, err, ErrTargetBelowBar)
+}
+
+func TestWarmupSets(t *testing.T) {
+	sets := WarmupSets(225, DefaultBarWeight, DefaultPlates)
+	require.Equal(t, []WarmupSet{
+		{Weight: 45, Reps: 10},
+		{Weight: 90, Reps: 5},
+		{Weight: 135, Reps: 3},
+		{Weight: 180, Reps: 2},
+	}, sets)
+
+	for _, set := range sets {
+		load, err := CalculatePlates(set.Weight, DefaultBarWeight, DefaultPlates)
+		require.NoError(t, err)
+		require.Zero(t, load.Remainder)
+	}
+
+	require.Empty(t, WarmupSets(DefaultBarWeight, DefaultBarWeight, DefaultPlates))
+}
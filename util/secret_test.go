@@ -0,0 +1,23 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecret(t *testing.T) {
+	a, err := NewSecret(32)
+	require.NoError(t, err)
+	require.Len(t, a, 43)
+
+	b, err := NewSecret(32)
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+}
+
+func TestHashSecret(t *testing.T) {
+	require.Equal(t, HashSecret("abc"), HashSecret("abc"))
+	require.NotEqual(t, HashSecret("abc"), HashSecret("abd"))
+	require.Len(t, HashSecret("abc"), 64)
+}
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+	digitsOnly   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Slugify mirrors the slugify function in the database so that a name and
+// its slug resolve to the same catalog row.
+func Slugify(s string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// ValidSlug reports whether the database accepts slug for a catalog row.
+// Empty slugs and slugs that are only digits, which would read as ids, are
+// refused.
+func ValidSlug(slug string) bool {
+	return slug != "" && !digitsOnly.MatchString(slug)
+}
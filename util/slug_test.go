@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	require.Equal(t, "bench-press", Slugify("Bench Press"))
+	require.Equal(t, "t-bar-row", Slugify("  T-Bar   Row! "))
+	require.Equal(t, "21-s", Slugify("21's"))
+	require.Empty(t, Slugify("--"))
+}
+
+func TestValidSlug(t *testing.T) {
+	require.True(t, ValidSlug("bench-press"))
+	require.True(t, ValidSlug("21-s"))
+	require.False(t, ValidSlug(""))
+	require.False(t, ValidSlug("100"))
+}